@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/sessions"
+)
+
+// statusPage renders the active dial-out sessions
+var statusPage = template.Must(template.New("status").Funcs(template.FuncMap{
+	"ago": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return time.Since(t).Truncate(time.Second).String() + " ago"
+	},
+}).Parse(`<html>
+<head>
+<title>Peppamon Cisco Telemetry Exporter</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; font-size: 13px; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>Peppamon Cisco Telemetry Exporter</h1>
+<p>
+<a href="/metrics">Metrics</a> |
+<a href="/api/sessions">Sessions API</a> |
+<a href="/api/unsupported-paths">Unsupported YANG Paths API</a> |
+<a href="/api/devices">Devices API</a> |
+<a href="/api/registry/devices">Device Registry API</a> |
+<a href="/api/config">Configuration</a>
+</p>
+<h2>Telemetry Dial-Out Sessions ({{len .}})</h2>
+{{if .}}
+<table>
+<tr>
+<th>Node</th><th>Peer</th><th>Subscriptions</th><th>YANG Paths</th><th>Connected</th>
+<th>Last Message</th><th>Messages</th><th>Bytes</th><th>Messages/s</th>
+</tr>
+{{range .}}
+<tr>
+<td>{{.NodeID}}</td>
+<td>{{.Peer}}</td>
+<td>{{range .Subscriptions}}{{.}}<br>{{end}}</td>
+<td>{{range .Paths}}{{.}}<br>{{end}}</td>
+<td>{{ago .ConnectedAt}}</td>
+<td>{{ago .LastMessage}}</td>
+<td>{{.Messages}}</td>
+<td>{{.Bytes}}</td>
+<td>{{printf "%.2f" .MessageRate}}</td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p>No device streaming Telemetry.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// unsupportedPathsResponse represents the payload of the unsupported YANG paths admin endpoint
+type unsupportedPathsResponse struct {
+	Policy string                         `json:"policy"`
+	Paths  []metrics.UnsupportedPathStats `json:"paths"`
+}
+
+// unsupportedPathsHandler returns the messages received per node for YANG paths without parser
+func unsupportedPathsHandler(u *metrics.UnsupportedPaths) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, unsupportedPathsResponse{Policy: u.Policy(), Paths: u.Stats()})
+	}
+}
+
+// sessionsHandler returns the active dial-out sessions
+func sessionsHandler(r *sessions.Registry) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, r.Sessions())
+	}
+}
+
+// statusPageHandler renders the status page listing the active dial-out sessions
+func statusPageHandler(r *sessions.Registry) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		if req.URL.Path != "/" {
+			http.NotFound(w, req)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if err := statusPage.Execute(w, r.Sessions()); err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to render status page %v", err)
+		}
+	}
+}
+
+// configHandler returns the effective configuration with the secrets redacted
+func configHandler(cfg *config.Config) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		b, err := cfg.Redacted().YAML()
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to render configuration %v", err)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-yaml")
+		_, _ = w.Write(b)
+	}
+}
+
+// writeJSON renders the admin endpoints payloads
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to render JSON response %v", err)
+	}
+}
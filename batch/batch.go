@@ -0,0 +1,212 @@
+// Package batch buffers the metrics samples published to the output sinks and hands them over in batches
+// with retry and exponential backoff
+package batch
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	minRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff = 30 * time.Second
+)
+
+// DrainTimeout bounds the final flush of the queued samples on shutdown
+const DrainTimeout = 5 * time.Second
+
+// SamplesCounter counts the samples processed by the output sinks by result (sent, failed, dropped)
+var SamplesCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "peppamon_sink_samples_total",
+		Help: "The number of samples processed by the output sinks by result",
+	},
+	[]string{"sink", "result"},
+)
+
+// Settings represents the batching settings of an output sink
+type Settings struct {
+	// Maximum number of samples per batch
+	BatchSize int
+
+	// Maximum time samples wait in queue before being flushed
+	FlushInterval time.Duration
+
+	// Maximum number of samples waiting to be flushed. Further samples are dropped
+	QueueSize int
+
+	// Number of retries of a failed batch before its samples are dropped
+	MaxRetries int
+}
+
+// SettingsFromConfig builds the batching settings of an output sink from its configuration section
+func SettingsFromConfig(c config.Batch) Settings {
+
+	return Settings{
+		BatchSize:     c.Size,
+		FlushInterval: c.FlushInterval,
+		QueueSize:     c.QueueSize,
+		MaxRetries:    c.MaxRetries,
+	}
+}
+
+// FlushFunc sends a batch of samples. It returns whether a failure is recoverable and the batch can be retried
+type FlushFunc func(ctx context.Context, samples []metrics.Sample) (recoverable bool, err error)
+
+// Queue buffers the samples of the published collection rounds and flushes them in batches.
+// It implements the metrics.Sink interface.
+type Queue struct {
+	name     string
+	settings Settings
+	queue    chan metrics.Sample
+	flush    FlushFunc
+}
+
+// NewQueue returns a sink Queue. Samples are flushed once Run is started
+func NewQueue(name string, s Settings, flush FlushFunc) *Queue {
+
+	return &Queue{
+		name:     name,
+		settings: s,
+		queue:    make(chan metrics.Sample, s.QueueSize),
+		flush:    flush,
+	}
+}
+
+// Publish queues the samples of a collection round. Samples are dropped when the queue is full
+func (q *Queue) Publish(r *metrics.Round) {
+
+	var dropped int
+
+	for _, s := range r.Samples {
+		select {
+		case q.queue <- s:
+		default:
+			dropped++
+		}
+	}
+
+	if dropped > 0 {
+		SamplesCounter.WithLabelValues(q.name, "dropped").Add(float64(dropped))
+		logging.PeppaMonLog(
+			"warning",
+			"Output sink %v queue full. Dropped %d sample(s)", q.name, dropped)
+	}
+}
+
+// Remove implements the metrics.Sink interface. Samples already sent cannot be withdrawn
+func (q *Queue) Remove(src metrics.Source) {}
+
+// Run flushes the queued samples in batches until the context is cancelled, then drains the queue
+func (q *Queue) Run(ctx context.Context) {
+
+	ticker := time.NewTicker(q.settings.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]metrics.Sample, 0, q.settings.BatchSize)
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.drain(batch)
+			return
+
+		case s := <-q.queue:
+			batch = append(batch, s)
+
+			if len(batch) >= q.settings.BatchSize {
+				q.flushWithRetry(ctx, batch)
+				batch = batch[:0]
+			}
+
+		case <-ticker.C:
+			if len(batch) > 0 {
+				q.flushWithRetry(ctx, batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// drain flushes the pending batch and the queued samples within DrainTimeout
+func (q *Queue) drain(batch []metrics.Sample) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DrainTimeout)
+	defer cancel()
+
+	for {
+
+		if ctx.Err() != nil {
+			dropped := len(batch) + len(q.queue)
+
+			SamplesCounter.WithLabelValues(q.name, "dropped").Add(float64(dropped))
+			logging.PeppaMonLog(
+				"warning",
+				"Output sink %v did not drain in %v. Dropped %d sample(s)", q.name, DrainTimeout, dropped)
+			return
+		}
+
+		select {
+		case s := <-q.queue:
+			batch = append(batch, s)
+
+			if len(batch) < q.settings.BatchSize {
+				continue
+			}
+
+		default:
+			if len(batch) > 0 {
+				q.flushWithRetry(ctx, batch)
+			}
+			return
+		}
+
+		q.flushWithRetry(ctx, batch)
+		batch = batch[:0]
+	}
+}
+
+// flushWithRetry flushes a batch with exponential backoff on recoverable errors
+func (q *Queue) flushWithRetry(ctx context.Context, batch []metrics.Sample) {
+
+	backoff := minRetryBackoff
+
+	for attempt := 0; ; attempt++ {
+
+		recoverable, err := q.flush(ctx, batch)
+
+		if err == nil {
+			SamplesCounter.WithLabelValues(q.name, "sent").Add(float64(len(batch)))
+			return
+		}
+
+		if !recoverable || attempt >= q.settings.MaxRetries {
+			SamplesCounter.WithLabelValues(q.name, "failed").Add(float64(len(batch)))
+			logging.PeppaMonLog(
+				"error",
+				"Output sink %v failed to send %d sample(s): %v", q.name, len(batch), err)
+			return
+		}
+
+		logging.PeppaMonLog(
+			"warning",
+			"Output sink %v failed to send samples: %v. Retrying in %v", q.name, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			SamplesCounter.WithLabelValues(q.name, "failed").Add(float64(len(batch)))
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
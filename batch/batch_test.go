@@ -0,0 +1,209 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// recorder records the size of the flushed batches
+type recorder struct {
+	mu      sync.Mutex
+	batches []int
+
+	// Errors returned by the successive flushes
+	errs        []error
+	recoverable bool
+}
+
+func (r *recorder) flush(ctx context.Context, samples []metrics.Sample) (bool, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+
+		if err != nil {
+			return r.recoverable, err
+		}
+	}
+
+	r.batches = append(r.batches, len(samples))
+
+	return false, nil
+}
+
+func (r *recorder) flushed() []int {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]int(nil), r.batches...)
+}
+
+// counted returns the number of samples counted for the sink and result
+func counted(sink string, result string) float64 {
+	return testutil.ToFloat64(SamplesCounter.WithLabelValues(sink, result))
+}
+
+func round(n int) *metrics.Round {
+
+	r := &metrics.Round{}
+
+	for i := 0; i < n; i++ {
+		r.Samples = append(r.Samples, metrics.Sample{Name: "test_metric", Value: float64(i)})
+	}
+
+	return r
+}
+
+// runQueue runs the queue until stop is called, which waits for Run to return
+func runQueue(q *Queue) (stop func()) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		q.Run(ctx)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func waitFlushed(t *testing.T, r *recorder, samples int) {
+
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for time.Now().Before(deadline) {
+
+		var total int
+
+		for _, n := range r.flushed() {
+			total += n
+		}
+
+		if total >= samples {
+			return
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("flushed batches %v, want %d sample(s)", r.flushed(), samples)
+}
+
+func TestQueueFlushesFullBatches(t *testing.T) {
+
+	r := &recorder{}
+	q := NewQueue("test_full_batches", Settings{BatchSize: 2, FlushInterval: time.Hour, QueueSize: 10}, r.flush)
+
+	stop := runQueue(q)
+
+	q.Publish(round(4))
+	waitFlushed(t, r, 4)
+	stop()
+
+	if got := r.flushed(); len(got) != 2 || got[0] != 2 || got[1] != 2 {
+		t.Fatalf("flushed batches %v, want [2 2]", got)
+	}
+}
+
+func TestQueueFlushesOnInterval(t *testing.T) {
+
+	r := &recorder{}
+	q := NewQueue("test_interval", Settings{BatchSize: 100, FlushInterval: 10 * time.Millisecond, QueueSize: 10}, r.flush)
+
+	stop := runQueue(q)
+	defer stop()
+
+	q.Publish(round(3))
+	waitFlushed(t, r, 3)
+}
+
+func TestQueueDrainsOnShutdown(t *testing.T) {
+
+	r := &recorder{}
+	q := NewQueue("test_drain", Settings{BatchSize: 2, FlushInterval: time.Hour, QueueSize: 10}, r.flush)
+
+	q.Publish(round(5))
+
+	// Samples queued before Run are flushed even though the context is already cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	q.Run(ctx)
+
+	var total int
+
+	for _, n := range r.flushed() {
+		if n > 2 {
+			t.Fatalf("flushed batch of %d sample(s) exceeds the batch size", n)
+		}
+		total += n
+	}
+
+	if total != 5 {
+		t.Fatalf("flushed %d sample(s) on shutdown, want 5", total)
+	}
+}
+
+func TestQueueDropsWhenFull(t *testing.T) {
+
+	q := NewQueue("test_queue_full", Settings{BatchSize: 10, FlushInterval: time.Hour, QueueSize: 2}, (&recorder{}).flush)
+
+	before := counted("test_queue_full", "dropped")
+
+	q.Publish(round(5))
+
+	if got := counted("test_queue_full", "dropped") - before; got != 3 {
+		t.Fatalf("dropped %v sample(s), want 3", got)
+	}
+}
+
+func TestQueueRetriesRecoverableErrors(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		recoverable bool
+		maxRetries  int
+		wantSent    float64
+		wantFailed  float64
+	}{
+		{name: "test_retry_recoverable", recoverable: true, maxRetries: 1, wantSent: 3},
+		{name: "test_retry_permanent", recoverable: false, maxRetries: 1, wantFailed: 3},
+		{name: "test_retry_exhausted", recoverable: true, maxRetries: 0, wantFailed: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			r := &recorder{errs: []error{errors.New("unavailable")}, recoverable: tt.recoverable}
+			q := NewQueue(tt.name, Settings{BatchSize: 3, QueueSize: 3, MaxRetries: tt.maxRetries}, r.flush)
+
+			sent, failed := counted(tt.name, "sent"), counted(tt.name, "failed")
+
+			q.flushWithRetry(context.Background(), round(3).Samples)
+
+			if got := counted(tt.name, "sent") - sent; got != tt.wantSent {
+				t.Errorf("sent %v sample(s), want %v", got, tt.wantSent)
+			}
+
+			if got := counted(tt.name, "failed") - failed; got != tt.wantFailed {
+				t.Errorf("failed %v sample(s), want %v", got, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package batch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Post sends an HTTP request to an output sink endpoint and returns whether a failure is recoverable.
+// Network errors, server errors and rate limiting are recoverable.
+func Post(client *http.Client, req *http.Request) (bool, error) {
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return true, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return true, nil
+	}
+
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+
+	err = fmt.Errorf("server returned HTTP status %v: %s", resp.Status, bytes.TrimSpace(msg))
+
+	// Other client errors will fail again
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
@@ -0,0 +1,258 @@
+// Package capture records the raw MDT dial-out messages of the devices to rotating capture files
+// which can be replayed to reproduce parsing issues offline
+package capture
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	mdt_dialout "github.com/lucabrasi83/peppamon_cisco/proto/mdt_grpc_dialout"
+)
+
+const (
+	// Capture every message received
+	ModeAll = "all"
+
+	// Capture only the messages which failed decoding or whose YANG path is not supported
+	ModeUnsupported = "unsupported"
+
+	// Capture file names sort in creation order
+	fileTimeFormat = "20060102T150405.000000000Z"
+)
+
+// Settings represents the raw Telemetry capture settings
+type Settings struct {
+	// Directory holding one sub-directory of capture files per device
+	Dir string
+
+	// Messages to capture: all or unsupported
+	Mode string
+
+	// Size in bytes from which a device capture file is rotated
+	MaxFileSize int64
+
+	// Number of capture files kept per device. Oldest files are removed on rotation
+	MaxFiles int
+}
+
+// Enabled returns whether the raw Telemetry capture is configured
+func (s Settings) Enabled() bool {
+	return s.Dir != ""
+}
+
+// SettingsFromConfig builds the raw Telemetry capture settings from the capture configuration section
+func SettingsFromConfig(c config.Capture) Settings {
+
+	return Settings{
+		Dir:         c.Dir,
+		Mode:        c.Mode,
+		MaxFileSize: int64(c.MaxFileSize),
+		MaxFiles:    c.MaxFiles,
+	}
+}
+
+// Recorder appends the dial-out messages to the capture file of their device
+type Recorder struct {
+	settings Settings
+
+	mu    sync.Mutex
+	files map[string]*deviceFile
+}
+
+// deviceFile represents the capture file currently written for a device
+type deviceFile struct {
+	f    *os.File
+	w    *Writer
+	size int64
+}
+
+// NewRecorder returns a Recorder writing capture files under the settings directory
+func NewRecorder(s Settings) (*Recorder, error) {
+
+	if s.Mode != ModeAll && s.Mode != ModeUnsupported {
+		return nil, fmt.Errorf("unsupported capture mode %v", s.Mode)
+	}
+
+	if s.MaxFiles < 1 {
+		s.MaxFiles = 1
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create capture directory %v", err)
+	}
+
+	return &Recorder{
+		settings: s,
+		files:    make(map[string]*deviceFile),
+	}, nil
+}
+
+// CapturesAll returns whether every message is captured rather than the unsupported ones only
+func (r *Recorder) CapturesAll() bool {
+	return r.settings.Mode == ModeAll
+}
+
+// Record appends the dial-out message received from the device to its capture file
+func (r *Recorder) Record(device string, args *mdt_dialout.MdtDialoutArgs) error {
+
+	rec := Record{Time: time.Now(), Args: args}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	df, ok := r.files[device]
+
+	if !ok || df.size >= r.settings.MaxFileSize {
+
+		var err error
+
+		if df, err = r.rotate(device, df); err != nil {
+			return err
+		}
+	}
+
+	n, err := df.w.Write(rec)
+	df.size += int64(n)
+
+	return err
+}
+
+// rotate closes the current capture file of the device, opens a new one and removes the oldest files
+func (r *Recorder) rotate(device string, current *deviceFile) (*deviceFile, error) {
+
+	dir := filepath.Join(r.settings.Dir, sanitizeDevice(device))
+
+	if current != nil {
+		_ = current.f.Close()
+		delete(r.files, device)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create device capture directory %v", err)
+	}
+
+	name := filepath.Join(dir, time.Now().UTC().Format(fileTimeFormat)+FileExt)
+
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to create capture file %v", err)
+	}
+
+	w, n, err := NewWriter(f, device)
+
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("unable to write capture file header %v", err)
+	}
+
+	df := &deviceFile{f: f, w: w, size: int64(n)}
+	r.files[device] = df
+
+	r.removeOldFiles(dir)
+
+	return df, nil
+}
+
+// removeOldFiles keeps the MaxFiles most recent capture files of a device directory
+func (r *Recorder) removeOldFiles(dir string) {
+
+	files, err := Files(dir)
+
+	if err != nil || len(files) <= r.settings.MaxFiles {
+		return
+	}
+
+	for _, f := range files[:len(files)-r.settings.MaxFiles] {
+		_ = os.Remove(f)
+	}
+}
+
+// Close closes the capture files
+func (r *Recorder) Close() error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+
+	for device, df := range r.files {
+
+		if err := df.f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(r.files, device)
+	}
+
+	return firstErr
+}
+
+// Files returns the capture files found at the given path, walking directories recursively.
+// Files of a directory are sorted in creation order.
+func Files(path string) ([]string, error) {
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := ioutil.ReadDir(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// ReadDir sorts entries by name
+	var files []string
+
+	for _, e := range entries {
+
+		p := filepath.Join(path, e.Name())
+
+		if e.IsDir() {
+
+			sub, errSub := Files(p)
+
+			if errSub != nil {
+				return nil, errSub
+			}
+			files = append(files, sub...)
+
+			continue
+		}
+
+		if strings.HasSuffix(e.Name(), FileExt) {
+			files = append(files, p)
+		}
+	}
+
+	return files, nil
+}
+
+// sanitizeDevice restricts a device name to characters safe in a directory name
+func sanitizeDevice(device string) string {
+
+	// Names made of dots only would escape the capture directory
+	if strings.Trim(device, ".") == "" {
+		return "unknown"
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, device)
+}
@@ -0,0 +1,159 @@
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	mdt_dialout "github.com/lucabrasi83/peppamon_cisco/proto/mdt_grpc_dialout"
+)
+
+// Capture file layout. All integers are big-endian.
+//
+//	header: magic "PPMNCAP1" | uint16 device length | device
+//	record: int64 receive time (Unix nanoseconds) | uint32 payload length | marshaled MdtDialoutArgs
+const (
+	fileMagic = "PPMNCAP1"
+
+	// File name extension of the capture files
+	FileExt = ".mdtcap"
+
+	recordHeaderSize = 12
+
+	// Upper bound of a record payload to detect corrupted files
+	maxRecordSize = 64 << 20
+)
+
+// ErrInvalidFile is returned when reading a file which is not a Peppamon capture file
+var ErrInvalidFile = errors.New("not a Peppamon Telemetry capture file")
+
+// Record represents a dial-out RPC message captured at a given time
+type Record struct {
+	Time time.Time
+	Args *mdt_dialout.MdtDialoutArgs
+}
+
+// Writer writes length-prefixed records to a capture file
+type Writer struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter writes the capture file header of the device and returns a Writer appending records after it.
+// It returns the number of bytes written.
+func NewWriter(w io.Writer, device string) (*Writer, int, error) {
+
+	if len(device) > 0xffff {
+		device = device[:0xffff]
+	}
+
+	header := make([]byte, len(fileMagic)+2+len(device))
+	copy(header, fileMagic)
+	binary.BigEndian.PutUint16(header[len(fileMagic):], uint16(len(device)))
+	copy(header[len(fileMagic)+2:], device)
+
+	n, err := w.Write(header)
+
+	if err != nil {
+		return nil, n, err
+	}
+
+	return &Writer{w: w}, n, nil
+}
+
+// Write appends a record in a single write so a crash cannot interleave partial records.
+// It returns the number of bytes written.
+func (w *Writer) Write(rec Record) (int, error) {
+
+	size := rec.Args.Size()
+
+	if size > maxRecordSize {
+		return 0, fmt.Errorf("capture record of %d bytes exceeds maximum size", size)
+	}
+
+	if cap(w.buf) < recordHeaderSize+size {
+		w.buf = make([]byte, recordHeaderSize+size)
+	}
+	buf := w.buf[:recordHeaderSize+size]
+
+	binary.BigEndian.PutUint64(buf, uint64(rec.Time.UnixNano()))
+	binary.BigEndian.PutUint32(buf[8:], uint32(size))
+
+	if _, err := rec.Args.MarshalTo(buf[recordHeaderSize:]); err != nil {
+		return 0, err
+	}
+
+	return w.w.Write(buf)
+}
+
+// Reader reads the records of a capture file
+type Reader struct {
+	r *bufio.Reader
+
+	// Device the capture file belongs to
+	Device string
+}
+
+// NewReader reads the capture file header and returns a Reader of its records
+func NewReader(r io.Reader) (*Reader, error) {
+
+	br := bufio.NewReader(r)
+
+	header := make([]byte, len(fileMagic)+2)
+
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	if string(header[:len(fileMagic)]) != fileMagic {
+		return nil, ErrInvalidFile
+	}
+
+	device := make([]byte, binary.BigEndian.Uint16(header[len(fileMagic):]))
+
+	if _, err := io.ReadFull(br, device); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	return &Reader{r: br, Device: string(device)}, nil
+}
+
+// Next returns the next record. It returns io.EOF at the end of the file and io.ErrUnexpectedEOF
+// if the last record was truncated. i.e. collector stopped while writing
+func (r *Reader) Next() (Record, error) {
+
+	var header [recordHeaderSize]byte
+
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		return Record{}, err
+	}
+
+	size := binary.BigEndian.Uint32(header[8:])
+
+	if size > maxRecordSize {
+		return Record{}, fmt.Errorf("capture record of %d bytes exceeds maximum size", size)
+	}
+
+	payload := make([]byte, size)
+
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Record{}, err
+	}
+
+	args := &mdt_dialout.MdtDialoutArgs{}
+
+	if err := args.Unmarshal(payload); err != nil {
+		return Record{}, fmt.Errorf("unable to unmarshal captured dial-out message: %v", err)
+	}
+
+	return Record{
+		Time: time.Unix(0, int64(binary.BigEndian.Uint64(header[:8]))),
+		Args: args,
+	}, nil
+}
@@ -0,0 +1,538 @@
+// Package config loads the Peppamon collector configuration from a YAML file, the environment and the command line.
+// Each setting is resolved in order from its default value, the configuration file, its environment variable
+// and its command line flag.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Environment variable holding the path of the configuration file when the -config flag is not set
+const configFileEnv = "PEPPAMON_CONFIG_FILE"
+
+// Value rendered in place of the secrets
+const redacted = "<redacted>"
+
+// Prometheus metric and label names syntax
+var (
+	metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegexp  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Config represents the Peppamon collector configuration. Every setting has a YAML key, an environment variable
+// and a command line flag named after its YAML path. i.e. metadb.max_conns is set by the -metadb.max-conns flag
+type Config struct {
+	GRPC     GRPC     `yaml:"grpc"`
+	HTTP     HTTP     `yaml:"http"`
+	MetaDB   MetaDB   `yaml:"metadb"`
+	KVStore  KVStore  `yaml:"kvstore"`
+	Registry Registry `yaml:"registry"`
+	DialIn   DialIn   `yaml:"dialin"`
+	Capture  Capture  `yaml:"capture"`
+	Metrics  Metrics  `yaml:"metrics"`
+	Outputs  Outputs  `yaml:"outputs"`
+}
+
+// GRPC represents the settings of the Telemetry dial-out gRPC server
+type GRPC struct {
+	ListenAddress string    `yaml:"listen_address" env:"PEPPAMON_GRPC_LISTEN_ADDRESS" desc:"Telemetry gRPC server listen address"`
+	Keepalive     Keepalive `yaml:"keepalive"`
+	TLS           GRPCTLS   `yaml:"tls"`
+}
+
+// Keepalive represents the gRPC server keepalive settings
+type Keepalive struct {
+	MaxConnectionIdle time.Duration `yaml:"max_connection_idle" env:"PEPPAMON_GRPC_KEEPALIVE_MAX_CONNECTION_IDLE" desc:"Idle time after which a gRPC connection is closed"`
+	Time              time.Duration `yaml:"time" env:"PEPPAMON_GRPC_KEEPALIVE_TIME" desc:"Inactivity time after which the server pings the client"`
+	Timeout           time.Duration `yaml:"timeout" env:"PEPPAMON_GRPC_KEEPALIVE_TIMEOUT" desc:"Time waited for a keepalive ping acknowledgement before closing the connection"`
+}
+
+// GRPCTLS represents the TLS / mutual TLS settings of the Telemetry dial-out gRPC server
+type GRPCTLS struct {
+	CertFile       string        `yaml:"cert_file" env:"PEPPAMON_GRPC_TLS_CERT" desc:"Server certificate PEM file. TLS disabled when empty"`
+	KeyFile        string        `yaml:"key_file" env:"PEPPAMON_GRPC_TLS_KEY" desc:"Server private key PEM file"`
+	ClientCAFile   string        `yaml:"client_ca_file" env:"PEPPAMON_GRPC_TLS_CLIENT_CA" desc:"Client CA bundle PEM file requiring client certificates (mutual TLS)"`
+	AllowedNames   []string      `yaml:"allowed_names" env:"PEPPAMON_GRPC_TLS_ALLOWED_NAMES" desc:"Comma separated client certificate Common Names / SANs accepted. Any verified certificate when empty"`
+	VerifyNodeID   bool          `yaml:"verify_node_id" env:"PEPPAMON_GRPC_TLS_VERIFY_NODE_ID" desc:"Require the Telemetry node ID to match the client certificate Common Name or one of its SANs"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"PEPPAMON_GRPC_TLS_RELOAD_INTERVAL" desc:"Interval between two checks of the certificate files rotation"`
+}
+
+// HTTP represents the settings of the metrics and admin HTTP server
+type HTTP struct {
+	ListenAddress         string        `yaml:"listen_address" env:"PEPPAMON_HTTP_LISTEN_ADDRESS" desc:"Metrics and admin HTTP server listen address"`
+	ShutdownTimeout       time.Duration `yaml:"shutdown_timeout" env:"PEPPAMON_HTTP_SHUTDOWN_TIMEOUT" desc:"Time waited for the HTTP requests to complete on shutdown"`
+	DrainDelay            time.Duration `yaml:"drain_delay" env:"PEPPAMON_SHUTDOWN_DRAIN_DELAY" desc:"Time during which readiness fails before the servers stop on shutdown"`
+	ReadinessDependencies bool          `yaml:"readiness_dependencies" env:"PEPPAMON_HTTP_READINESS_DEPENDENCIES" desc:"Fail readiness while the metadata database or the KV store is unreachable"`
+}
+
+// MetaDB represents the settings of the Telemetry metadata database
+type MetaDB struct {
+	Backend           string        `yaml:"backend" env:"PEPPAMON_METADB_BACKEND" desc:"Metadata storage backend, postgres or sqlite"`
+	SQLitePath        string        `yaml:"sqlite_path" env:"PEPPAMON_METADB_SQLITE_PATH" desc:"SQLite database file of the sqlite backend"`
+	Host              string        `yaml:"host" env:"PEPPAMON_METADB_HOST" desc:"Postgres hostname. Metadata persistence disabled when empty"`
+	Port              int           `yaml:"port" env:"PEPPAMON_METADB_PORT" desc:"Postgres port"`
+	Username          string        `yaml:"username" env:"PEPPAMON_METADB_USERNAME" desc:"Postgres username"`
+	Password          string        `yaml:"password" env:"PEPPAMON_METADB_PASSWORD" desc:"Postgres password" secret:"true"`
+	Database          string        `yaml:"database" env:"PEPPAMON_METADB_DATABASE_NAME" desc:"Postgres database name"`
+	MaxConns          int           `yaml:"max_conns" env:"PEPPAMON_METADB_MAX_CONNS" desc:"Maximum number of connections of the Postgres pool"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"PEPPAMON_METADB_HEALTH_CHECK_PERIOD" desc:"Interval between two health checks of the idle Postgres connections"`
+	ConnectTimeout    time.Duration `yaml:"connect_timeout" env:"PEPPAMON_METADB_CONNECT_TIMEOUT" desc:"Postgres connection timeout"`
+	QueryTimeout      time.Duration `yaml:"query_timeout" env:"PEPPAMON_METADB_QUERY_TIMEOUT" desc:"Postgres query timeout"`
+	TLSSkipVerify     bool          `yaml:"tls_skip_verify" env:"PEPPAMON_METADB_TLS_SKIP_VERIFY" desc:"Skip the Postgres server certificate verification"`
+	RetryInterval     time.Duration `yaml:"retry_interval" env:"PEPPAMON_METADB_RETRY_INTERVAL" desc:"Interval between two connection attempts while Postgres is unreachable"`
+	AutoMigrate       bool          `yaml:"auto_migrate" env:"PEPPAMON_METADB_AUTO_MIGRATE" desc:"Apply the pending schema migrations when connecting to Postgres"`
+	HistoryRetention  time.Duration `yaml:"history_retention" env:"PEPPAMON_METADB_HISTORY_RETENTION" desc:"Age after which the metadata history changes are deleted. History kept forever when 0"`
+	Queue             MetaDBQueue   `yaml:"queue"`
+}
+
+// MetaDBQueue represents the settings of the per-table metadata write-behind queues
+type MetaDBQueue struct {
+	Size          int           `yaml:"size" env:"PEPPAMON_METADB_QUEUE_SIZE" desc:"Maximum number of nodes with a pending write per table. Writes of other nodes are dropped when full"`
+	FlushSize     int           `yaml:"flush_size" env:"PEPPAMON_METADB_QUEUE_FLUSH_SIZE" desc:"Number of nodes with a pending write triggering the flush of a table queue"`
+	FlushInterval time.Duration `yaml:"flush_interval" env:"PEPPAMON_METADB_QUEUE_FLUSH_INTERVAL" desc:"Interval between two flushes of a table queue"`
+	MaxRetries    int           `yaml:"max_retries" env:"PEPPAMON_METADB_QUEUE_MAX_RETRIES" desc:"Number of retries of a write failing with a transient error"`
+	RetryBackoff  time.Duration `yaml:"retry_backoff" env:"PEPPAMON_METADB_QUEUE_RETRY_BACKOFF" desc:"Delay before the first retry of a failed write, doubled on each retry"`
+}
+
+// Enabled returns whether the Telemetry metadata persistence is enabled
+func (m MetaDB) Enabled() bool {
+
+	if m.Backend == "sqlite" {
+		return true
+	}
+
+	return m.Host != ""
+}
+
+// KVStore represents the settings of the Redis KV store
+type KVStore struct {
+	Host         string `yaml:"host" env:"PEPPAMON_KV_HOST" desc:"Redis hostname. KV store disabled when empty"`
+	Port         int    `yaml:"port" env:"PEPPAMON_KV_PORT" desc:"Redis port"`
+	Password     string `yaml:"password" env:"PEPPAMON_KV_PASSWORD" desc:"Redis password" secret:"true"`
+	DB           int    `yaml:"db" env:"PEPPAMON_KV_DB" desc:"Redis database number"`
+	PoolSize     int    `yaml:"pool_size" env:"PEPPAMON_KV_POOL_SIZE" desc:"Maximum number of Redis connections"`
+	MinIdleConns int    `yaml:"min_idle_conns" env:"PEPPAMON_KV_MIN_IDLE_CONNS" desc:"Minimum number of idle Redis connections"`
+}
+
+// Registry represents the settings of the device registry stored in the Redis KV store
+type Registry struct {
+	Labels              []string      `yaml:"labels" env:"PEPPAMON_REGISTRY_LABELS" desc:"Comma separated device attributes added as labels to the metrics of the device"`
+	RefreshInterval     time.Duration `yaml:"refresh_interval" env:"PEPPAMON_REGISTRY_REFRESH_INTERVAL" desc:"Interval between two reloads of the device registry cache from the KV store"`
+	RequireRegistration bool          `yaml:"require_registration" env:"PEPPAMON_REGISTRY_REQUIRE_REGISTRATION" desc:"Reject the Telemetry dial-out streams of the devices missing from the registry"`
+}
+
+// DialIn represents the settings of the gNMI dial-in subscriptions
+type DialIn struct {
+	TargetsFile string `yaml:"targets_file" env:"PEPPAMON_GNMI_DIALIN_FILE" desc:"JSON file of the gNMI dial-in targets. Dial-in disabled when empty"`
+}
+
+// Capture represents the settings of the raw Telemetry capture files
+type Capture struct {
+	Dir         string `yaml:"dir" env:"PEPPAMON_CAPTURE_DIR" desc:"Directory of the capture files. Capture disabled when empty"`
+	Mode        string `yaml:"mode" env:"PEPPAMON_CAPTURE_MODE" desc:"Messages captured, all or unsupported"`
+	MaxFileSize int    `yaml:"max_file_size" env:"PEPPAMON_CAPTURE_MAX_FILE_SIZE" desc:"Size in bytes from which a device capture file is rotated"`
+	MaxFiles    int    `yaml:"max_files" env:"PEPPAMON_CAPTURE_MAX_FILES" desc:"Number of capture files kept per device"`
+}
+
+// Metrics represents the settings of the metrics decoding
+type Metrics struct {
+	MappingsFile           string        `yaml:"mappings_file" env:"PEPPAMON_METRIC_MAPPINGS_FILE" desc:"YAML file of the declarative YANG path to metrics mappings"`
+	UnsupportedPathsPolicy string        `yaml:"unsupported_paths_policy" env:"PEPPAMON_UNSUPPORTED_PATHS_POLICY" desc:"Policy of the YANG paths without parser, reject, drop or passthrough"`
+	Generic                Generic       `yaml:"generic"`
+	TTL                    TTL           `yaml:"ttl"`
+	CollectionRoundTimeout time.Duration `yaml:"collection_round_timeout" env:"PEPPAMON_METRICS_COLLECTION_ROUND_TIMEOUT" desc:"Time after which a collection round missing its last message is published anyway. Derived from the shortest TTL when 0"`
+}
+
+// Generic represents the settings of the generic decoder of the unsupported YANG paths with the passthrough policy.
+// Patterns match the YANG encoding path followed by the leaf path and * matches any sequence of characters.
+type Generic struct {
+	Allow     []string `yaml:"allow" env:"PEPPAMON_GENERIC_ALLOW" desc:"Comma separated patterns of the leaves decoded. All leaves when empty"`
+	Deny      []string `yaml:"deny" env:"PEPPAMON_GENERIC_DENY" desc:"Comma separated patterns of the leaves never decoded"`
+	MaxSeries int      `yaml:"max_series" env:"PEPPAMON_GENERIC_MAX_SERIES" desc:"Maximum number of series decoded from a single message"`
+}
+
+// TTL represents the settings of the stale metrics eviction of the Prometheus scrape endpoint
+type TTL struct {
+	Multiplier    float64       `yaml:"multiplier" env:"PEPPAMON_METRICS_TTL_MULTIPLIER" desc:"Number of sample intervals without message after which the metrics of a YANG path are evicted"`
+	Default       time.Duration `yaml:"default" env:"PEPPAMON_METRICS_TTL_DEFAULT" desc:"TTL of the metrics of a YANG path while its sample interval is unknown"`
+	Paths         []string      `yaml:"paths" env:"PEPPAMON_METRICS_TTL_PATHS" desc:"Comma separated <encoding path>=<TTL> overrides of the observed sample interval"`
+	CheckInterval time.Duration `yaml:"check_interval" env:"PEPPAMON_METRICS_TTL_CHECK_INTERVAL" desc:"Interval between two checks for stale metrics. Derived from the shortest TTL when 0"`
+}
+
+// Shortest returns the shortest of the default TTL and the valid path overrides
+func (t TTL) Shortest() time.Duration {
+
+	shortest := t.Default
+
+	for _, pathTTL := range t.Paths {
+		if _, d, err := ParsePathTTL(pathTTL); err == nil && d < shortest {
+			shortest = d
+		}
+	}
+
+	return shortest
+}
+
+// Default returns the configuration default values
+func Default() Config {
+
+	return Config{
+		GRPC: GRPC{
+			ListenAddress: ":50051",
+			Keepalive: Keepalive{
+				MaxConnectionIdle: 5 * time.Minute,
+				Time:              30 * time.Second,
+				Timeout:           time.Minute,
+			},
+			TLS: GRPCTLS{
+				ReloadInterval: time.Minute,
+			},
+		},
+		HTTP: HTTP{
+			ListenAddress:         ":2112",
+			ShutdownTimeout:       3 * time.Second,
+			DrainDelay:            5 * time.Second,
+			ReadinessDependencies: true,
+		},
+		MetaDB: MetaDB{
+			Backend:           "postgres",
+			SQLitePath:        "peppamon-metadata.db",
+			Port:              5432,
+			MaxConns:          50,
+			HealthCheckPeriod: time.Minute,
+			ConnectTimeout:    time.Minute,
+			QueryTimeout:      10 * time.Second,
+			TLSSkipVerify:     true,
+			RetryInterval:     30 * time.Second,
+			AutoMigrate:       true,
+			HistoryRetention:  90 * 24 * time.Hour,
+			Queue: MetaDBQueue{
+				Size:          5000,
+				FlushSize:     100,
+				FlushInterval: 5 * time.Second,
+				MaxRetries:    5,
+				RetryBackoff:  time.Second,
+			},
+		},
+		KVStore: KVStore{
+			Port:         6379,
+			PoolSize:     20,
+			MinIdleConns: 5,
+		},
+		Registry: Registry{
+			RefreshInterval: time.Minute,
+		},
+		Capture: Capture{
+			Mode:        "all",
+			MaxFileSize: 64 << 20,
+			MaxFiles:    10,
+		},
+		Metrics: Metrics{
+			UnsupportedPathsPolicy: "drop",
+			Generic: Generic{
+				MaxSeries: 10000,
+			},
+			TTL: TTL{
+				Multiplier: 3,
+				Default:    10 * time.Minute,
+			},
+		},
+		Outputs: defaultOutputs(),
+	}
+}
+
+// Load resolves the configuration from the configuration file, the environment and the command line arguments
+func Load(name string, args []string) (*Config, error) {
+
+	cfg := Default()
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(configFileEnv),
+		fmt.Sprintf("Path of the YAML configuration file (env %v)", configFileEnv))
+
+	settings := cfg.settings()
+
+	// Flags are applied once the configuration file and the environment are loaded
+	flagValues := make(map[string]*string)
+
+	for _, s := range settings {
+
+		// Default value displayed in the usage
+		v := new(string)
+		*v = s.text()
+		flagValues[s.flag] = v
+
+		usage := fmt.Sprintf("%v (env %v)", s.desc, s.env)
+
+		if s.value.Kind() == reflect.Bool {
+			fs.Var((*boolFlag)(v), s.flag, usage)
+		} else {
+			fs.Var((*stringFlag)(v), s.flag, usage)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument(s) %v", strings.Join(fs.Args(), " "))
+	}
+
+	if *configFile != "" {
+
+		b, err := ioutil.ReadFile(*configFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read configuration file: %v", err)
+		}
+
+		if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+			return nil, fmt.Errorf("unable to decode configuration file %v: %v", *configFile, err)
+		}
+	}
+
+	var errs []string
+
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.env); ok && v != "" {
+			if err := s.set(v); err != nil {
+				errs = append(errs, fmt.Sprintf("environment variable %v: %v", s.env, err))
+			}
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if v, ok := flagValues[f.Name]; ok {
+			if err := settingByFlag(settings, f.Name).set(*v); err != nil {
+				errs = append(errs, fmt.Sprintf("flag -%v: %v", f.Name, err))
+			}
+		}
+	})
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %v", strings.Join(errs, "; "))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the configuration values
+func (c *Config) Validate() error {
+
+	var errs []string
+
+	for _, addr := range []struct{ key, value string }{
+		{"grpc.listen_address", c.GRPC.ListenAddress},
+		{"http.listen_address", c.HTTP.ListenAddress},
+	} {
+		if _, _, err := net.SplitHostPort(addr.value); err != nil {
+			errs = append(errs, fmt.Sprintf("%v %q is not a valid host:port address", addr.key, addr.value))
+		}
+	}
+
+	switch c.MetaDB.Backend {
+	case "postgres", "sqlite":
+	default:
+		errs = append(errs, fmt.Sprintf("metadb.backend %q must be postgres or sqlite", c.MetaDB.Backend))
+	}
+
+	if c.MetaDB.Backend == "sqlite" && c.MetaDB.SQLitePath == "" {
+		errs = append(errs, "metadb.sqlite_path is required with the sqlite backend")
+	}
+
+	// Postgres credentials required once the metadata persistence is enabled
+	if c.MetaDB.Backend == "postgres" && c.MetaDB.Enabled() {
+		for _, required := range []struct{ key, value string }{
+			{"metadb.username", c.MetaDB.Username},
+			{"metadb.password", c.MetaDB.Password},
+			{"metadb.database", c.MetaDB.Database},
+		} {
+			if required.value == "" {
+				errs = append(errs, fmt.Sprintf("%v is required when metadb.host is set", required.key))
+			}
+		}
+	}
+
+	for _, port := range []struct {
+		key   string
+		value int
+	}{
+		{"metadb.port", c.MetaDB.Port},
+		{"kvstore.port", c.KVStore.Port},
+	} {
+		if port.value < 1 || port.value > 65535 {
+			errs = append(errs, fmt.Sprintf("%v %v is not a valid TCP port", port.key, port.value))
+		}
+	}
+
+	// TLS requires both the certificate and its key
+	if (c.GRPC.TLS.CertFile == "") != (c.GRPC.TLS.KeyFile == "") {
+		errs = append(errs, "grpc.tls.cert_file and grpc.tls.key_file must be set together")
+	}
+
+	if c.GRPC.TLS.ClientCAFile != "" && c.GRPC.TLS.CertFile == "" {
+		errs = append(errs, "grpc.tls.client_ca_file requires grpc.tls.cert_file and grpc.tls.key_file")
+	}
+
+	switch c.Capture.Mode {
+	case "all", "unsupported":
+	default:
+		errs = append(errs, fmt.Sprintf("capture.mode %q must be all or unsupported", c.Capture.Mode))
+	}
+
+	switch c.Metrics.UnsupportedPathsPolicy {
+	case "reject", "drop", "passthrough":
+	default:
+		errs = append(errs, fmt.Sprintf(
+			"metrics.unsupported_paths_policy %q must be reject, drop or passthrough", c.Metrics.UnsupportedPathsPolicy))
+	}
+
+	if c.Metrics.TTL.Multiplier <= 0 {
+		errs = append(errs, "metrics.ttl.multiplier must be positive")
+	}
+
+	for _, pathTTL := range c.Metrics.TTL.Paths {
+		if _, _, err := ParsePathTTL(pathTTL); err != nil {
+			errs = append(errs, fmt.Sprintf("metrics.ttl.paths %v", err))
+		}
+	}
+
+	errs = append(errs, c.Outputs.validate()...)
+
+	for _, positive := range []struct {
+		key   string
+		value int64
+	}{
+		{"grpc.keepalive.max_connection_idle", int64(c.GRPC.Keepalive.MaxConnectionIdle)},
+		{"grpc.keepalive.time", int64(c.GRPC.Keepalive.Time)},
+		{"grpc.keepalive.timeout", int64(c.GRPC.Keepalive.Timeout)},
+		{"http.shutdown_timeout", int64(c.HTTP.ShutdownTimeout)},
+		{"metadb.max_conns", int64(c.MetaDB.MaxConns)},
+		{"metadb.health_check_period", int64(c.MetaDB.HealthCheckPeriod)},
+		{"metadb.connect_timeout", int64(c.MetaDB.ConnectTimeout)},
+		{"metadb.query_timeout", int64(c.MetaDB.QueryTimeout)},
+		{"metadb.retry_interval", int64(c.MetaDB.RetryInterval)},
+		{"metadb.queue.size", int64(c.MetaDB.Queue.Size)},
+		{"metadb.queue.flush_size", int64(c.MetaDB.Queue.FlushSize)},
+		{"metadb.queue.flush_interval", int64(c.MetaDB.Queue.FlushInterval)},
+		{"metadb.queue.retry_backoff", int64(c.MetaDB.Queue.RetryBackoff)},
+		{"kvstore.pool_size", int64(c.KVStore.PoolSize)},
+		{"registry.refresh_interval", int64(c.Registry.RefreshInterval)},
+		{"grpc.tls.reload_interval", int64(c.GRPC.TLS.ReloadInterval)},
+		{"capture.max_file_size", int64(c.Capture.MaxFileSize)},
+		{"capture.max_files", int64(c.Capture.MaxFiles)},
+		{"metrics.generic.max_series", int64(c.Metrics.Generic.MaxSeries)},
+		{"metrics.ttl.default", int64(c.Metrics.TTL.Default)},
+	} {
+		if positive.value <= 0 {
+			errs = append(errs, fmt.Sprintf("%v must be positive", positive.key))
+		}
+	}
+
+	for _, notNegative := range []struct {
+		key   string
+		value int64
+	}{
+		{"http.drain_delay", int64(c.HTTP.DrainDelay)},
+		{"kvstore.db", int64(c.KVStore.DB)},
+		{"kvstore.min_idle_conns", int64(c.KVStore.MinIdleConns)},
+		{"metadb.queue.max_retries", int64(c.MetaDB.Queue.MaxRetries)},
+		{"metadb.history_retention", int64(c.MetaDB.HistoryRetention)},
+		{"metrics.ttl.check_interval", int64(c.Metrics.TTL.CheckInterval)},
+		{"metrics.collection_round_timeout", int64(c.Metrics.CollectionRoundTimeout)},
+	} {
+		if notNegative.value < 0 {
+			errs = append(errs, fmt.Sprintf("%v must not be negative", notNegative.key))
+		}
+	}
+
+	if c.MetaDB.Queue.FlushSize > c.MetaDB.Queue.Size {
+		errs = append(errs, "metadb.queue.flush_size must not exceed metadb.queue.size")
+	}
+
+	// The device registry is stored in the KV store
+	if c.KVStore.Host == "" && (len(c.Registry.Labels) > 0 || c.Registry.RequireRegistration) {
+		errs = append(errs, "registry.labels and registry.require_registration require kvstore.host")
+	}
+
+	for _, l := range c.Registry.Labels {
+		if !ValidLabelName(l) {
+			errs = append(errs, fmt.Sprintf("registry.labels %q is not a valid Prometheus label name", l))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %v", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// ParsePathTTL parses a <encoding path>=<TTL> override of the stale metrics eviction.
+// YANG encoding paths contain colons but no equal sign, so the entry is split on its last equal sign.
+func ParsePathTTL(pathTTL string) (string, time.Duration, error) {
+
+	i := strings.LastIndex(pathTTL, "=")
+
+	if i <= 0 {
+		return "", 0, fmt.Errorf("entry %q is not in the <encoding path>=<TTL> format", pathTTL)
+	}
+
+	d, err := time.ParseDuration(pathTTL[i+1:])
+
+	if err != nil || d <= 0 {
+		return "", 0, fmt.Errorf("entry %q TTL must be a positive duration", pathTTL)
+	}
+
+	return pathTTL[:i], d, nil
+}
+
+// ValidMetricName returns whether the name is a valid Prometheus metric name
+func ValidMetricName(name string) bool {
+	return metricNameRegexp.MatchString(name)
+}
+
+// ValidLabelName returns whether the name is a valid Prometheus label name not reserved for internal use
+func ValidLabelName(name string) bool {
+	return labelNameRegexp.MatchString(name) && !strings.HasPrefix(name, "__")
+}
+
+// Redacted returns a copy of the configuration where the secrets are masked
+func (c Config) Redacted() Config {
+
+	for _, s := range c.settings() {
+
+		if !s.secret {
+			continue
+		}
+
+		switch {
+		case s.value.Kind() == reflect.String && s.value.String() != "":
+			s.value.SetString(redacted)
+		case s.value.Type() == stringSliceType && s.value.Len() > 0:
+			s.value.Set(reflect.ValueOf([]string{redacted}))
+		}
+	}
+
+	return c
+}
+
+// YAML renders the configuration in the configuration file format
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
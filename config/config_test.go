@@ -0,0 +1,447 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setenv sets the environment variables for the duration of a test
+func setenv(t *testing.T, env map[string]string) {
+
+	for k, v := range env {
+
+		old, ok := os.LookupEnv(k)
+
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatalf("Setenv(%v) error = %v", k, err)
+		}
+
+		k := k
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(k, old)
+			} else {
+				os.Unsetenv(k)
+			}
+		})
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+
+	dir, err := ioutil.TempDir("", "peppamon-config")
+
+	if err != nil {
+		t.Fatalf("TempDir() error = %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	name := filepath.Join(dir, "peppamon.yml")
+
+	if err := ioutil.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return name
+}
+
+func TestLoadPrecedence(t *testing.T) {
+
+	file := writeConfigFile(t, `
+grpc:
+  listen_address: :50052
+http:
+  listen_address: :2113
+  shutdown_timeout: 10s
+kvstore:
+  port: 6380
+outputs:
+  otlp:
+    batch:
+      size: 200
+`)
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		check func(t *testing.T, c *Config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, c *Config) {
+				if c.GRPC.ListenAddress != ":50051" || c.Outputs.OTLP.Batch.Size != 500 {
+					t.Errorf("got %v and batch size %v, want the defaults", c.GRPC.ListenAddress, c.Outputs.OTLP.Batch.Size)
+				}
+
+				if !c.HTTP.ReadinessDependencies {
+					t.Errorf("readiness dependencies disabled by default")
+				}
+			},
+		},
+		{
+			name: "file over defaults",
+			args: []string{"-config", file},
+			check: func(t *testing.T, c *Config) {
+				if c.GRPC.ListenAddress != ":50052" || c.HTTP.ShutdownTimeout != 10*time.Second {
+					t.Errorf("got %v and %v, want the file values", c.GRPC.ListenAddress, c.HTTP.ShutdownTimeout)
+				}
+
+				// Settings missing from the file keep their default value
+				if c.HTTP.DrainDelay != 5*time.Second || c.KVStore.PoolSize != 20 {
+					t.Errorf("got %v and %v, want the defaults", c.HTTP.DrainDelay, c.KVStore.PoolSize)
+				}
+			},
+		},
+		{
+			name: "config file from the environment",
+			env:  map[string]string{configFileEnv: file},
+			check: func(t *testing.T, c *Config) {
+				if c.KVStore.Port != 6380 {
+					t.Errorf("kvstore.port = %v, want 6380", c.KVStore.Port)
+				}
+			},
+		},
+		{
+			name: "environment over file",
+			env: map[string]string{
+				"PEPPAMON_GRPC_LISTEN_ADDRESS": ":50053",
+				"PEPPAMON_OTLP_BATCH_SIZE":     "300",
+				"PEPPAMON_GENERIC_ALLOW":       "a:b/*, c:d/*",
+			},
+			args: []string{"-config", file},
+			check: func(t *testing.T, c *Config) {
+				if c.GRPC.ListenAddress != ":50053" || c.Outputs.OTLP.Batch.Size != 300 {
+					t.Errorf("got %v and batch size %v, want the environment values",
+						c.GRPC.ListenAddress, c.Outputs.OTLP.Batch.Size)
+				}
+
+				if !reflect.DeepEqual(c.Metrics.Generic.Allow, []string{"a:b/*", "c:d/*"}) {
+					t.Errorf("metrics.generic.allow = %v", c.Metrics.Generic.Allow)
+				}
+
+				// Settings missing from the environment keep the file value
+				if c.HTTP.ListenAddress != ":2113" {
+					t.Errorf("http.listen_address = %v, want :2113", c.HTTP.ListenAddress)
+				}
+			},
+		},
+		{
+			name: "flags over environment",
+			env:  map[string]string{"PEPPAMON_GRPC_LISTEN_ADDRESS": ":50053", "PEPPAMON_METRICS_TTL_MULTIPLIER": "2"},
+			args: []string{
+				"-config", file,
+				"-grpc.listen-address", ":50054",
+				"-outputs.otlp.batch.size", "400",
+				"-metrics.ttl.multiplier", "4.5",
+				"-grpc.tls.verify-node-id",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.GRPC.ListenAddress != ":50054" || c.Outputs.OTLP.Batch.Size != 400 {
+					t.Errorf("got %v and batch size %v, want the flag values",
+						c.GRPC.ListenAddress, c.Outputs.OTLP.Batch.Size)
+				}
+
+				if c.Metrics.TTL.Multiplier != 4.5 || !c.GRPC.TLS.VerifyNodeID {
+					t.Errorf("got multiplier %v and verify node ID %v, want the flag values",
+						c.Metrics.TTL.Multiplier, c.GRPC.TLS.VerifyNodeID)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			setenv(t, map[string]string{configFileEnv: ""})
+			setenv(t, tt.env)
+
+			c, err := Load("peppamon", tt.args)
+
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "unknown file key",
+			file:    "grpc:\n  listen_adress: :50051\n",
+			wantErr: "field listen_adress not found",
+		},
+		{
+			name:    "invalid environment value",
+			env:     map[string]string{"PEPPAMON_KV_PORT": "redis"},
+			wantErr: "environment variable PEPPAMON_KV_PORT",
+		},
+		{
+			name:    "invalid flag value",
+			args:    []string{"-http.shutdown-timeout", "3"},
+			wantErr: "flag -http.shutdown-timeout",
+		},
+		{
+			name:    "unexpected argument",
+			args:    []string{"serve"},
+			wantErr: "unexpected argument(s) serve",
+		},
+		{
+			name:    "invalid value",
+			args:    []string{"-metadb.max-conns", "0"},
+			wantErr: "metadb.max_conns must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			setenv(t, map[string]string{configFileEnv: ""})
+			setenv(t, tt.env)
+
+			args := tt.args
+
+			if tt.file != "" {
+				args = append([]string{"-config", writeConfigFile(t, tt.file)}, args...)
+			}
+
+			_, err := Load("peppamon", args)
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadExampleFile(t *testing.T) {
+
+	setenv(t, map[string]string{configFileEnv: "", "PEPPAMON_METADB_PASSWORD": "secret"})
+
+	if _, err := Load("peppamon", []string{"-config", "../peppamon.example.yml"}); err != nil {
+		t.Errorf("Load() error = %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			modify: func(c *Config) {},
+		},
+		{
+			name:    "listen address",
+			modify:  func(c *Config) { c.GRPC.ListenAddress = "50051" },
+			wantErr: `grpc.listen_address "50051" is not a valid host:port address`,
+		},
+		{
+			name:    "metadb backend",
+			modify:  func(c *Config) { c.MetaDB.Backend = "mysql" },
+			wantErr: `metadb.backend "mysql" must be postgres or sqlite`,
+		},
+		{
+			name:    "postgres credentials",
+			modify:  func(c *Config) { c.MetaDB.Host = "postgres" },
+			wantErr: "metadb.password is required when metadb.host is set",
+		},
+		{
+			name:    "port",
+			modify:  func(c *Config) { c.KVStore.Port = 70000 },
+			wantErr: "kvstore.port 70000 is not a valid TCP port",
+		},
+		{
+			name:    "flush size",
+			modify:  func(c *Config) { c.MetaDB.Queue.FlushSize = c.MetaDB.Queue.Size + 1 },
+			wantErr: "metadb.queue.flush_size must not exceed metadb.queue.size",
+		},
+		{
+			name:    "history retention",
+			modify:  func(c *Config) { c.MetaDB.HistoryRetention = -time.Hour },
+			wantErr: "metadb.history_retention must not be negative",
+		},
+		{
+			name:    "ttl check interval",
+			modify:  func(c *Config) { c.Metrics.TTL.CheckInterval = -time.Second },
+			wantErr: "metrics.ttl.check_interval must not be negative",
+		},
+		{
+			name:    "collection round timeout",
+			modify:  func(c *Config) { c.Metrics.CollectionRoundTimeout = -time.Second },
+			wantErr: "metrics.collection_round_timeout must not be negative",
+		},
+		{
+			name:    "registry without kvstore",
+			modify:  func(c *Config) { c.Registry.RequireRegistration = true },
+			wantErr: "registry.labels and registry.require_registration require kvstore.host",
+		},
+		{
+			name: "registry label",
+			modify: func(c *Config) {
+				c.KVStore.Host = "redis"
+				c.Registry.Labels = []string{"__site"}
+			},
+			wantErr: `registry.labels "__site" is not a valid Prometheus label name`,
+		},
+		{
+			name:    "tls key",
+			modify:  func(c *Config) { c.GRPC.TLS.CertFile = "cert.pem" },
+			wantErr: "grpc.tls.cert_file and grpc.tls.key_file must be set together",
+		},
+		{
+			name:    "capture mode",
+			modify:  func(c *Config) { c.Capture.Mode = "errors" },
+			wantErr: `capture.mode "errors" must be all or unsupported`,
+		},
+		{
+			name:    "unsupported paths policy",
+			modify:  func(c *Config) { c.Metrics.UnsupportedPathsPolicy = "ignore" },
+			wantErr: `metrics.unsupported_paths_policy "ignore" must be reject, drop or passthrough`,
+		},
+		{
+			name:    "ttl path",
+			modify:  func(c *Config) { c.Metrics.TTL.Paths = []string{"Cisco-IOS-XE-bgp-oper:bgp-state-data"} },
+			wantErr: "is not in the <encoding path>=<TTL> format",
+		},
+		{
+			name:    "unknown sink",
+			modify:  func(c *Config) { c.Outputs.Sinks = []string{"graphite"} },
+			wantErr: `outputs.sinks "graphite" is not a supported output sink`,
+		},
+		{
+			name:    "sink endpoint",
+			modify:  func(c *Config) { c.Outputs.Sinks = []string{SinkPrometheus, SinkRemoteWrite} },
+			wantErr: "outputs.sinks remote_write requires outputs.remote_write.url",
+		},
+		{
+			name:    "otlp headers",
+			modify:  func(c *Config) { c.Outputs.OTLP.Headers = []string{"authorization"} },
+			wantErr: "outputs.otlp.headers entries must be in the key=value format",
+		},
+		{
+			name:    "kafka compression",
+			modify:  func(c *Config) { c.Outputs.Kafka.Compression = "brotli" },
+			wantErr: `outputs.kafka.compression "brotli" must be none, gzip, snappy, lz4 or zstd`,
+		},
+		{
+			name:    "kafka required acks",
+			modify:  func(c *Config) { c.Outputs.Kafka.RequiredAcks = "leader" },
+			wantErr: `outputs.kafka.required_acks "leader" must be none, one or all`,
+		},
+		{
+			name:    "batch",
+			modify:  func(c *Config) { c.Outputs.InfluxDB.Batch.Size = 0 },
+			wantErr: "outputs.influxdb.batch size, flush_interval and queue_size must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			c := Default()
+			tt.modify(&c)
+
+			err := c.Validate()
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+
+	c := Default()
+	c.MetaDB.Password = "metadb"
+	c.Outputs.RemoteWrite.Username = "peppamon"
+	c.Outputs.RemoteWrite.Password = "remote-write"
+	c.Outputs.RemoteWrite.BearerToken = "bearer"
+	c.Outputs.InfluxDB.Token = "influxdb"
+	c.Outputs.OTLP.Headers = []string{"authorization=Bearer otlp"}
+
+	r := c.Redacted()
+
+	for key, v := range map[string]string{
+		"metadb.password":                   r.MetaDB.Password,
+		"kvstore.password":                  r.KVStore.Password,
+		"outputs.remote_write.password":     r.Outputs.RemoteWrite.Password,
+		"outputs.remote_write.bearer_token": r.Outputs.RemoteWrite.BearerToken,
+		"outputs.influxdb.token":            r.Outputs.InfluxDB.Token,
+		"outputs.influxdb.password":         r.Outputs.InfluxDB.Password,
+	} {
+		want := redacted
+
+		// Unset secrets are rendered empty
+		if key == "kvstore.password" || key == "outputs.influxdb.password" {
+			want = ""
+		}
+
+		if v != want {
+			t.Errorf("Redacted() %v = %q, want %q", key, v, want)
+		}
+	}
+
+	if !reflect.DeepEqual(r.Outputs.OTLP.Headers, []string{redacted}) {
+		t.Errorf("Redacted() outputs.otlp.headers = %v", r.Outputs.OTLP.Headers)
+	}
+
+	if r.Outputs.RemoteWrite.Username != "peppamon" {
+		t.Errorf("Redacted() outputs.remote_write.username = %q, want it unchanged", r.Outputs.RemoteWrite.Username)
+	}
+
+	// The original configuration is left untouched
+	if c.Outputs.InfluxDB.Token != "influxdb" || c.Outputs.OTLP.Headers[0] != "authorization=Bearer otlp" {
+		t.Errorf("Redacted() modified the configuration")
+	}
+}
+
+func TestBatchEnvPrefix(t *testing.T) {
+
+	c := Default()
+	env := make(map[string]string)
+
+	for _, s := range c.settings() {
+		env[s.key] = s.env
+	}
+
+	for key, want := range map[string]string{
+		"outputs.remote_write.batch.size":        "PEPPAMON_REMOTE_WRITE_BATCH_SIZE",
+		"outputs.influxdb.batch.flush_interval":  "PEPPAMON_INFLUXDB_FLUSH_INTERVAL",
+		"outputs.otlp.batch.queue_size":          "PEPPAMON_OTLP_QUEUE_SIZE",
+		"outputs.otlp.batch.max_retries":         "PEPPAMON_OTLP_MAX_RETRIES",
+		"metadb.queue.size":                      "PEPPAMON_METADB_QUEUE_SIZE",
+		"grpc.tls.reload_interval":               "PEPPAMON_GRPC_TLS_RELOAD_INTERVAL",
+		"outputs.remote_write.batch.max_retries": "PEPPAMON_REMOTE_WRITE_MAX_RETRIES",
+	} {
+		if env[key] != want {
+			t.Errorf("%v environment variable = %q, want %q", key, env[key], want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Output sinks names of outputs.sinks
+const (
+	SinkPrometheus   = "prometheus"
+	SinkRemoteWrite  = "remote_write"
+	SinkInfluxDB     = "influxdb"
+	SinkInfluxDBFile = "influxdb_file"
+	SinkKafka        = "kafka"
+	SinkOTLP         = "otlp"
+)
+
+// Outputs represents the settings of the metrics output sinks
+type Outputs struct {
+	Sinks       []string    `yaml:"sinks" env:"PEPPAMON_OUTPUT_SINKS" desc:"Comma separated output sinks enabled. Prometheus and the sinks with an endpoint when empty"`
+	RemoteWrite RemoteWrite `yaml:"remote_write"`
+	InfluxDB    InfluxDB    `yaml:"influxdb"`
+	Kafka       Kafka       `yaml:"kafka"`
+	OTLP        OTLP        `yaml:"otlp"`
+}
+
+// RemoteWrite represents the settings of the Prometheus remote write output sink
+type RemoteWrite struct {
+	URL         string        `yaml:"url" env:"PEPPAMON_REMOTE_WRITE_URL" desc:"Prometheus remote write endpoint URL"`
+	Username    string        `yaml:"username" env:"PEPPAMON_REMOTE_WRITE_USERNAME" desc:"Remote write basic authentication username"`
+	Password    string        `yaml:"password" env:"PEPPAMON_REMOTE_WRITE_PASSWORD" desc:"Remote write basic authentication password" secret:"true"`
+	BearerToken string        `yaml:"bearer_token" env:"PEPPAMON_REMOTE_WRITE_BEARER_TOKEN" desc:"Remote write bearer token" secret:"true"`
+	Timeout     time.Duration `yaml:"timeout" env:"PEPPAMON_REMOTE_WRITE_TIMEOUT" desc:"Remote write request timeout"`
+	Batch       Batch         `yaml:"batch" env:"PEPPAMON_REMOTE_WRITE"`
+}
+
+// InfluxDB represents the settings of the InfluxDB line protocol output sinks
+type InfluxDB struct {
+	URL      string        `yaml:"url" env:"PEPPAMON_INFLUXDB_URL" desc:"InfluxDB write endpoint URL including the database or bucket parameters"`
+	Token    string        `yaml:"token" env:"PEPPAMON_INFLUXDB_TOKEN" desc:"InfluxDB 2.x API token" secret:"true"`
+	Username string        `yaml:"username" env:"PEPPAMON_INFLUXDB_USERNAME" desc:"InfluxDB 1.x username"`
+	Password string        `yaml:"password" env:"PEPPAMON_INFLUXDB_PASSWORD" desc:"InfluxDB 1.x password" secret:"true"`
+	Timeout  time.Duration `yaml:"timeout" env:"PEPPAMON_INFLUXDB_TIMEOUT" desc:"InfluxDB write request timeout"`
+	File     string        `yaml:"file" env:"PEPPAMON_INFLUXDB_FILE" desc:"File the line protocol is appended to"`
+	Batch    Batch         `yaml:"batch" env:"PEPPAMON_INFLUXDB"`
+}
+
+// Kafka represents the settings of the Kafka output sink
+type Kafka struct {
+	Brokers        []string      `yaml:"brokers" env:"PEPPAMON_KAFKA_BROKERS" desc:"Comma separated Kafka bootstrap brokers"`
+	TelemetryTopic string        `yaml:"telemetry_topic" env:"PEPPAMON_KAFKA_TELEMETRY_TOPIC" desc:"Topic of the decoded Telemetry messages. {path} is replaced by the YANG encoding path"`
+	SamplesTopic   string        `yaml:"samples_topic" env:"PEPPAMON_KAFKA_SAMPLES_TOPIC" desc:"Topic of the metrics samples. {path} is replaced by the YANG encoding path"`
+	Compression    string        `yaml:"compression" env:"PEPPAMON_KAFKA_COMPRESSION" desc:"Compression codec, none, gzip, snappy, lz4 or zstd"`
+	RequiredAcks   string        `yaml:"required_acks" env:"PEPPAMON_KAFKA_REQUIRED_ACKS" desc:"Acknowledgements required from the brokers, none, one or all"`
+	MaxAttempts    int           `yaml:"max_attempts" env:"PEPPAMON_KAFKA_MAX_ATTEMPTS" desc:"Number of delivery attempts of a batch before its records are dropped"`
+	BatchSize      int           `yaml:"batch_size" env:"PEPPAMON_KAFKA_BATCH_SIZE" desc:"Maximum number of records per batch"`
+	BatchTimeout   time.Duration `yaml:"batch_timeout" env:"PEPPAMON_KAFKA_BATCH_TIMEOUT" desc:"Maximum time records wait before being sent"`
+	QueueSize      int           `yaml:"queue_size" env:"PEPPAMON_KAFKA_QUEUE_SIZE" desc:"Maximum number of records waiting to be sent. Further records are dropped"`
+	WriteTimeout   time.Duration `yaml:"write_timeout" env:"PEPPAMON_KAFKA_WRITE_TIMEOUT" desc:"Timeout of a batch write to the brokers"`
+}
+
+// OTLP represents the settings of the OpenTelemetry OTLP metrics output sink
+type OTLP struct {
+	Endpoint string        `yaml:"endpoint" env:"PEPPAMON_OTLP_ENDPOINT" desc:"OTLP receiver host:port with grpc or base URL with http"`
+	Protocol string        `yaml:"protocol" env:"PEPPAMON_OTLP_PROTOCOL" desc:"OTLP transport protocol, grpc or http"`
+	Insecure bool          `yaml:"insecure" env:"PEPPAMON_OTLP_INSECURE" desc:"Disable TLS of the OTLP gRPC transport"`
+	Headers  []string      `yaml:"headers" env:"PEPPAMON_OTLP_HEADERS" desc:"Comma separated key=value headers sent with every export request" secret:"true"`
+	Timeout  time.Duration `yaml:"timeout" env:"PEPPAMON_OTLP_TIMEOUT" desc:"OTLP export request timeout"`
+	Batch    Batch         `yaml:"batch" env:"PEPPAMON_OTLP"`
+}
+
+// Batch represents the batching settings of an output sink. Its environment variables are prefixed by the ones
+// of the output sink. i.e. PEPPAMON_OTLP_BATCH_SIZE
+type Batch struct {
+	Size          int           `yaml:"size" env:"BATCH_SIZE" desc:"Maximum number of samples per batch"`
+	FlushInterval time.Duration `yaml:"flush_interval" env:"FLUSH_INTERVAL" desc:"Maximum time samples wait in queue before being flushed"`
+	QueueSize     int           `yaml:"queue_size" env:"QUEUE_SIZE" desc:"Maximum number of samples waiting to be flushed. Further samples are dropped"`
+	MaxRetries    int           `yaml:"max_retries" env:"MAX_RETRIES" desc:"Number of retries of a failed batch before its samples are dropped"`
+}
+
+func defaultOutputs() Outputs {
+
+	batch := Batch{
+		Size:          500,
+		FlushInterval: 5 * time.Second,
+		QueueSize:     100000,
+		MaxRetries:    10,
+	}
+
+	return Outputs{
+		RemoteWrite: RemoteWrite{
+			Timeout: 10 * time.Second,
+			Batch:   batch,
+		},
+		InfluxDB: InfluxDB{
+			Timeout: 10 * time.Second,
+			Batch:   batch,
+		},
+		Kafka: Kafka{
+			Compression:  "none",
+			RequiredAcks: "all",
+			MaxAttempts:  10,
+			BatchSize:    100,
+			BatchTimeout: time.Second,
+			QueueSize:    10000,
+			WriteTimeout: 10 * time.Second,
+		},
+		OTLP: OTLP{
+			Protocol: "grpc",
+			Timeout:  10 * time.Second,
+			Batch:    batch,
+		},
+	}
+}
+
+// validate checks the output sinks settings and returns the errors found
+func (o Outputs) validate() []string {
+
+	var errs []string
+
+	for _, name := range o.Sinks {
+
+		var missing string
+
+		switch name {
+		case SinkPrometheus:
+		case SinkRemoteWrite:
+			if o.RemoteWrite.URL == "" {
+				missing = "outputs.remote_write.url"
+			}
+		case SinkInfluxDB:
+			if o.InfluxDB.URL == "" {
+				missing = "outputs.influxdb.url"
+			}
+		case SinkInfluxDBFile:
+			if o.InfluxDB.File == "" {
+				missing = "outputs.influxdb.file"
+			}
+		case SinkKafka:
+			if len(o.Kafka.Brokers) == 0 || (o.Kafka.TelemetryTopic == "" && o.Kafka.SamplesTopic == "") {
+				missing = "outputs.kafka.brokers and a telemetry or samples topic"
+			}
+		case SinkOTLP:
+			if o.OTLP.Endpoint == "" {
+				missing = "outputs.otlp.endpoint"
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("outputs.sinks %q is not a supported output sink", name))
+		}
+
+		if missing != "" {
+			errs = append(errs, fmt.Sprintf("outputs.sinks %v requires %v", name, missing))
+		}
+	}
+
+	switch o.OTLP.Protocol {
+	case "grpc", "http":
+	default:
+		errs = append(errs, fmt.Sprintf("outputs.otlp.protocol %q must be grpc or http", o.OTLP.Protocol))
+	}
+
+	switch strings.ToLower(o.Kafka.Compression) {
+	case "none", "gzip", "snappy", "lz4", "zstd":
+	default:
+		errs = append(errs, fmt.Sprintf("outputs.kafka.compression %q must be none, gzip, snappy, lz4 or zstd",
+			o.Kafka.Compression))
+	}
+
+	switch strings.ToLower(o.Kafka.RequiredAcks) {
+	case "none", "one", "all":
+	default:
+		errs = append(errs, fmt.Sprintf("outputs.kafka.required_acks %q must be none, one or all", o.Kafka.RequiredAcks))
+	}
+
+	for _, h := range o.OTLP.Headers {
+		if kv := strings.SplitN(h, "=", 2); len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			errs = append(errs, "outputs.otlp.headers entries must be in the key=value format")
+			break
+		}
+	}
+
+	for _, b := range []struct {
+		key   string
+		batch Batch
+	}{
+		{"outputs.remote_write.batch", o.RemoteWrite.Batch},
+		{"outputs.influxdb.batch", o.InfluxDB.Batch},
+		{"outputs.otlp.batch", o.OTLP.Batch},
+	} {
+		if b.batch.Size <= 0 || b.batch.FlushInterval <= 0 || b.batch.QueueSize <= 0 {
+			errs = append(errs, fmt.Sprintf("%v size, flush_interval and queue_size must be positive", b.key))
+		}
+
+		if b.batch.MaxRetries < 0 {
+			errs = append(errs, fmt.Sprintf("%v.max_retries must not be negative", b.key))
+		}
+	}
+
+	for _, positive := range []struct {
+		key   string
+		value int64
+	}{
+		{"outputs.remote_write.timeout", int64(o.RemoteWrite.Timeout)},
+		{"outputs.influxdb.timeout", int64(o.InfluxDB.Timeout)},
+		{"outputs.kafka.max_attempts", int64(o.Kafka.MaxAttempts)},
+		{"outputs.kafka.batch_size", int64(o.Kafka.BatchSize)},
+		{"outputs.kafka.batch_timeout", int64(o.Kafka.BatchTimeout)},
+		{"outputs.kafka.queue_size", int64(o.Kafka.QueueSize)},
+		{"outputs.kafka.write_timeout", int64(o.Kafka.WriteTimeout)},
+		{"outputs.otlp.timeout", int64(o.OTLP.Timeout)},
+	} {
+		if positive.value <= 0 {
+			errs = append(errs, fmt.Sprintf("%v must be positive", positive.key))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	stringSliceType = reflect.TypeOf([]string(nil))
+)
+
+// setting represents a leaf of the configuration
+type setting struct {
+	// YAML path of the setting. i.e. metadb.max_conns
+	key    string
+	flag   string
+	env    string
+	desc   string
+	secret bool
+
+	value reflect.Value
+}
+
+// settings walks the configuration structure and returns its leaves
+func (c *Config) settings() []setting {
+	return walkSettings(reflect.ValueOf(c).Elem(), "", "")
+}
+
+// walkSettings returns the leaves of a configuration section. The env tag of a section prefixes the environment
+// variables of its leaves so a section type can be shared. i.e. the batching settings of the output sinks
+func walkSettings(v reflect.Value, prefix string, envPrefix string) []setting {
+
+	var settings []setting
+
+	for i := 0; i < v.NumField(); i++ {
+
+		f := v.Type().Field(i)
+		key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		env := f.Tag.Get("env")
+
+		if envPrefix != "" && env != "" {
+			env = envPrefix + "_" + env
+		}
+
+		if f.Type.Kind() == reflect.Struct {
+			settings = append(settings, walkSettings(v.Field(i), key, env)...)
+			continue
+		}
+
+		settings = append(settings, setting{
+			key:    key,
+			flag:   strings.Replace(key, "_", "-", -1),
+			env:    env,
+			desc:   f.Tag.Get("desc"),
+			secret: f.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+
+	return settings
+}
+
+func settingByFlag(settings []setting, name string) setting {
+
+	for _, s := range settings {
+		if s.flag == name {
+			return s
+		}
+	}
+
+	return setting{}
+}
+
+// set parses the string value into the setting
+func (s setting) set(v string) error {
+
+	switch {
+	case s.value.Type() == durationType:
+
+		d, err := time.ParseDuration(v)
+
+		if err != nil {
+			return fmt.Errorf("invalid duration %q for %v", v, s.key)
+		}
+		s.value.SetInt(int64(d))
+
+	case s.value.Kind() == reflect.String:
+		s.value.SetString(v)
+
+	case s.value.Type() == stringSliceType:
+
+		var values []string
+
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(values))
+
+	case s.value.Kind() == reflect.Int:
+
+		n, err := strconv.Atoi(v)
+
+		if err != nil {
+			return fmt.Errorf("invalid integer %q for %v", v, s.key)
+		}
+		s.value.SetInt(int64(n))
+
+	case s.value.Kind() == reflect.Float64:
+
+		f, err := strconv.ParseFloat(v, 64)
+
+		if err != nil {
+			return fmt.Errorf("invalid number %q for %v", v, s.key)
+		}
+		s.value.SetFloat(f)
+
+	case s.value.Kind() == reflect.Bool:
+
+		b, err := strconv.ParseBool(v)
+
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q for %v", v, s.key)
+		}
+		s.value.SetBool(b)
+
+	default:
+		return fmt.Errorf("unsupported setting type %v for %v", s.value.Type(), s.key)
+	}
+
+	return nil
+}
+
+// text returns the setting value as set in the environment or on the command line
+func (s setting) text() string {
+
+	if s.value.Type() == stringSliceType {
+		return strings.Join(s.value.Interface().([]string), ",")
+	}
+
+	return fmt.Sprint(s.value.Interface())
+}
+
+// stringFlag holds the raw value of a command line flag
+type stringFlag string
+
+func (f *stringFlag) String() string {
+
+	if f == nil {
+		return ""
+	}
+
+	return string(*f)
+}
+
+func (f *stringFlag) Set(v string) error {
+
+	*f = stringFlag(v)
+
+	return nil
+}
+
+// boolFlag holds the raw value of a boolean command line flag which can be set without value
+type boolFlag string
+
+func (f *boolFlag) String() string {
+	return (*stringFlag)(f).String()
+}
+
+func (f *boolFlag) Set(v string) error {
+	return (*stringFlag)(f).Set(v)
+}
+
+func (f *boolFlag) IsBoolFlag() bool {
+	return true
+}
@@ -0,0 +1,101 @@
+// Package decoder normalises the Telemetry payload encodings streamed by the devices into the self-describing
+// kvGPB tree consumed by the metrics parsers
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+// Encoding represents the encoding of a Telemetry payload
+type Encoding int
+
+const (
+	// EncodingKVGPB is the self-describing key/value Google Protocol Buffers encoding
+	EncodingKVGPB Encoding = iota
+
+	// EncodingGPB is the compact Google Protocol Buffers encoding relying on per YANG model protos
+	EncodingGPB
+
+	// EncodingJSON is the JSON encoding
+	EncodingJSON
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingKVGPB:
+		return "kvgpb"
+	case EncodingGPB:
+		return "gpb"
+	case EncodingJSON:
+		return "json"
+	}
+	return "unknown"
+}
+
+// Decode detects the encoding of a Telemetry payload and returns it as a Telemetry message carrying the
+// data in the self-describing kvGPB form. Each row of the message holds a "keys" and a "content" field.
+func Decode(data []byte) (*telemetry.Telemetry, Encoding, error) {
+
+	if len(data) == 0 {
+		return nil, EncodingKVGPB, fmt.Errorf("empty Telemetry payload")
+	}
+
+	// A JSON payload is an object. The first byte of a Telemetry protocol buffer message is a field tag
+	// and 0x7b is never a valid one, whereas JSON whitespace may be (i.e. 0x0a is the tag of node_id_str).
+	if data[0] == '{' {
+		return decodeJSONPayload(data)
+	}
+
+	msg := &telemetry.Telemetry{}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+
+		// JSON payload with leading whitespace
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			return decodeJSONPayload(trimmed)
+		}
+
+		return nil, EncodingKVGPB, fmt.Errorf("unable to unmarshal Telemetry protocol buffer message: %v", err)
+	}
+
+	if len(msg.GetDataGpb().GetRow()) == 0 {
+		return msg, EncodingKVGPB, nil
+	}
+
+	rows, err := decodeGPBTable(msg.GetEncodingPath(), msg.GetDataGpb())
+
+	if err != nil {
+		return nil, EncodingGPB, err
+	}
+
+	msg.DataGpbkv = rows
+	msg.DataGpb = nil
+
+	return msg, EncodingGPB, nil
+}
+
+func decodeJSONPayload(data []byte) (*telemetry.Telemetry, Encoding, error) {
+
+	msg, err := decodeJSON(data)
+
+	if err != nil {
+		return nil, EncodingJSON, fmt.Errorf("unable to decode JSON Telemetry payload: %v", err)
+	}
+
+	return msg, EncodingJSON, nil
+}
+
+// newRow returns a kvGPB row holding the keys and content fields
+func newRow(timestamp uint64, keys []*telemetry.TelemetryField, content []*telemetry.TelemetryField) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{
+		Timestamp: timestamp,
+		Fields: []*telemetry.TelemetryField{
+			{Name: "keys", Fields: keys},
+			{Name: "content", Fields: content},
+		},
+	}
+}
@@ -0,0 +1,265 @@
+package decoder_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/lucabrasi83/peppamon_cisco/decoder"
+	"github.com/lucabrasi83/peppamon_cisco/proto/gpbmodels"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+const interfacesPath = "Cisco-IOS-XE-interfaces-oper:interfaces/interface"
+
+// flatten renders the kvGPB fields as path=value strings
+func flatten(prefix string, fields []*telemetry.TelemetryField) []string {
+
+	var out []string
+
+	for _, f := range fields {
+
+		path := f.GetName()
+
+		if prefix != "" {
+			path = prefix + "/" + path
+		}
+
+		if len(f.GetFields()) > 0 {
+			out = append(out, flatten(path, f.GetFields())...)
+			continue
+		}
+
+		var value interface{}
+
+		switch v := f.GetValueByType().(type) {
+		case *telemetry.TelemetryField_StringValue:
+			value = v.StringValue
+		case *telemetry.TelemetryField_BoolValue:
+			value = v.BoolValue
+		case *telemetry.TelemetryField_Uint32Value:
+			value = v.Uint32Value
+		case *telemetry.TelemetryField_Uint64Value:
+			value = v.Uint64Value
+		case *telemetry.TelemetryField_Sint64Value:
+			value = v.Sint64Value
+		case *telemetry.TelemetryField_DoubleValue:
+			value = v.DoubleValue
+		}
+
+		out = append(out, fmt.Sprintf("%v=%v", path, value))
+	}
+
+	return out
+}
+
+// containsInOrder returns whether want is a subsequence of got. Compact GPB rows hold the proto3 zero values of
+// the leaves missing from the payload.
+func containsInOrder(got, want []string) bool {
+
+	i := 0
+
+	for _, g := range got {
+		if i < len(want) && g == want[i] {
+			i++
+		}
+	}
+
+	return i == len(want)
+}
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+
+	b, err := proto.Marshal(msg)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// gpbMessage returns a compact GPB Telemetry message holding a single row
+func gpbMessage(t *testing.T, path string, keys, content []byte) []byte {
+
+	return marshal(t, &telemetry.Telemetry{
+		NodeId:       &telemetry.Telemetry_NodeIdStr{NodeIdStr: "r1"},
+		EncodingPath: path,
+		DataGpb: &telemetry.TelemetryGPBTable{
+			Row: []*telemetry.TelemetryRowGPB{{Timestamp: 1000, Keys: keys, Content: content}},
+		},
+	})
+}
+
+// appendVarint appends a raw varint field to an encoded message
+func appendVarint(msg []byte, field uint64, value uint64) []byte {
+
+	b := proto.NewBuffer(append([]byte(nil), msg...))
+	_ = b.EncodeVarint(field<<3 | proto.WireVarint)
+	_ = b.EncodeVarint(value)
+
+	return b.Bytes()
+}
+
+// appendBytes appends a raw length-delimited field to an encoded message
+func appendBytes(msg []byte, field uint64, value []byte) []byte {
+
+	b := proto.NewBuffer(append([]byte(nil), msg...))
+	_ = b.EncodeVarint(field<<3 | proto.WireBytes)
+	_ = b.EncodeRawBytes(value)
+
+	return b.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+
+	ifKeys := marshal(t, &gpbmodels.InterfaceKeys{Name: "Gi1"})
+	ifContent := marshal(t, &gpbmodels.Interface{
+		Name:        "Gi1",
+		AdminStatus: "if-state-up",
+		IfIndex:     7,
+		Statistics:  &gpbmodels.Interface_Statistics{InOctets: 1234},
+		DiffservInfo: []*gpbmodels.Interface_DiffservInfo{
+			{PolicyName: "p1"},
+			{PolicyName: "p2"},
+		},
+	})
+
+	kvgpb := marshal(t, &telemetry.Telemetry{
+		NodeId:       &telemetry.Telemetry_NodeIdStr{NodeIdStr: "r1"},
+		EncodingPath: interfacesPath,
+		DataGpbkv: []*telemetry.TelemetryField{{Timestamp: 1000, Fields: []*telemetry.TelemetryField{
+			{Name: "keys", Fields: []*telemetry.TelemetryField{
+				{Name: "name", ValueByType: &telemetry.TelemetryField_StringValue{StringValue: "Gi1"}},
+			}},
+			{Name: "content", Fields: []*telemetry.TelemetryField{
+				{Name: "mtu", ValueByType: &telemetry.TelemetryField_Uint32Value{Uint32Value: 1500}},
+			}},
+		}}},
+	})
+
+	jsonPayload := `{"node_id_str":"r1","encoding_path":"` + interfacesPath + `","collection_id":"12",
+		"data_json":[{"timestamp":1000,"keys":[{"name":"Gi1"}],"content":{"mtu":1500,"statistics":{"in-octets":"5"}}}]}`
+
+	tests := []struct {
+		name         string
+		payload      []byte
+		wantEncoding decoder.Encoding
+		wantFields   []string
+		wantErr      string
+	}{
+		{
+			name:         "kvGPB",
+			payload:      kvgpb,
+			wantEncoding: decoder.EncodingKVGPB,
+			wantFields:   []string{"keys/name=Gi1", "content/mtu=1500"},
+		},
+		{
+			name:         "compact GPB",
+			payload:      gpbMessage(t, interfacesPath, ifKeys, ifContent),
+			wantEncoding: decoder.EncodingGPB,
+			wantFields: []string{
+				"keys/name=Gi1",
+				"content/name=Gi1",
+				"content/admin-status=if-state-up",
+				"content/if-index=7",
+				"content/statistics/in-octets=1234",
+				"content/diffserv-info/policy-name=p1",
+				"content/diffserv-info/policy-name=p2",
+			},
+		},
+		{
+			name:         "JSON",
+			payload:      []byte(jsonPayload),
+			wantEncoding: decoder.EncodingJSON,
+			wantFields:   []string{"keys/name=Gi1", "content/mtu=1500", "content/statistics/in-octets=5"},
+		},
+		{
+			name:         "JSON with leading whitespace",
+			payload:      []byte("\n  " + jsonPayload),
+			wantEncoding: decoder.EncodingJSON,
+			wantFields:   []string{"keys/name=Gi1", "content/mtu=1500", "content/statistics/in-octets=5"},
+		},
+		{
+			name:    "empty payload",
+			payload: nil,
+			wantErr: "empty Telemetry payload",
+		},
+		{
+			name:         "invalid JSON",
+			payload:      []byte(`{"node_id_str":`),
+			wantEncoding: decoder.EncodingJSON,
+			wantErr:      "unable to decode JSON Telemetry payload",
+		},
+		{
+			name:         "compact GPB without model",
+			payload:      gpbMessage(t, "Cisco-IOS-XE-foo-oper:foo", ifKeys, ifContent),
+			wantEncoding: decoder.EncodingGPB,
+			wantErr:      "no compact GPB model registered for YANG path Cisco-IOS-XE-foo-oper:foo",
+		},
+		{
+			name:         "compact GPB field unknown to the model",
+			payload:      gpbMessage(t, interfacesPath, ifKeys, appendVarint(ifContent, 99, 1)),
+			wantEncoding: decoder.EncodingGPB,
+			wantErr:      "unable to decode compact GPB content",
+		},
+		{
+			name: "compact GPB field with another wire type",
+			// if-index is a varint in the model
+			payload:      gpbMessage(t, interfacesPath, ifKeys, appendBytes(ifContent, 6, []byte("x"))),
+			wantEncoding: decoder.EncodingGPB,
+			wantErr:      "unable to decode compact GPB content",
+		},
+		{
+			name: "compact GPB nested field unknown to the model",
+			// statistics container holding a field 99
+			payload: gpbMessage(t, interfacesPath, ifKeys,
+				appendBytes(ifContent, 14, appendVarint(nil, 99, 1))),
+			wantEncoding: decoder.EncodingGPB,
+			wantErr:      "unable to decode compact GPB content",
+		},
+		{
+			name:         "compact GPB keys unknown to the model",
+			payload:      gpbMessage(t, interfacesPath, appendBytes(ifKeys, 2, []byte("x")), ifContent),
+			wantEncoding: decoder.EncodingGPB,
+			wantErr:      "unable to decode compact GPB keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			msg, encoding, err := decoder.Decode(tt.payload)
+
+			if encoding != tt.wantEncoding {
+				t.Errorf("Decode() encoding = %v, want %v", encoding, tt.wantEncoding)
+			}
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			if msg.GetNodeIdStr() != "r1" || msg.GetEncodingPath() != interfacesPath {
+				t.Errorf("Decode() node %q path %q, want r1 %v", msg.GetNodeIdStr(), msg.GetEncodingPath(), interfacesPath)
+			}
+
+			if len(msg.GetDataGpbkv()) != 1 || msg.GetDataGpbkv()[0].GetTimestamp() != 1000 {
+				t.Fatalf("Decode() rows = %v, want a single row at 1000", msg.GetDataGpbkv())
+			}
+
+			got := flatten("", msg.GetDataGpbkv()[0].GetFields())
+
+			if !containsInOrder(got, tt.wantFields) {
+				t.Errorf("Decode() fields =\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(tt.wantFields, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+// GPBModel represents the generated protos of a YANG model used to decode compact GPB rows.
+// Keys and Content return a new empty message of the row keys and content respectively.
+type GPBModel struct {
+	Keys    func() proto.Message
+	Content func() proto.Message
+}
+
+// errUnknownFields is returned when a compact GPB row carries fields the model does not know about
+var errUnknownFields = errors.New("fields unknown to the model, the model does not match the device protos")
+
+var (
+	gpbModelsMutex sync.RWMutex
+	gpbModels      = make(map[string]GPBModel)
+)
+
+// RegisterGPBModel registers the generated protos of a YANG encoding path so compact GPB rows streamed
+// for that path can be decoded. It is meant to be called from the init function of the generated model package.
+func RegisterGPBModel(encodingPath string, model GPBModel) {
+
+	gpbModelsMutex.Lock()
+	defer gpbModelsMutex.Unlock()
+
+	gpbModels[encodingPath] = model
+}
+
+// decodeGPBTable converts the compact GPB rows of a Telemetry message into kvGPB rows
+func decodeGPBTable(encodingPath string, table *telemetry.TelemetryGPBTable) ([]*telemetry.TelemetryField, error) {
+
+	gpbModelsMutex.RLock()
+	model, ok := gpbModels[encodingPath]
+	gpbModelsMutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no compact GPB model registered for YANG path %v", encodingPath)
+	}
+
+	rows := make([]*telemetry.TelemetryField, 0, len(table.GetRow()))
+
+	for _, r := range table.GetRow() {
+
+		keys, err := decodeGPBRowPart(model.Keys, r.GetKeys())
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode compact GPB keys for YANG path %v: %v", encodingPath, err)
+		}
+
+		content, err := decodeGPBRowPart(model.Content, r.GetContent())
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode compact GPB content for YANG path %v: %v", encodingPath, err)
+		}
+
+		rows = append(rows, newRow(r.GetTimestamp(), keys, content))
+	}
+
+	return rows, nil
+}
+
+func decodeGPBRowPart(newMsg func() proto.Message, data []byte) ([]*telemetry.TelemetryField, error) {
+
+	if newMsg == nil || len(data) == 0 {
+		return nil, nil
+	}
+
+	msg := newMsg()
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+
+	// Fields missing from the model or with another wire type are kept aside as unrecognized. Decoding the row
+	// anyway would map the values of a model not matching the device protos to the wrong leaves.
+	if hasUnknownFields(reflect.ValueOf(msg)) {
+		return nil, errUnknownFields
+	}
+
+	return messageFields(reflect.ValueOf(msg)), nil
+}
+
+// hasUnknownFields returns whether the generated proto message or one of its nested messages holds unrecognized
+// fields
+func hasUnknownFields(v reflect.Value) bool {
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+
+			if v.Type().Field(i).Name == "XXX_unrecognized" {
+				if v.Field(i).Len() > 0 {
+					return true
+				}
+				continue
+			}
+
+			if v.Type().Field(i).PkgPath == "" && hasUnknownFields(v.Field(i)) {
+				return true
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if hasUnknownFields(v.Index(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// messageFields walks a generated proto message and returns its populated fields in kvGPB form.
+// Proto field names are converted back to YANG names (underscores replaced by hyphens).
+func messageFields(v reflect.Value) []*telemetry.TelemetryField {
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []*telemetry.TelemetryField
+
+	for i := 0; i < v.NumField(); i++ {
+
+		sf := v.Type().Field(i)
+
+		// Oneof wrapper holds a single tagged field
+		if _, ok := sf.Tag.Lookup("protobuf_oneof"); ok {
+			fields = append(fields, messageFields(v.Field(i))...)
+			continue
+		}
+
+		name := protoFieldName(sf.Tag.Get("protobuf"))
+
+		if name == "" {
+			continue
+		}
+
+		fields = append(fields, valueFields(name, v.Field(i))...)
+	}
+
+	return fields
+}
+
+// valueFields converts a proto field value into kvGPB fields. Repeated fields produce one field per element.
+func valueFields(name string, v reflect.Value) []*telemetry.TelemetryField {
+
+	f := &telemetry.TelemetryField{Name: name}
+
+	// Enumerations are exported with their YANG name
+	if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() == reflect.Int32 {
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: s.String()}
+		return []*telemetry.TelemetryField{f}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Struct:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil
+		}
+		f.Fields = messageFields(v)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			f.ValueByType = &telemetry.TelemetryField_BytesValue{BytesValue: v.Bytes()}
+			break
+		}
+		var repeated []*telemetry.TelemetryField
+		for i := 0; i < v.Len(); i++ {
+			repeated = append(repeated, valueFields(name, v.Index(i))...)
+		}
+		return repeated
+	case reflect.String:
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: v.String()}
+	case reflect.Bool:
+		f.ValueByType = &telemetry.TelemetryField_BoolValue{BoolValue: v.Bool()}
+	case reflect.Uint32:
+		f.ValueByType = &telemetry.TelemetryField_Uint32Value{Uint32Value: uint32(v.Uint())}
+	case reflect.Uint64:
+		f.ValueByType = &telemetry.TelemetryField_Uint64Value{Uint64Value: v.Uint()}
+	case reflect.Int32:
+		f.ValueByType = &telemetry.TelemetryField_Sint32Value{Sint32Value: int32(v.Int())}
+	case reflect.Int64:
+		f.ValueByType = &telemetry.TelemetryField_Sint64Value{Sint64Value: v.Int()}
+	case reflect.Float32:
+		f.ValueByType = &telemetry.TelemetryField_FloatValue{FloatValue: float32(v.Float())}
+	case reflect.Float64:
+		f.ValueByType = &telemetry.TelemetryField_DoubleValue{DoubleValue: v.Float()}
+	default:
+		return nil
+	}
+
+	return []*telemetry.TelemetryField{f}
+}
+
+// protoFieldName extracts the field name from a generated protobuf struct tag.
+// i.e. "varint,1,opt,name=in_octets,json=inOctets,proto3" returns "in-octets"
+func protoFieldName(tag string) string {
+
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.Replace(strings.TrimPrefix(part, "name="), "_", "-", -1)
+		}
+	}
+
+	return ""
+}
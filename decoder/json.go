@@ -0,0 +1,262 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+// jsonTelemetry represents a JSON encoded Telemetry message. Numbers may be encoded as JSON numbers or strings
+type jsonTelemetry struct {
+	NodeIDStr           string          `json:"node_id_str"`
+	SubscriptionIDStr   string          `json:"subscription_id_str"`
+	EncodingPath        string          `json:"encoding_path"`
+	CollectionID        json.RawMessage `json:"collection_id"`
+	CollectionStartTime json.RawMessage `json:"collection_start_time"`
+	MsgTimestamp        json.RawMessage `json:"msg_timestamp"`
+	CollectionEndTime   json.RawMessage `json:"collection_end_time"`
+	DataJSON            []jsonRow       `json:"data_json"`
+}
+
+// jsonRow represents a row of a JSON encoded Telemetry message.
+// Keys can be encoded as an object or as an array of objects
+type jsonRow struct {
+	Timestamp json.RawMessage `json:"timestamp"`
+	Keys      json.RawMessage `json:"keys"`
+	Content   json.RawMessage `json:"content"`
+}
+
+// decodeJSON converts a JSON encoded Telemetry message into a kvGPB Telemetry message
+func decodeJSON(payload []byte) (*telemetry.Telemetry, error) {
+
+	var jt jsonTelemetry
+
+	if err := json.Unmarshal(payload, &jt); err != nil {
+		return nil, err
+	}
+
+	msg := &telemetry.Telemetry{
+		NodeId:       &telemetry.Telemetry_NodeIdStr{NodeIdStr: jt.NodeIDStr},
+		Subscription: &telemetry.Telemetry_SubscriptionIdStr{SubscriptionIdStr: jt.SubscriptionIDStr},
+		EncodingPath: jt.EncodingPath,
+	}
+
+	var err error
+
+	if msg.CollectionId, err = jsonUint64(jt.CollectionID); err != nil {
+		return nil, fmt.Errorf("invalid collection_id: %v", err)
+	}
+
+	if msg.CollectionStartTime, err = jsonUint64(jt.CollectionStartTime); err != nil {
+		return nil, fmt.Errorf("invalid collection_start_time: %v", err)
+	}
+
+	if msg.MsgTimestamp, err = jsonUint64(jt.MsgTimestamp); err != nil {
+		return nil, fmt.Errorf("invalid msg_timestamp: %v", err)
+	}
+
+	if msg.CollectionEndTime, err = jsonUint64(jt.CollectionEndTime); err != nil {
+		return nil, fmt.Errorf("invalid collection_end_time: %v", err)
+	}
+
+	for _, r := range jt.DataJSON {
+
+		timestamp, err := jsonUint64(r.Timestamp)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid row timestamp: %v", err)
+		}
+
+		keys, err := jsonFields(r.Keys)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid row keys: %v", err)
+		}
+
+		content, err := jsonFields(r.Content)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid row content: %v", err)
+		}
+
+		msg.DataGpbkv = append(msg.DataGpbkv, newRow(timestamp, keys, content))
+	}
+
+	return msg, nil
+}
+
+// jsonUint64 decodes an unsigned integer encoded either as a JSON number or string
+func jsonUint64(raw json.RawMessage) (uint64, error) {
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err != nil {
+		s = string(raw)
+	}
+
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// jsonFields converts a JSON object, or array of objects, into kvGPB fields preserving the order of the members
+func jsonFields(raw json.RawMessage) ([]*telemetry.TelemetryField, error) {
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		return jsonObjectFields(dec)
+
+	case json.Delim('['):
+
+		var fields []*telemetry.TelemetryField
+
+		for dec.More() {
+
+			tok, err := dec.Token()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if tok != json.Delim('{') {
+				return nil, fmt.Errorf("expected object in array, got %v", tok)
+			}
+
+			objFields, err := jsonObjectFields(dec)
+
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, objFields...)
+		}
+
+		return fields, nil
+	}
+
+	return nil, fmt.Errorf("expected object or array, got %v", tok)
+}
+
+// jsonObjectFields reads the members of a JSON object whose opening delimiter was already consumed
+func jsonObjectFields(dec *json.Decoder) ([]*telemetry.TelemetryField, error) {
+
+	var fields []*telemetry.TelemetryField
+
+	for dec.More() {
+
+		tok, err := dec.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := tok.(string)
+
+		if !ok {
+			return nil, fmt.Errorf("expected object member name, got %v", tok)
+		}
+
+		valueFields, err := jsonValueFields(dec, name)
+
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, valueFields...)
+	}
+
+	// Consume closing delimiter
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// jsonValueFields reads a JSON value and returns it as kvGPB fields.
+// Arrays (YANG lists and leaf-lists) produce one field per element, like kvGPB does.
+func jsonValueFields(dec *json.Decoder, name string) ([]*telemetry.TelemetryField, error) {
+
+	tok, err := dec.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := &telemetry.TelemetryField{Name: name}
+
+	switch v := tok.(type) {
+	case json.Delim:
+
+		switch v {
+		case '{':
+			if f.Fields, err = jsonObjectFields(dec); err != nil {
+				return nil, err
+			}
+
+		case '[':
+			var repeated []*telemetry.TelemetryField
+
+			for dec.More() {
+
+				elemFields, err := jsonValueFields(dec, name)
+
+				if err != nil {
+					return nil, err
+				}
+				repeated = append(repeated, elemFields...)
+			}
+
+			// Consume closing delimiter
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return repeated, nil
+
+		default:
+			return nil, fmt.Errorf("unexpected delimiter %v", v)
+		}
+
+	case string:
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: v}
+
+	case bool:
+		f.ValueByType = &telemetry.TelemetryField_BoolValue{BoolValue: v}
+
+	case json.Number:
+		if u, errNum := strconv.ParseUint(v.String(), 10, 64); errNum == nil {
+			f.ValueByType = &telemetry.TelemetryField_Uint64Value{Uint64Value: u}
+		} else if i, errNum := strconv.ParseInt(v.String(), 10, 64); errNum == nil {
+			f.ValueByType = &telemetry.TelemetryField_Sint64Value{Sint64Value: i}
+		} else if d, errNum := v.Float64(); errNum == nil {
+			f.ValueByType = &telemetry.TelemetryField_DoubleValue{DoubleValue: d}
+		} else {
+			return nil, fmt.Errorf("invalid number %v for %v", v, name)
+		}
+
+	case nil:
+		// Empty YANG leaf
+	}
+
+	return []*telemetry.TelemetryField{f}, nil
+}
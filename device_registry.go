@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/lucabrasi83/peppamon_cisco/devices"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// Device registry admin endpoints
+const registryDevicesPath = "/api/registry/devices"
+
+// registryDevicePayload represents the attributes of a device replaced with PUT
+type registryDevicePayload struct {
+	Attributes map[string]string `json:"attributes"`
+}
+
+// registryDevicesHandler lists the registered devices with GET and registers a device with POST
+func registryDevicesHandler(r *devices.Registry) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		if r == nil {
+			http.Error(w, "device registry requires the KV store", http.StatusServiceUnavailable)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, r.List())
+
+		case http.MethodPost:
+
+			var d devices.Device
+
+			if err := json.NewDecoder(req.Body).Decode(&d); err != nil {
+				http.Error(w, "invalid JSON payload: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if msg := validateRegistryDevice(d); msg != "" {
+				http.Error(w, msg, http.StatusBadRequest)
+				return
+			}
+
+			// The device is created only when missing from the KV store, whatever the state of the cache
+			created, err := r.Create(d)
+
+			if err != nil {
+				logging.PeppaMonLog(
+					"error",
+					"Failed to save device %v in KV store: %v", d.NodeID, err)
+
+				http.Error(w, "failed to save device", http.StatusInternalServerError)
+				return
+			}
+
+			if !created {
+				http.Error(w, "device "+d.NodeID+" already registered", http.StatusConflict)
+				return
+			}
+
+			saved, _ := r.Get(d.NodeID)
+
+			writeJSON(w, http.StatusCreated, saved)
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// registryDeviceHandler returns the device with GET, replaces its attributes with PUT and removes it with DELETE
+func registryDeviceHandler(r *devices.Registry) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		if r == nil {
+			http.Error(w, "device registry requires the KV store", http.StatusServiceUnavailable)
+			return
+		}
+
+		node := strings.TrimPrefix(req.URL.Path, registryDevicesPath+"/")
+
+		if node == "" || strings.Contains(node, "/") {
+			http.NotFound(w, req)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet:
+
+			d, ok := r.Get(node)
+
+			if !ok {
+				http.Error(w, "device "+node+" not registered", http.StatusNotFound)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, d)
+
+		case http.MethodPut:
+
+			var p registryDevicePayload
+
+			if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+				http.Error(w, "invalid JSON payload: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			d := devices.Device{NodeID: node, Attributes: p.Attributes}
+
+			if msg := validateRegistryDevice(d); msg != "" {
+				http.Error(w, msg, http.StatusBadRequest)
+				return
+			}
+
+			saveRegistryDevice(w, r, d, http.StatusOK)
+
+		case http.MethodDelete:
+
+			removed, err := r.Delete(node)
+
+			if err != nil {
+				logging.PeppaMonLog(
+					"error",
+					"Failed to remove device %v from KV store: %v", node, err)
+
+				http.Error(w, "failed to remove device", http.StatusInternalServerError)
+				return
+			}
+
+			if !removed {
+				http.Error(w, "device "+node+" not registered", http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// validateRegistryDevice returns the reason why the device cannot be registered, empty when valid
+func validateRegistryDevice(d devices.Device) string {
+
+	if d.NodeID == "" || strings.Contains(d.NodeID, "/") {
+		return "nodeId must be set and must not contain /"
+	}
+
+	for name := range d.Attributes {
+		if name == "" {
+			return "attribute names must not be empty"
+		}
+	}
+
+	return ""
+}
+
+// saveRegistryDevice stores the device in the registry and renders it
+func saveRegistryDevice(w http.ResponseWriter, r *devices.Registry, d devices.Device, code int) {
+
+	if err := r.Save(d); err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to save device %v in KV store: %v", d.NodeID, err)
+
+		http.Error(w, "failed to save device", http.StatusInternalServerError)
+		return
+	}
+
+	saved, _ := r.Get(d.NodeID)
+
+	writeJSON(w, code, saved)
+}
@@ -0,0 +1,252 @@
+// Package devices keeps the registry of the Telemetry devices and their attributes such as the hostname, site or
+// role. Redis is the source of truth, the registry is cached in memory as it is looked up for every Telemetry message.
+package devices
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/kvstore"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+// Device represents a registered device identified by its Telemetry node ID
+type Device struct {
+	NodeID     string            `json:"nodeId"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Registry represents the in-memory cache of the device registry stored in the KV store
+type Registry struct {
+	mu      *sync.RWMutex
+	devices map[string]map[string]string
+
+	// Incremented on every change of the cache so a reload does not overwrite the changes made while loading
+	generation uint64
+
+	// Device attributes added as labels to the metrics
+	labels []string
+
+	refreshInterval     time.Duration
+	requireRegistration bool
+}
+
+// NewRegistry will create a new instance of the device registry
+func NewRegistry(cfg config.Registry) *Registry {
+
+	return &Registry{
+		mu:                  &sync.RWMutex{},
+		devices:             make(map[string]map[string]string),
+		labels:              cfg.Labels,
+		refreshInterval:     cfg.RefreshInterval,
+		requireRegistration: cfg.RequireRegistration,
+	}
+}
+
+// Start loads the registry from the KV store and reloads it on interval until the context is done.
+// Changes made by other collector instances sharing the KV store are picked up on reload.
+func (r *Registry) Start(ctx context.Context) {
+
+	if err := r.refresh(); err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to load device registry from KV store, retrying in %v: %v", r.refreshInterval, err)
+	}
+
+	go func() {
+
+		t := time.NewTicker(r.refreshInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := r.refresh(); err != nil {
+					logging.PeppaMonLog(
+						"error",
+						"Failed to reload device registry from KV store: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// refresh replaces the cache with the devices stored in the KV store.
+// The devices loaded are discarded when the cache changed during the load, the next refresh picks them up.
+func (r *Registry) refresh() error {
+
+	r.mu.RLock()
+	generation := r.generation
+	r.mu.RUnlock()
+
+	devices, err := kvstore.LoadDevices()
+
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.generation != generation {
+		logging.PeppaMonLog(
+			"debug",
+			"Device registry changed while reloading from KV store, reload skipped")
+		return nil
+	}
+
+	r.devices = devices
+	r.generation++
+
+	return nil
+}
+
+// Get returns the registered device
+func (r *Registry) Get(node string) (Device, bool) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attributes, ok := r.devices[node]
+
+	if !ok {
+		return Device{}, false
+	}
+
+	return Device{NodeID: node, Attributes: copyAttributes(attributes)}, true
+}
+
+// List returns the registered devices sorted by node ID
+func (r *Registry) List() []Device {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	devices := make([]Device, 0, len(r.devices))
+
+	for node, attributes := range r.devices {
+		devices = append(devices, Device{NodeID: node, Attributes: copyAttributes(attributes)})
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].NodeID < devices[j].NodeID
+	})
+
+	return devices
+}
+
+// Create registers the device and returns false when it is already registered
+func (r *Registry) Create(d Device) (bool, error) {
+
+	attributes := copyAttributes(d.Attributes)
+
+	created, err := kvstore.CreateDevice(d.NodeID, attributes)
+
+	if err != nil || !created {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.devices[d.NodeID] = attributes
+	r.generation++
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+// Save creates the device or replaces its attributes
+func (r *Registry) Save(d Device) error {
+
+	attributes := copyAttributes(d.Attributes)
+
+	if err := kvstore.SaveDevice(d.NodeID, attributes); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.devices[d.NodeID] = attributes
+	r.generation++
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Delete removes the device from the registry and returns whether it was registered
+func (r *Registry) Delete(node string) (bool, error) {
+
+	removed, err := kvstore.DeleteDevice(node)
+
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	delete(r.devices, node)
+	r.generation++
+	r.mu.Unlock()
+
+	return removed, nil
+}
+
+// Registered returns whether the device is part of the registry
+func (r *Registry) Registered(node string) bool {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.devices[node]
+
+	return ok
+}
+
+// RequireRegistration returns whether the Telemetry streams of unregistered devices are rejected
+func (r *Registry) RequireRegistration() bool {
+	return r.requireRegistration
+}
+
+// Size returns the number of registered devices
+func (r *Registry) Size() int {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.devices)
+}
+
+// NodeLabels returns the configured attributes of the device as metric labels and implements
+// metrics.NodeLabeler. Every configured label is returned, empty for the unknown devices and attributes.
+func (r *Registry) NodeLabels(node string) []metrics.Label {
+
+	if len(r.labels) == 0 {
+		return nil
+	}
+
+	r.mu.RLock()
+	attributes := r.devices[node]
+	r.mu.RUnlock()
+
+	labels := make([]metrics.Label, len(r.labels))
+
+	for i, name := range r.labels {
+		labels[i] = metrics.Label{Name: name, Value: attributes[name]}
+	}
+
+	return labels
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+
+	c := make(map[string]string, len(attributes))
+
+	for k, v := range attributes {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,233 @@
+package dialin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// Default gNMI port on IOS-XE devices
+	defaultGNMIPort = "9339"
+
+	// Default sample interval for SAMPLE subscriptions
+	defaultSampleInterval = 30 * time.Second
+)
+
+// Target represents a device Peppamon opens a gNMI Subscribe session to
+type Target struct {
+	// Device gNMI socket (host:port)
+	Address string `json:"address"`
+
+	// Telemetry Node ID used as metrics node label. Defaults to the device address
+	Node string `json:"node"`
+
+	// gNMI credentials sent as gRPC metadata. Password can be read from an environment variable
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	PasswordEnv string `json:"password_env"`
+
+	// gNMI payload encoding requested to the device. Defaults to JSON_IETF
+	Encoding string `json:"encoding"`
+
+	// TLS settings of the gNMI session
+	TLS                bool   `json:"tls"`
+	CAFile             string `json:"ca_file"`
+	CertFile           string `json:"cert_file"`
+	KeyFile            string `json:"key_file"`
+	ServerName         string `json:"server_name"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// Subscription represents a gNMI subscription to a YANG path
+type Subscription struct {
+	// YANG path which is also used as Telemetry encoding path.
+	// i.e. Cisco-IOS-XE-interfaces-oper:interfaces/interface
+	Path string `json:"path"`
+
+	// gNMI path origin. Usually empty or "rfc7951" on IOS-XE
+	Origin string `json:"origin"`
+
+	// Subscription mode: SAMPLE or ON_CHANGE
+	Mode string `json:"mode"`
+
+	// Sample interval for SAMPLE subscriptions. i.e. 30s
+	SampleInterval string `json:"sample_interval"`
+
+	// Keys of the YANG list at the end of the path. Only used when the device returns
+	// the list entries as a JSON array rather than as keyed gNMI paths
+	Keys []string `json:"keys"`
+
+	sampleInterval time.Duration
+	mode           gnmi.SubscriptionMode
+	elems          []*gnmi.PathElem
+}
+
+// targetsFile represents the gNMI dial-in targets JSON file
+type targetsFile struct {
+	Targets []Target `json:"targets"`
+}
+
+// LoadTargets reads and validates the gNMI dial-in targets file
+func LoadTargets(file string) ([]Target, error) {
+
+	b, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var tf targetsFile
+
+	if err := json.Unmarshal(b, &tf); err != nil {
+		return nil, fmt.Errorf("unable to decode gNMI targets file %v: %v", file, err)
+	}
+
+	for i := range tf.Targets {
+		if err := tf.Targets[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return tf.Targets, nil
+}
+
+func (t *Target) validate() error {
+
+	if t.Address == "" {
+		return fmt.Errorf("gNMI target address is missing")
+	}
+
+	host, _, err := net.SplitHostPort(t.Address)
+
+	// Address without port, IPv6 addresses may be enclosed in brackets
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(t.Address, "["), "]")
+		t.Address = net.JoinHostPort(host, defaultGNMIPort)
+	}
+
+	if t.Node == "" {
+		t.Node = host
+	}
+
+	if t.PasswordEnv != "" {
+		t.Password = os.Getenv(t.PasswordEnv)
+	}
+
+	if t.Encoding == "" {
+		t.Encoding = gnmi.Encoding_JSON_IETF.String()
+	}
+
+	if _, ok := gnmi.Encoding_value[strings.ToUpper(t.Encoding)]; !ok {
+		return fmt.Errorf("gNMI target %v unsupported encoding %v", t.Address, t.Encoding)
+	}
+
+	if len(t.Subscriptions) == 0 {
+		return fmt.Errorf("gNMI target %v has no subscription", t.Address)
+	}
+
+	for i := range t.Subscriptions {
+
+		sub := &t.Subscriptions[i]
+
+		elems, err := parsePath(sub.Path)
+
+		if err != nil {
+			return fmt.Errorf("gNMI target %v invalid subscription path %v: %v", t.Address, sub.Path, err)
+		}
+		sub.elems = elems
+
+		switch strings.ToUpper(sub.Mode) {
+		case "", "SAMPLE":
+			sub.mode = gnmi.SubscriptionMode_SAMPLE
+		case "ON_CHANGE":
+			sub.mode = gnmi.SubscriptionMode_ON_CHANGE
+		default:
+			return fmt.Errorf("gNMI target %v unsupported subscription mode %v", t.Address, sub.Mode)
+		}
+
+		sub.sampleInterval = defaultSampleInterval
+
+		if sub.SampleInterval != "" {
+			d, err := time.ParseDuration(sub.SampleInterval)
+
+			if err != nil || d <= 0 {
+				return fmt.Errorf("gNMI target %v invalid sample interval %v", t.Address, sub.SampleInterval)
+			}
+			sub.sampleInterval = d
+		}
+	}
+
+	return nil
+}
+
+// parsePath converts a YANG path string into gNMI path elements.
+// List keys can be specified with the XPath syntax. i.e. interfaces/interface[name=GigabitEthernet1]
+func parsePath(p string) ([]*gnmi.PathElem, error) {
+
+	var elems []*gnmi.PathElem
+
+	for _, segment := range splitPath(strings.Trim(p, "/")) {
+
+		if segment == "" {
+			return nil, fmt.Errorf("empty path element")
+		}
+
+		elem := &gnmi.PathElem{Name: segment}
+
+		if i := strings.Index(segment, "["); i >= 0 {
+
+			elem.Name = segment[:i]
+			elem.Key = make(map[string]string)
+
+			for _, kv := range strings.Split(strings.Trim(segment[i:], "[]"), "][") {
+
+				pair := strings.SplitN(kv, "=", 2)
+
+				if len(pair) != 2 {
+					return nil, fmt.Errorf("invalid key %v", kv)
+				}
+				elem.Key[pair[0]] = pair[1]
+			}
+		}
+		elems = append(elems, elem)
+	}
+
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("empty path")
+	}
+
+	return elems, nil
+}
+
+// splitPath splits a path on "/" while ignoring separators within list keys
+func splitPath(p string) []string {
+
+	var segments []string
+	var depth int
+	start := 0
+
+	for i, c := range p {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				segments = append(segments, p[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(segments, p[start:])
+}
@@ -0,0 +1,223 @@
+package dialin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const testPath = "Cisco-IOS-XE-test-oper:queues/queue"
+
+func TestTargetValidate(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		target       Target
+		wantAddress  string
+		wantNode     string
+		wantEncoding string
+		wantMode     gnmi.SubscriptionMode
+		wantInterval time.Duration
+		wantErr      string
+	}{
+		{
+			name:         "IPv4 address without port",
+			target:       Target{Address: "192.0.2.1"},
+			wantAddress:  "192.0.2.1:9339",
+			wantNode:     "192.0.2.1",
+			wantEncoding: "JSON_IETF",
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: 30 * time.Second,
+		},
+		{
+			name:         "hostname with port",
+			target:       Target{Address: "r1.example.net:57400", Encoding: "json"},
+			wantAddress:  "r1.example.net:57400",
+			wantNode:     "r1.example.net",
+			wantEncoding: "json",
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: 30 * time.Second,
+		},
+		{
+			name:         "IPv6 address in brackets without port",
+			target:       Target{Address: "[2001:db8::1]"},
+			wantAddress:  "[2001:db8::1]:9339",
+			wantNode:     "2001:db8::1",
+			wantEncoding: "JSON_IETF",
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: 30 * time.Second,
+		},
+		{
+			name:         "IPv6 address without brackets",
+			target:       Target{Address: "2001:db8::1"},
+			wantAddress:  "[2001:db8::1]:9339",
+			wantNode:     "2001:db8::1",
+			wantEncoding: "JSON_IETF",
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: 30 * time.Second,
+		},
+		{
+			name:         "IPv6 address with port and node",
+			target:       Target{Address: "[2001:db8::1]:57400", Node: "r1"},
+			wantAddress:  "[2001:db8::1]:57400",
+			wantNode:     "r1",
+			wantEncoding: "JSON_IETF",
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: 30 * time.Second,
+		},
+		{
+			name: "sample interval",
+			target: Target{Address: "192.0.2.1", Subscriptions: []Subscription{
+				{Path: testPath, Mode: "sample", SampleInterval: "10s"},
+			}},
+			wantAddress:  "192.0.2.1:9339",
+			wantNode:     "192.0.2.1",
+			wantEncoding: "JSON_IETF",
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: 10 * time.Second,
+		},
+		{
+			name: "on change",
+			target: Target{Address: "192.0.2.1", Subscriptions: []Subscription{
+				{Path: testPath, Mode: "ON_CHANGE"},
+			}},
+			wantAddress:  "192.0.2.1:9339",
+			wantNode:     "192.0.2.1",
+			wantEncoding: "JSON_IETF",
+			wantMode:     gnmi.SubscriptionMode_ON_CHANGE,
+			wantInterval: 30 * time.Second,
+		},
+		{
+			name:    "missing address",
+			target:  Target{},
+			wantErr: "gNMI target address is missing",
+		},
+		{
+			name:    "unsupported encoding",
+			target:  Target{Address: "192.0.2.1", Encoding: "XML"},
+			wantErr: "gNMI target 192.0.2.1:9339 unsupported encoding XML",
+		},
+		{
+			name:    "no subscription",
+			target:  Target{Address: "192.0.2.1", Subscriptions: []Subscription{}},
+			wantErr: "gNMI target 192.0.2.1:9339 has no subscription",
+		},
+		{
+			name: "unsupported mode",
+			target: Target{Address: "192.0.2.1", Subscriptions: []Subscription{
+				{Path: testPath, Mode: "POLL"},
+			}},
+			wantErr: "gNMI target 192.0.2.1:9339 unsupported subscription mode POLL",
+		},
+		{
+			name: "invalid sample interval",
+			target: Target{Address: "192.0.2.1", Subscriptions: []Subscription{
+				{Path: testPath, SampleInterval: "0s"},
+			}},
+			wantErr: "gNMI target 192.0.2.1:9339 invalid sample interval 0s",
+		},
+		{
+			name: "invalid path",
+			target: Target{Address: "192.0.2.1", Subscriptions: []Subscription{
+				{Path: "queues//queue"},
+			}},
+			wantErr: "gNMI target 192.0.2.1:9339 invalid subscription path queues//queue: empty path element",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			target := tt.target
+
+			if target.Subscriptions == nil {
+				target.Subscriptions = []Subscription{{Path: testPath}}
+			}
+
+			err := target.validate()
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("validate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+
+			if target.Address != tt.wantAddress || target.Node != tt.wantNode || target.Encoding != tt.wantEncoding {
+				t.Errorf("validate() address %v node %v encoding %v, want %v %v %v",
+					target.Address, target.Node, target.Encoding, tt.wantAddress, tt.wantNode, tt.wantEncoding)
+			}
+
+			sub := target.Subscriptions[0]
+
+			if sub.mode != tt.wantMode || sub.sampleInterval != tt.wantInterval {
+				t.Errorf("validate() mode %v interval %v, want %v %v",
+					sub.mode, sub.sampleInterval, tt.wantMode, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []*gnmi.PathElem
+		wantErr string
+	}{
+		{
+			name: "module prefix",
+			path: "/Cisco-IOS-XE-interfaces-oper:interfaces/interface/",
+			want: []*gnmi.PathElem{{Name: "Cisco-IOS-XE-interfaces-oper:interfaces"}, {Name: "interface"}},
+		},
+		{
+			name: "keys holding separators",
+			path: "interfaces/interface[name=GigabitEthernet1/0/1]/subinterface[vrf=a][index=1]",
+			want: []*gnmi.PathElem{
+				{Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"name": "GigabitEthernet1/0/1"}},
+				{Name: "subinterface", Key: map[string]string{"vrf": "a", "index": "1"}},
+			},
+		},
+		{
+			name:    "empty path",
+			path:    "/",
+			wantErr: "empty path element",
+		},
+		{
+			name:    "key without value",
+			path:    "interfaces/interface[name]",
+			wantErr: "invalid key name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := parsePath(tt.path)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parsePath() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parsePath() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
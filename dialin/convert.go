@@ -0,0 +1,681 @@
+package dialin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// Synthetic key used to identify JSON array entries that carry no YANG key
+	jsonArrayIndexKey = "#"
+
+	// Number of sample intervals after which a list entry not refreshed by the device is removed
+	sampleStaleIntervals = 3
+)
+
+// keyValue represents a YANG list key
+type keyValue struct {
+	name  string
+	value string
+}
+
+// pathSegment represents a YANG node within a list entry, with the keys of the node if it is a list entry itself
+type pathSegment struct {
+	name string
+	keys []keyValue
+}
+
+// leaf represents a YANG leaf value within a list entry
+type leaf struct {
+	segments []pathSegment
+	value    *telemetry.TelemetryField
+}
+
+// row represents a YANG list entry at the end of the subscription path.
+// It is converted to a kvGPB keys/content row so existing parsers can consume it
+type row struct {
+	keys      []keyValue
+	leaves    map[string]*leaf
+	lastSeen  time.Time
+	timestamp uint64
+}
+
+// snapshot keeps the current state of a gNMI subscription.
+// gNMI sends one notification per list entry (SAMPLE) or only changed leaves (ON_CHANGE),
+// whereas the metrics cache expects the complete set of list entries of a YANG path in each Telemetry message.
+type snapshot struct {
+	sub  *Subscription
+	rows map[string]*row
+}
+
+func newSnapshot(sub *Subscription) *snapshot {
+	return &snapshot{
+		sub:  sub,
+		rows: make(map[string]*row),
+	}
+}
+
+// errPathMismatch is returned when a notification path does not belong to the subscription
+var errPathMismatch = fmt.Errorf("path does not match subscription")
+
+// applyUpdate merges a gNMI Update into the snapshot
+func (s *snapshot) applyUpdate(prefix *gnmi.Path, u *gnmi.Update, ts int64) error {
+
+	full := fullPath(prefix, u.GetPath())
+
+	if !s.matches(full) {
+		return errPathMismatch
+	}
+
+	n := len(s.sub.elems)
+
+	// Update is rooted at the subscription list entry or one of its descendants
+	if len(full) >= n {
+
+		r := s.row(collectKeys(full[:n]), ts)
+		content := toSegments(full[n:])
+
+		return r.setValue(content, u.GetVal())
+	}
+
+	// Update is rooted above the subscription path. Value must be a JSON blob we need to walk down
+	v, err := typedValueJSON(u.GetVal())
+
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return fmt.Errorf("update for %v is above the subscription path and is not JSON encoded", s.sub.Path)
+	}
+
+	s.descendJSON(v, len(full), collectKeys(full), ts)
+
+	return nil
+}
+
+// applyDelete removes list entries or leaves deleted by the device.
+// It returns errPathMismatch when the deleted path does not belong to the subscription.
+func (s *snapshot) applyDelete(prefix *gnmi.Path, p *gnmi.Path) error {
+
+	full := fullPath(prefix, p)
+
+	if !s.matches(full) {
+		return errPathMismatch
+	}
+
+	n := len(s.sub.elems)
+
+	if len(full) > n {
+		if r, ok := s.rows[rowID(collectKeys(full[:n]))]; ok {
+			r.deleteLeaves(toSegments(full[n:]))
+		}
+		return nil
+	}
+
+	// Delete all list entries under the deleted path
+	deletedKeys := collectKeys(full)
+
+	for id, r := range s.rows {
+		if containsKeys(r.keys, deletedKeys) {
+			delete(s.rows, id)
+		}
+	}
+
+	return nil
+}
+
+// descendJSON walks a JSON value from the given depth down to the subscription list entries
+func (s *snapshot) descendJSON(v interface{}, depth int, keys []keyValue, ts int64) {
+
+	switch node := v.(type) {
+
+	case []interface{}:
+		for _, entry := range node {
+			s.descendJSON(entry, depth, append(keys[:len(keys):len(keys)], s.jsonEntryKeys(entry)...), ts)
+		}
+
+	case map[string]interface{}:
+
+		if depth == len(s.sub.elems) {
+
+			r := s.row(sortKeys(keys), ts)
+
+			for k, child := range node {
+				r.setJSON([]pathSegment{{name: stripModulePrefix(k)}}, child)
+			}
+			return
+		}
+
+		for k, child := range node {
+			if stripModulePrefix(k) == stripModulePrefix(s.sub.elems[depth].GetName()) {
+				s.descendJSON(child, depth+1, keys, ts)
+			}
+		}
+	}
+}
+
+// jsonEntryKeys extracts the configured YANG keys from a JSON list entry
+func (s *snapshot) jsonEntryKeys(entry interface{}) []keyValue {
+
+	obj, ok := entry.(map[string]interface{})
+
+	if !ok {
+		return nil
+	}
+
+	var keys []keyValue
+
+	for _, k := range s.sub.Keys {
+		for name, v := range obj {
+			if stripModulePrefix(name) == k {
+				keys = append(keys, keyValue{name: k, value: jsonScalarString(v)})
+			}
+		}
+	}
+
+	return keys
+}
+
+// matches verifies that the path names are the same as the subscription ones, ignoring YANG module prefixes
+func (s *snapshot) matches(full []*gnmi.PathElem) bool {
+
+	for i := 0; i < len(full) && i < len(s.sub.elems); i++ {
+
+		if stripModulePrefix(full[i].GetName()) != stripModulePrefix(s.sub.elems[i].GetName()) {
+			return false
+		}
+
+		// Keys set in the subscription path act as filter
+		for k, v := range s.sub.elems[i].GetKey() {
+			if full[i].GetKey()[k] != v {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *snapshot) row(keys []keyValue, ts int64) *row {
+
+	id := rowID(keys)
+
+	r, ok := s.rows[id]
+
+	if !ok {
+		r = &row{
+			keys:   keys,
+			leaves: make(map[string]*leaf),
+		}
+		s.rows[id] = r
+	}
+
+	r.lastSeen = time.Now()
+	r.timestamp = uint64(ts / int64(time.Millisecond))
+
+	return r
+}
+
+// telemetryMsg converts the snapshot into a kvGPB Telemetry message
+func (s *snapshot) telemetryMsg(node string, subscriptionID string, collectionID uint64) *telemetry.Telemetry {
+
+	// Remove list entries the device stopped sampling
+	if s.sub.mode == gnmi.SubscriptionMode_SAMPLE {
+
+		expiry := time.Duration(sampleStaleIntervals) * s.sub.sampleInterval
+
+		for id, r := range s.rows {
+			if time.Since(r.lastSeen) > expiry {
+				delete(s.rows, id)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(s.rows))
+
+	for id := range s.rows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var msgTimestamp uint64
+
+	rows := make([]*telemetry.TelemetryField, 0, len(ids))
+
+	for _, id := range ids {
+
+		r := s.rows[id]
+
+		if r.timestamp > msgTimestamp {
+			msgTimestamp = r.timestamp
+		}
+
+		rows = append(rows, r.telemetryField())
+	}
+
+	return &telemetry.Telemetry{
+		NodeId:              &telemetry.Telemetry_NodeIdStr{NodeIdStr: node},
+		Subscription:        &telemetry.Telemetry_SubscriptionIdStr{SubscriptionIdStr: subscriptionID},
+		EncodingPath:        s.sub.encodingPath(),
+		CollectionId:        collectionID,
+		CollectionStartTime: msgTimestamp,
+		MsgTimestamp:        msgTimestamp,
+		CollectionEndTime:   msgTimestamp,
+		DataGpbkv:           rows,
+	}
+}
+
+// setValue sets a gNMI TypedValue at the content path of the list entry
+func (r *row) setValue(content []pathSegment, tv *gnmi.TypedValue) error {
+
+	v, err := typedValueJSON(tv)
+
+	if err != nil {
+		return err
+	}
+
+	// JSON values replace the whole subtree
+	if v != nil {
+		r.deleteLeaves(content)
+
+		if obj, ok := v.(map[string]interface{}); ok {
+			for k, child := range obj {
+				r.setJSON(appendSegment(content, pathSegment{name: stripModulePrefix(k)}), child)
+			}
+			return nil
+		}
+
+		if len(content) == 0 {
+			return fmt.Errorf("unable to set JSON scalar value on list entry")
+		}
+		r.setJSON(content, v)
+
+		return nil
+	}
+
+	if len(content) == 0 {
+		return fmt.Errorf("unable to set scalar value on list entry")
+	}
+
+	name := content[len(content)-1].name
+
+	if leafList := tv.GetLeaflistVal(); leafList != nil {
+
+		r.deleteLeaves(content)
+
+		for i, e := range leafList.GetElement() {
+			f := typedValueToField(name, e)
+
+			if f != nil {
+				r.set(indexedSegments(content, i), f)
+			}
+		}
+		return nil
+	}
+
+	if f := typedValueToField(name, tv); f != nil {
+		r.set(content, f)
+	}
+
+	return nil
+}
+
+// setJSON flattens a decoded JSON value into leaves
+func (r *row) setJSON(segments []pathSegment, v interface{}) {
+
+	switch node := v.(type) {
+
+	case map[string]interface{}:
+		for k, child := range node {
+			r.setJSON(appendSegment(segments, pathSegment{name: stripModulePrefix(k)}), child)
+		}
+
+	case []interface{}:
+		for i, entry := range node {
+			r.setJSON(indexedSegments(segments, i), entry)
+		}
+
+	default:
+		if f := jsonScalarToField(segments[len(segments)-1].name, node); f != nil {
+			r.set(segments, f)
+		}
+	}
+}
+
+func (r *row) set(segments []pathSegment, f *telemetry.TelemetryField) {
+	r.leaves[segmentsID(segments)] = &leaf{segments: segments, value: f}
+}
+
+// deleteLeaves removes all leaves located under the content path
+func (r *row) deleteLeaves(content []pathSegment) {
+
+	prefix := segmentsID(content)
+
+	for id := range r.leaves {
+		if prefix == "" || id == prefix || strings.HasPrefix(id, prefix+"/") {
+			delete(r.leaves, id)
+		}
+	}
+}
+
+// telemetryField builds the kvGPB keys/content representation of the list entry
+func (r *row) telemetryField() *telemetry.TelemetryField {
+
+	keysField := &telemetry.TelemetryField{Name: "keys"}
+
+	for _, k := range r.keys {
+		if k.name != jsonArrayIndexKey {
+			keysField.Fields = append(keysField.Fields, keyToField(k))
+		}
+	}
+
+	contentField := &telemetry.TelemetryField{Name: "content"}
+
+	ids := make([]string, 0, len(r.leaves))
+
+	for id := range r.leaves {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	containers := make(map[string]*telemetry.TelemetryField)
+
+	for _, id := range ids {
+
+		l := r.leaves[id]
+		parent := contentField
+
+		for i := range l.segments[:len(l.segments)-1] {
+
+			containerID := segmentsID(l.segments[:i+1])
+
+			container, ok := containers[containerID]
+
+			if !ok {
+				container = &telemetry.TelemetryField{Name: l.segments[i].name}
+
+				for _, k := range l.segments[i].keys {
+					if k.name != jsonArrayIndexKey {
+						container.Fields = append(container.Fields, keyToField(k))
+					}
+				}
+
+				containers[containerID] = container
+				parent.Fields = append(parent.Fields, container)
+			}
+			parent = container
+		}
+
+		parent.Fields = append(parent.Fields, l.value)
+	}
+
+	return &telemetry.TelemetryField{
+		Timestamp: r.timestamp,
+		Fields:    []*telemetry.TelemetryField{keysField, contentField},
+	}
+}
+
+// encodingPath returns the subscription path without list keys, as streamed by MDT dial-out
+func (sub *Subscription) encodingPath() string {
+
+	names := make([]string, 0, len(sub.elems))
+
+	for _, e := range sub.elems {
+		names = append(names, e.GetName())
+	}
+
+	return strings.Join(names, "/")
+}
+
+func fullPath(prefix *gnmi.Path, p *gnmi.Path) []*gnmi.PathElem {
+
+	full := make([]*gnmi.PathElem, 0, len(prefix.GetElem())+len(p.GetElem()))
+	full = append(full, prefix.GetElem()...)
+
+	return append(full, p.GetElem()...)
+}
+
+func collectKeys(elems []*gnmi.PathElem) []keyValue {
+
+	var keys []keyValue
+
+	for _, e := range elems {
+		keys = append(keys, mapToKeys(e.GetKey())...)
+	}
+
+	return sortKeys(keys)
+}
+
+func toSegments(elems []*gnmi.PathElem) []pathSegment {
+
+	segments := make([]pathSegment, 0, len(elems))
+
+	for _, e := range elems {
+		segments = append(segments, pathSegment{name: stripModulePrefix(e.GetName()), keys: mapToKeys(e.GetKey())})
+	}
+
+	return segments
+}
+
+func mapToKeys(m map[string]string) []keyValue {
+
+	keys := make([]keyValue, 0, len(m))
+
+	for k, v := range m {
+		keys = append(keys, keyValue{name: k, value: v})
+	}
+
+	return sortKeys(keys)
+}
+
+func sortKeys(keys []keyValue) []keyValue {
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].name < keys[j].name })
+
+	return keys
+}
+
+func containsKeys(keys []keyValue, subset []keyValue) bool {
+
+	for _, s := range subset {
+
+		found := false
+
+		for _, k := range keys {
+			if k == s {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func rowID(keys []keyValue) string {
+	return segmentsID([]pathSegment{{keys: keys}})
+}
+
+func segmentsID(segments []pathSegment) string {
+
+	var b strings.Builder
+
+	for i, s := range segments {
+
+		if i > 0 {
+			b.WriteString("/")
+		}
+		b.WriteString(s.name)
+
+		for _, k := range s.keys {
+			_, _ = fmt.Fprintf(&b, "[%s=%s]", k.name, k.value)
+		}
+	}
+
+	return b.String()
+}
+
+func appendSegment(segments []pathSegment, s pathSegment) []pathSegment {
+
+	out := make([]pathSegment, 0, len(segments)+1)
+	out = append(out, segments...)
+
+	return append(out, s)
+}
+
+// indexedSegments identifies a JSON array or leaf-list entry by its index
+func indexedSegments(segments []pathSegment, idx int) []pathSegment {
+
+	out := make([]pathSegment, len(segments))
+	copy(out, segments)
+
+	last := out[len(out)-1]
+	last.keys = append(last.keys[:len(last.keys):len(last.keys)], keyValue{name: jsonArrayIndexKey, value: strconv.Itoa(idx)})
+	out[len(out)-1] = last
+
+	return out
+}
+
+// stripModulePrefix removes the YANG module prefix from a node name.
+// i.e. Cisco-IOS-XE-interfaces-oper:interfaces becomes interfaces
+func stripModulePrefix(name string) string {
+
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+
+	return name
+}
+
+// typedValueJSON decodes JSON encoded gNMI values. It returns nil for non JSON values
+func typedValueJSON(tv *gnmi.TypedValue) (interface{}, error) {
+
+	var raw []byte
+
+	switch tv.GetValue().(type) {
+	case *gnmi.TypedValue_JsonIetfVal:
+		raw = tv.GetJsonIetfVal()
+	case *gnmi.TypedValue_JsonVal:
+		raw = tv.GetJsonVal()
+	default:
+		return nil, nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+
+	var v interface{}
+
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON value: %v", err)
+	}
+
+	return v, nil
+}
+
+// typedValueToField converts a gNMI scalar TypedValue into a kvGPB field
+func typedValueToField(name string, tv *gnmi.TypedValue) *telemetry.TelemetryField {
+
+	f := &telemetry.TelemetryField{Name: name}
+
+	switch v := tv.GetValue().(type) {
+	case *gnmi.TypedValue_StringVal:
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: v.StringVal}
+	case *gnmi.TypedValue_AsciiVal:
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: v.AsciiVal}
+	case *gnmi.TypedValue_IntVal:
+		f.ValueByType = &telemetry.TelemetryField_Sint64Value{Sint64Value: v.IntVal}
+	case *gnmi.TypedValue_UintVal:
+		f.ValueByType = &telemetry.TelemetryField_Uint64Value{Uint64Value: v.UintVal}
+	case *gnmi.TypedValue_BoolVal:
+		f.ValueByType = &telemetry.TelemetryField_BoolValue{BoolValue: v.BoolVal}
+	case *gnmi.TypedValue_BytesVal:
+		f.ValueByType = &telemetry.TelemetryField_BytesValue{BytesValue: v.BytesVal}
+	case *gnmi.TypedValue_FloatVal:
+		f.ValueByType = &telemetry.TelemetryField_FloatValue{FloatValue: v.FloatVal}
+	case *gnmi.TypedValue_DecimalVal:
+		d := float64(v.DecimalVal.GetDigits()) / math.Pow10(int(v.DecimalVal.GetPrecision()))
+		f.ValueByType = &telemetry.TelemetryField_DoubleValue{DoubleValue: d}
+	default:
+		return nil
+	}
+
+	return f
+}
+
+// jsonNumericString matches the integers and decimals encoded as JSON strings. Special values such as NaN or Inf
+// are not numbers in YANG and are kept as strings.
+var jsonNumericString = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// jsonScalarToField converts a decoded JSON scalar into a kvGPB field
+func jsonScalarToField(name string, v interface{}) *telemetry.TelemetryField {
+
+	f := &telemetry.TelemetryField{Name: name}
+
+	switch val := v.(type) {
+	case string:
+		// RFC 7951 encodes the 64 bits integers and decimal64 leaves as JSON strings
+		if jsonNumericString.MatchString(val) {
+			return jsonScalarToField(name, json.Number(val))
+		}
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: val}
+	case bool:
+		f.ValueByType = &telemetry.TelemetryField_BoolValue{BoolValue: val}
+	case json.Number:
+		if u, err := strconv.ParseUint(val.String(), 10, 64); err == nil {
+			f.ValueByType = &telemetry.TelemetryField_Uint64Value{Uint64Value: u}
+		} else if i, err := strconv.ParseInt(val.String(), 10, 64); err == nil {
+			f.ValueByType = &telemetry.TelemetryField_Sint64Value{Sint64Value: i}
+		} else if d, err := val.Float64(); err == nil {
+			f.ValueByType = &telemetry.TelemetryField_DoubleValue{DoubleValue: d}
+		} else {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return f
+}
+
+func jsonScalarString(v interface{}) string {
+
+	switch val := v.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// keyToField converts a YANG list key into a kvGPB field. gNMI keys are strings,
+// numeric keys are converted back to integers as parsers expect them with kvGPB
+func keyToField(k keyValue) *telemetry.TelemetryField {
+
+	f := &telemetry.TelemetryField{Name: k.name}
+
+	if u, err := strconv.ParseUint(k.value, 10, 64); err == nil {
+		f.ValueByType = &telemetry.TelemetryField_Uint64Value{Uint64Value: u}
+	} else {
+		f.ValueByType = &telemetry.TelemetryField_StringValue{StringValue: k.value}
+	}
+
+	return f
+}
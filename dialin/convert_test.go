@@ -0,0 +1,353 @@
+package dialin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// flatten renders the kvGPB fields as path=value strings, string values are quoted
+func flatten(prefix string, fields []*telemetry.TelemetryField) []string {
+
+	var out []string
+
+	for _, f := range fields {
+
+		path := f.GetName()
+
+		if prefix != "" {
+			path = prefix + "/" + path
+		}
+
+		if len(f.GetFields()) > 0 {
+			out = append(out, flatten(path, f.GetFields())...)
+			continue
+		}
+
+		// Empty containers
+		if f.GetValueByType() == nil {
+			continue
+		}
+
+		var value interface{}
+
+		switch v := f.GetValueByType().(type) {
+		case *telemetry.TelemetryField_StringValue:
+			value = fmt.Sprintf("%q", v.StringValue)
+		case *telemetry.TelemetryField_BoolValue:
+			value = v.BoolValue
+		case *telemetry.TelemetryField_Uint64Value:
+			value = v.Uint64Value
+		case *telemetry.TelemetryField_Sint64Value:
+			value = v.Sint64Value
+		case *telemetry.TelemetryField_DoubleValue:
+			value = v.DoubleValue
+		}
+
+		out = append(out, fmt.Sprintf("%v=%v", path, value))
+	}
+
+	return out
+}
+
+// flattenRows renders each kvGPB row of a Telemetry message
+func flattenRows(msg *telemetry.Telemetry) [][]string {
+
+	var rows [][]string
+
+	for _, r := range msg.GetDataGpbkv() {
+		rows = append(rows, flatten("", r.GetFields()))
+	}
+
+	return rows
+}
+
+// testSubscription returns a validated subscription to the given path
+func testSubscription(t *testing.T, path string, mode string) *Subscription {
+
+	target := Target{
+		Address:       "192.0.2.1",
+		Subscriptions: []Subscription{{Path: path, Mode: mode, SampleInterval: "1s", Keys: []string{"name"}}},
+	}
+
+	if err := target.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	return &target.Subscriptions[0]
+}
+
+func gnmiPath(t *testing.T, p string) *gnmi.Path {
+
+	elems, err := parsePath(p)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &gnmi.Path{Elem: elems}
+}
+
+func jsonVal(v string) *gnmi.TypedValue {
+	return &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: []byte(v)}}
+}
+
+func uintVal(v uint64) *gnmi.TypedValue {
+	return &gnmi.TypedValue{Value: &gnmi.TypedValue_UintVal{UintVal: v}}
+}
+
+func TestSnapshotUpdates(t *testing.T) {
+
+	// change represents a gNMI update or delete applied to the snapshot
+	type change struct {
+		prefix string
+		path   string
+		val    *gnmi.TypedValue
+		delete bool
+	}
+
+	q0 := change{path: "queues/queue[name=q0]", val: jsonVal(`{"depth":1,"state":"up"}`)}
+	q1 := change{path: "queues/queue[name=q1]", val: jsonVal(`{"depth":2,"state":"down"}`)}
+
+	q0Row := []string{`keys/name="q0"`, "content/depth=1", `content/state="up"`}
+	q1Row := []string{`keys/name="q1"`, "content/depth=2", `content/state="down"`}
+
+	tests := []struct {
+		name     string
+		subPath  string
+		changes  []change
+		wantErr  error
+		wantRows [][]string
+	}{
+		{
+			name: "leaf update under a prefix",
+			changes: []change{
+				{prefix: "Cisco-IOS-XE-test-oper:queues", path: "queue[name=q0]/stats/drops", val: uintVal(3)},
+			},
+			wantRows: [][]string{{`keys/name="q0"`, "content/stats/drops=3"}},
+		},
+		{
+			name: "JSON update of a list entry",
+			changes: []change{
+				{path: "queues/queue[name=q0]", val: jsonVal(
+					`{"depth":"12","Cisco-IOS-XE-test-oper:ratio":"0.5","rate":"NaN","state":"up"}`)},
+			},
+			wantRows: [][]string{{`keys/name="q0"`, "content/depth=12", `content/rate="NaN"`, "content/ratio=0.5",
+				`content/state="up"`}},
+		},
+		{
+			name: "JSON update above the subscription path",
+			changes: []change{
+				{path: "queues", val: jsonVal(`{"queue":[{"name":"q0","depth":1},{"name":"q1","depth":2}]}`)},
+			},
+			wantRows: [][]string{
+				{`keys/name="q0"`, "content/depth=1", `content/name="q0"`},
+				{`keys/name="q1"`, "content/depth=2", `content/name="q1"`},
+			},
+		},
+		{
+			name: "leaf-list update",
+			changes: []change{
+				{path: "queues/queue[name=q0]/members", val: &gnmi.TypedValue{
+					Value: &gnmi.TypedValue_LeaflistVal{LeaflistVal: &gnmi.ScalarArray{Element: []*gnmi.TypedValue{
+						{Value: &gnmi.TypedValue_StringVal{StringVal: "a"}},
+						{Value: &gnmi.TypedValue_StringVal{StringVal: "b"}},
+					}}},
+				}},
+			},
+			wantRows: [][]string{{`keys/name="q0"`, `content/members="a"`, `content/members="b"`}},
+		},
+		{
+			name:     "JSON update replacing a list entry",
+			changes:  []change{q0, {path: "queues/queue[name=q0]", val: jsonVal(`{"depth":5}`)}},
+			wantRows: [][]string{{`keys/name="q0"`, "content/depth=5"}},
+		},
+		{
+			name:    "update of another subscription",
+			changes: []change{{path: "interfaces/interface[name=Gi1]/mtu", val: uintVal(1500)}},
+			wantErr: errPathMismatch,
+		},
+		{
+			name:     "update filtered by the subscription keys",
+			subPath:  "Cisco-IOS-XE-test-oper:queues/queue[name=q0]",
+			changes:  []change{q0, q1},
+			wantErr:  errPathMismatch,
+			wantRows: [][]string{q0Row},
+		},
+		{
+			name:    "scalar update of a list entry",
+			changes: []change{{path: "queues/queue[name=q0]", val: uintVal(1)}},
+			wantErr: fmt.Errorf("unable to set scalar value on list entry"),
+			// The list entry is created before the value is rejected
+			wantRows: [][]string{{`keys/name="q0"`}},
+		},
+		{
+			name:     "leaf delete",
+			changes:  []change{q0, q1, {path: "queues/queue[name=q0]/state", delete: true}},
+			wantRows: [][]string{{`keys/name="q0"`, "content/depth=1"}, q1Row},
+		},
+		{
+			name:     "list entry delete",
+			changes:  []change{q0, q1, {path: "queues/queue[name=q1]", delete: true}},
+			wantRows: [][]string{q0Row},
+		},
+		{
+			name:    "delete above the subscription path",
+			changes: []change{q0, q1, {path: "Cisco-IOS-XE-test-oper:queues", delete: true}},
+		},
+		{
+			name:     "delete of another subscription",
+			changes:  []change{q0, {path: "interfaces/interface[name=Gi1]", delete: true}},
+			wantErr:  errPathMismatch,
+			wantRows: [][]string{q0Row},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			subPath := tt.subPath
+
+			if subPath == "" {
+				subPath = testPath
+			}
+
+			s := newSnapshot(testSubscription(t, subPath, "ON_CHANGE"))
+
+			var err error
+
+			for i, c := range tt.changes {
+
+				if i > 0 && err != nil {
+					t.Fatalf("change %v error = %v", i-1, err)
+				}
+
+				var prefix *gnmi.Path
+
+				if c.prefix != "" {
+					prefix = gnmiPath(t, c.prefix)
+				}
+
+				if c.delete {
+					err = s.applyDelete(prefix, gnmiPath(t, c.path))
+				} else {
+					err = s.applyUpdate(prefix, &gnmi.Update{Path: gnmiPath(t, c.path), Val: c.val}, int64(time.Second))
+				}
+			}
+
+			if fmt.Sprint(err) != fmt.Sprint(tt.wantErr) {
+				t.Errorf("last change error = %v, want %v", err, tt.wantErr)
+			}
+
+			got := flattenRows(s.telemetryMsg("r1", "gnmi-0", 1))
+
+			if len(got) != len(tt.wantRows) || (len(got) > 0 && !reflect.DeepEqual(got, tt.wantRows)) {
+				t.Errorf("rows = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestSnapshotTelemetryMsg(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		mode      string
+		wantNodes []string
+	}{
+		{name: "sample removes stale entries", mode: "SAMPLE", wantNodes: []string{`keys/name="q0"`}},
+		{name: "on change keeps entries", mode: "ON_CHANGE", wantNodes: []string{`keys/name="q0"`, `keys/name="q1"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := newSnapshot(testSubscription(t, testPath, tt.mode))
+
+			for i, name := range []string{"q0", "q1"} {
+				u := &gnmi.Update{Path: gnmiPath(t, "queues/queue[name="+name+"]/depth"), Val: uintVal(1)}
+
+				if err := s.applyUpdate(nil, u, int64(i+1)*int64(time.Second)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// q1 not sampled for 4 intervals of 1s
+			s.rows[rowID([]keyValue{{name: "name", value: "q1"}})].lastSeen = time.Now().Add(-4 * time.Second)
+
+			msg := s.telemetryMsg("r1", "gnmi-0", 7)
+
+			if msg.GetNodeIdStr() != "r1" || msg.GetSubscriptionIdStr() != "gnmi-0" || msg.GetCollectionId() != 7 {
+				t.Errorf("telemetryMsg() node %v subscription %v collection %v, want r1 gnmi-0 7",
+					msg.GetNodeIdStr(), msg.GetSubscriptionIdStr(), msg.GetCollectionId())
+			}
+
+			if msg.GetEncodingPath() != testPath {
+				t.Errorf("telemetryMsg() encoding path = %v, want %v", msg.GetEncodingPath(), testPath)
+			}
+
+			var gotNodes []string
+
+			for _, r := range flattenRows(msg) {
+				gotNodes = append(gotNodes, r[0])
+			}
+
+			if !reflect.DeepEqual(gotNodes, tt.wantNodes) {
+				t.Errorf("telemetryMsg() rows = %v, want %v", gotNodes, tt.wantNodes)
+			}
+
+			// The message is timestamped with the latest row, converted to milliseconds
+			wantTimestamp := uint64(len(tt.wantNodes)) * 1000
+
+			if msg.GetMsgTimestamp() != wantTimestamp || msg.GetCollectionEndTime() != wantTimestamp {
+				t.Errorf("telemetryMsg() timestamp = %v, want %v", msg.GetMsgTimestamp(), wantTimestamp)
+			}
+		})
+	}
+}
+
+func TestJSONScalarToField(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "unsigned integer string", value: "12", want: "leaf=12"},
+		{name: "max uint64 string", value: "18446744073709551615", want: "leaf=18446744073709551615"},
+		{name: "negative integer string", value: "-3", want: "leaf=-3"},
+		{name: "decimal64 string", value: "0.25", want: "leaf=0.25"},
+		{name: "NaN string", value: "NaN", want: `leaf="NaN"`},
+		{name: "Inf string", value: "Inf", want: `leaf="Inf"`},
+		{name: "exponent string", value: "1e3", want: `leaf="1e3"`},
+		{name: "text", value: "up", want: `leaf="up"`},
+		{name: "number", value: json.Number("42"), want: "leaf=42"},
+		{name: "float number", value: json.Number("1.5e2"), want: "leaf=150"},
+		{name: "bool", value: true, want: "leaf=true"},
+		{name: "null", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			f := jsonScalarToField("leaf", tt.value)
+
+			if tt.want == "" {
+				if f != nil {
+					t.Errorf("jsonScalarToField() = %v, want nil", f)
+				}
+				return
+			}
+
+			if got := strings.Join(flatten("", []*telemetry.TelemetryField{f}), ""); got != tt.want {
+				t.Errorf("jsonScalarToField() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
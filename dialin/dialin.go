@@ -0,0 +1,328 @@
+// Package dialin handles the gNMI dial-in sessions where Peppamon subscribes to Telemetry data on the devices
+package dialin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/selfmetrics"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// Timeout to establish the gRPC connection with a device
+const dialTimeout = 10 * time.Second
+
+// Variables rather than constants so the tests can shorten them
+var (
+	// Delay to coalesce gNMI notifications into a single Telemetry message per subscription
+	notificationFlushDelay = 1 * time.Second
+
+	// Reconnection backoff boundaries
+	minReconnectBackoff = 1 * time.Second
+	maxReconnectBackoff = 1 * time.Minute
+)
+
+// Manager handles the gNMI Subscribe sessions towards the configured devices
+type Manager struct {
+	exp     *metrics.Collector
+	targets []Target
+}
+
+// NewManager returns a gNMI dial-in Manager feeding the given Peppamon Collector
+func NewManager(exp *metrics.Collector, targets []Target) *Manager {
+	return &Manager{
+		exp:     exp,
+		targets: targets,
+	}
+}
+
+// Start launches a gNMI session per target. Sessions are re-established until the context is cancelled
+func (m *Manager) Start(ctx context.Context) {
+
+	for _, t := range m.targets {
+		go m.runTarget(ctx, t)
+	}
+}
+
+// runTarget keeps the gNMI session of a target alive with exponential backoff between attempts
+func (m *Manager) runTarget(ctx context.Context, t Target) {
+
+	backoff := minReconnectBackoff
+
+	for {
+		sessionStart := time.Now()
+
+		err := m.subscribe(ctx, t)
+
+		// Removing Metrics from cache as session is no longer active
+		for _, sub := range t.Subscriptions {
+			m.exp.RemoveSource(metrics.Source{NodeID: t.Node, Path: sub.encodingPath()})
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Reset backoff if session was stable
+		if time.Since(sessionStart) > maxReconnectBackoff {
+			backoff = minReconnectBackoff
+		}
+
+		logging.PeppaMonLog(
+			"error",
+			"gNMI session with device %v (Node %v) ended: %v. Reconnecting in %v",
+			t.Address, t.Node, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// subscribe opens a gNMI Subscribe stream and records the notifications until the stream fails
+func (m *Manager) subscribe(ctx context.Context, t Target) error {
+
+	dialOpts, err := t.dialOptions()
+
+	if err != nil {
+		return err
+	}
+
+	ctxDial, cancelDial := context.WithTimeout(ctx, dialTimeout)
+	defer cancelDial()
+
+	conn, err := grpc.DialContext(ctxDial, t.Address, dialOpts...)
+
+	if err != nil {
+		return fmt.Errorf("unable to connect: %v", err)
+	}
+
+	defer conn.Close()
+
+	ctxStream, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+
+	if t.Username != "" {
+		ctxStream = metadata.AppendToOutgoingContext(ctxStream, "username", t.Username, "password", t.Password)
+	}
+
+	stream, err := gnmi.NewGNMIClient(conn).Subscribe(ctxStream)
+
+	if err != nil {
+		return fmt.Errorf("unable to open Subscribe stream: %v", err)
+	}
+
+	if err := stream.Send(t.subscribeRequest()); err != nil {
+		return fmt.Errorf("unable to send Subscribe request: %v", err)
+	}
+
+	logging.PeppaMonLog(
+		"info",
+		"gNMI Subscription established with device %v (Node %v) for %v path(s)",
+		t.Address, t.Node, len(t.Subscriptions))
+
+	selfmetrics.StreamOpened("gnmi")
+	defer selfmetrics.StreamClosed("gnmi")
+
+	snapshots := make([]*snapshot, len(t.Subscriptions))
+
+	for i := range t.Subscriptions {
+		snapshots[i] = newSnapshot(&t.Subscriptions[i])
+	}
+
+	// Read gNMI stream in separate goroutine so notifications can be coalesced
+	chResp := make(chan *gnmi.SubscribeResponse)
+	chErr := make(chan error, 1)
+
+	go func() {
+		for {
+			resp, err := stream.Recv()
+
+			if err != nil {
+				chErr <- err
+				return
+			}
+
+			select {
+			case chResp <- resp:
+			case <-ctxStream.Done():
+				return
+			}
+		}
+	}()
+
+	dirty := make(map[int]bool)
+	flush := time.NewTimer(notificationFlushDelay)
+	flush.Stop()
+
+	var collectionID uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err := <-chErr:
+			if err == io.EOF {
+				return fmt.Errorf("stream closed by device")
+			}
+			return err
+
+		case resp := <-chResp:
+
+			if resp.GetSyncResponse() {
+				continue
+			}
+
+			n := resp.GetUpdate()
+
+			if n == nil {
+				continue
+			}
+
+			if len(dirty) == 0 {
+				flush.Reset(notificationFlushDelay)
+			}
+
+			for _, u := range n.GetUpdate() {
+				for i, s := range snapshots {
+
+					errUpdate := s.applyUpdate(n.GetPrefix(), u, n.GetTimestamp())
+
+					if errUpdate == errPathMismatch {
+						continue
+					}
+
+					if errUpdate != nil {
+						logging.PeppaMonLog(
+							"error",
+							"Failed to decode gNMI update from device %v for path %v: %v",
+							t.Address, s.sub.Path, errUpdate)
+					}
+					dirty[i] = true
+					break
+				}
+			}
+
+			// A deleted path above the subscription paths may remove entries of several subscriptions
+			for _, d := range n.GetDelete() {
+				for i, s := range snapshots {
+					if s.applyDelete(n.GetPrefix(), d) == errPathMismatch {
+						continue
+					}
+					dirty[i] = true
+				}
+			}
+
+		case <-flush.C:
+
+			for i := range dirty {
+
+				collectionID++
+
+				msg := snapshots[i].telemetryMsg(t.Node, fmt.Sprintf("gnmi-%d", i), collectionID)
+
+				selfmetrics.MessageReceived(msg.GetNodeIdStr(), msg.GetEncodingPath())
+
+				if _, ok := m.exp.RecordTelemetryMsg(msg); !ok {
+					logging.PeppaMonLog(
+						"error",
+						"Received gNMI notification from device %v for unsupported YANG Node Path %v",
+						t.Address, msg.GetEncodingPath())
+				}
+			}
+
+			dirty = make(map[int]bool)
+		}
+	}
+}
+
+// subscribeRequest builds the gNMI STREAM subscription of the target
+func (t Target) subscribeRequest() *gnmi.SubscribeRequest {
+
+	subList := &gnmi.SubscriptionList{
+		Mode:     gnmi.SubscriptionList_STREAM,
+		Encoding: gnmi.Encoding(gnmi.Encoding_value[strings.ToUpper(t.Encoding)]),
+	}
+
+	for _, sub := range t.Subscriptions {
+
+		s := &gnmi.Subscription{
+			Path: &gnmi.Path{
+				Origin: sub.Origin,
+				Elem:   sub.elems,
+			},
+			Mode: sub.mode,
+		}
+
+		if sub.mode == gnmi.SubscriptionMode_SAMPLE {
+			s.SampleInterval = uint64(sub.sampleInterval.Nanoseconds())
+		}
+
+		subList.Subscription = append(subList.Subscription, s)
+	}
+
+	return &gnmi.SubscribeRequest{
+		Request: &gnmi.SubscribeRequest_Subscribe{Subscribe: subList},
+	}
+}
+
+// dialOptions returns the gRPC options to connect to the target
+func (t Target) dialOptions() ([]grpc.DialOption, error) {
+
+	opts := []grpc.DialOption{grpc.WithBlock()}
+
+	if !t.TLS {
+		return append(opts, grpc.WithInsecure()), nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CAFile != "" {
+
+		caPEM, err := ioutil.ReadFile(t.CAFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file: %v", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid PEM certificate found in CA file %v", t.CAFile)
+		}
+	}
+
+	if t.CertFile != "" && t.KeyFile != "" {
+
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))), nil
+}
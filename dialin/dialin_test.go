@@ -0,0 +1,289 @@
+package dialin
+
+import (
+	"context"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+)
+
+func TestMain(m *testing.M) {
+
+	notificationFlushDelay = 50 * time.Millisecond
+	minReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff = 400 * time.Millisecond
+
+	os.Exit(m.Run())
+}
+
+// fakeGNMIServer represents a device serving gNMI Subscribe sessions one at a time
+type fakeGNMIServer struct {
+	gnmi.UnimplementedGNMIServer
+
+	// Subscribe requests received at the start of each session
+	requests chan *gnmi.SubscribeRequest
+
+	// Notifications sent on the current session
+	notifications chan *gnmi.Notification
+
+	// Ends the current session
+	closeSession chan struct{}
+}
+
+// startFakeGNMIServer serves gNMI on a loopback address and returns its address
+func startFakeGNMIServer(t *testing.T, loopback string) (*fakeGNMIServer, string) {
+
+	lis, err := net.Listen("tcp", net.JoinHostPort(loopback, "0"))
+
+	if err != nil {
+		t.Skipf("loopback %v not available: %v", loopback, err)
+	}
+
+	fake := &fakeGNMIServer{
+		requests:      make(chan *gnmi.SubscribeRequest, 10),
+		notifications: make(chan *gnmi.Notification),
+		closeSession:  make(chan struct{}),
+	}
+
+	s := grpc.NewServer()
+	gnmi.RegisterGNMIServer(s, fake)
+
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	return fake, lis.Addr().String()
+}
+
+func (f *fakeGNMIServer) Subscribe(stream gnmi.GNMI_SubscribeServer) error {
+
+	req, err := stream.Recv()
+
+	if err != nil {
+		return err
+	}
+
+	f.requests <- req
+
+	for {
+		select {
+		case n := <-f.notifications:
+			resp := &gnmi.SubscribeResponse{Response: &gnmi.SubscribeResponse_Update{Update: n}}
+
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+
+			syncResp := &gnmi.SubscribeResponse{Response: &gnmi.SubscribeResponse_SyncResponse{SyncResponse: true}}
+
+			if err := stream.Send(syncResp); err != nil {
+				return err
+			}
+
+		case <-f.closeSession:
+			return nil
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (f *fakeGNMIServer) waitRequest(t *testing.T) *gnmi.SubscribeRequest {
+
+	select {
+	case req := <-f.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("no gNMI Subscribe request received")
+	}
+
+	return nil
+}
+
+// messageRecorder records the Telemetry messages the Manager feeds to the Collector
+type messageRecorder chan *telemetry.Telemetry
+
+func (r messageRecorder) PublishMessage(msg *telemetry.Telemetry) {
+	r <- msg
+}
+
+// waitRows returns the first recorded message holding the wanted rows
+func (r messageRecorder) waitRows(t *testing.T, want [][]string) *telemetry.Telemetry {
+
+	timeout := time.After(5 * time.Second)
+
+	var got [][]string
+
+	for {
+		select {
+		case msg := <-r:
+			got = flattenRows(msg)
+
+			if reflect.DeepEqual(got, want) {
+				return msg
+			}
+
+		case <-timeout:
+			t.Fatalf("rows = %v, want %v", got, want)
+			return nil
+		}
+	}
+}
+
+// startManager subscribes to the fake device with the given subscription
+func startManager(t *testing.T, address string, sub Subscription) messageRecorder {
+
+	target := Target{Address: address, Subscriptions: []Subscription{sub}}
+
+	if err := target.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := make(messageRecorder, 100)
+
+	exp := metrics.NewCollector()
+	exp.AddMessageSink(rec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	NewManager(exp, []Target{target}).Start(ctx)
+
+	return rec
+}
+
+func queueNotification(t *testing.T, name string, val string) *gnmi.Notification {
+
+	return &gnmi.Notification{
+		Timestamp: int64(2 * time.Second),
+		Prefix:    gnmiPath(t, "Cisco-IOS-XE-test-oper:queues"),
+		Update:    []*gnmi.Update{{Path: gnmiPath(t, "queue[name="+name+"]"), Val: jsonVal(val)}},
+	}
+}
+
+func TestManagerSubscribe(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		loopback     string
+		sub          Subscription
+		wantMode     gnmi.SubscriptionMode
+		wantInterval uint64
+		wantNode     string
+	}{
+		{
+			name:         "sample over IPv4",
+			loopback:     "127.0.0.1",
+			sub:          Subscription{Path: testPath, SampleInterval: "10s"},
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: uint64(10 * time.Second),
+			wantNode:     "127.0.0.1",
+		},
+		{
+			name:     "on change over IPv4",
+			loopback: "127.0.0.1",
+			sub:      Subscription{Path: testPath, Mode: "on_change"},
+			wantMode: gnmi.SubscriptionMode_ON_CHANGE,
+			wantNode: "127.0.0.1",
+		},
+		{
+			name:         "sample over IPv6",
+			loopback:     "::1",
+			sub:          Subscription{Path: testPath},
+			wantMode:     gnmi.SubscriptionMode_SAMPLE,
+			wantInterval: uint64(30 * time.Second),
+			wantNode:     "::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			fake, address := startFakeGNMIServer(t, tt.loopback)
+			rec := startManager(t, address, tt.sub)
+
+			list := fake.waitRequest(t).GetSubscribe()
+
+			if list.GetMode() != gnmi.SubscriptionList_STREAM || list.GetEncoding() != gnmi.Encoding_JSON_IETF {
+				t.Errorf("subscription list mode %v encoding %v, want STREAM JSON_IETF", list.GetMode(), list.GetEncoding())
+			}
+
+			if len(list.GetSubscription()) != 1 {
+				t.Fatalf("subscriptions = %v, want 1", list.GetSubscription())
+			}
+
+			s := list.GetSubscription()[0]
+
+			if s.GetMode() != tt.wantMode || s.GetSampleInterval() != tt.wantInterval {
+				t.Errorf("subscription mode %v interval %v, want %v %v",
+					s.GetMode(), s.GetSampleInterval(), tt.wantMode, tt.wantInterval)
+			}
+
+			if got := (&Subscription{elems: s.GetPath().GetElem()}).encodingPath(); got != testPath {
+				t.Errorf("subscription path = %v, want %v", got, testPath)
+			}
+
+			// Notifications are coalesced into a message holding every list entry
+			fake.notifications <- queueNotification(t, "q0", `{"depth":"12"}`)
+			fake.notifications <- queueNotification(t, "q1", `{"depth":"3"}`)
+
+			msg := rec.waitRows(t, [][]string{
+				{`keys/name="q0"`, "content/depth=12"},
+				{`keys/name="q1"`, "content/depth=3"},
+			})
+
+			if msg.GetNodeIdStr() != tt.wantNode || msg.GetEncodingPath() != testPath || msg.GetMsgTimestamp() != 2000 {
+				t.Errorf("message node %v path %v timestamp %v, want %v %v 2000",
+					msg.GetNodeIdStr(), msg.GetEncodingPath(), msg.GetMsgTimestamp(), tt.wantNode, testPath)
+			}
+
+			// Deleted list entries are removed from the next message
+			fake.notifications <- &gnmi.Notification{
+				Prefix: gnmiPath(t, "Cisco-IOS-XE-test-oper:queues"),
+				Delete: []*gnmi.Path{gnmiPath(t, "queue[name=q1]")},
+			}
+
+			rec.waitRows(t, [][]string{{`keys/name="q0"`, "content/depth=12"}})
+		})
+	}
+}
+
+func TestManagerReconnects(t *testing.T) {
+
+	fake, address := startFakeGNMIServer(t, "127.0.0.1")
+	rec := startManager(t, address, Subscription{Path: testPath, Mode: "ON_CHANGE"})
+
+	fake.waitRequest(t)
+	fake.notifications <- queueNotification(t, "q0", `{"depth":1}`)
+	rec.waitRows(t, [][]string{{`keys/name="q0"`, "content/depth=1"}})
+
+	// The backoff doubles after each session closed by the device, up to the maximum
+	wantBackoffs := []time.Duration{
+		minReconnectBackoff,
+		2 * minReconnectBackoff,
+		maxReconnectBackoff,
+		maxReconnectBackoff,
+	}
+
+	for i, want := range wantBackoffs {
+
+		closed := time.Now()
+		fake.closeSession <- struct{}{}
+		fake.waitRequest(t)
+
+		if got := time.Since(closed); got < want {
+			t.Errorf("reconnection %v after %v, want at least %v", i+1, got, want)
+		}
+	}
+
+	// The new session starts from an empty snapshot
+	fake.notifications <- queueNotification(t, "q1", `{"depth":2}`)
+	rec.waitRows(t, [][]string{{`keys/name="q1"`, "content/depth=2"}})
+}
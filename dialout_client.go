@@ -0,0 +1,76 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// dialoutClientFlags represents the options of the commands streaming to a collector over MDT dial-out
+type dialoutClientFlags struct {
+	target        *string
+	useTLS        *bool
+	tlsCA         *string
+	tlsCert       *string
+	tlsKey        *string
+	tlsServerName *string
+}
+
+// registerDialoutClientFlags declares the collector address and TLS options in the command flag set
+func registerDialoutClientFlags(fs *flag.FlagSet, defaultTarget string, targetUsage string) *dialoutClientFlags {
+
+	return &dialoutClientFlags{
+		target:        fs.String("target", defaultTarget, targetUsage),
+		useTLS:        fs.Bool("tls", false, "Enable TLS towards the collector"),
+		tlsCA:         fs.String("tls-ca", "", "CA certificate file verifying the collector"),
+		tlsCert:       fs.String("tls-cert", "", "Client certificate file for mutual TLS"),
+		tlsKey:        fs.String("tls-key", "", "Client private key file for mutual TLS"),
+		tlsServerName: fs.String("tls-server-name", "", "Server name verified in the collector certificate"),
+	}
+}
+
+// dial creates the gRPC connection to the collector. It is established in background
+func (f *dialoutClientFlags) dial() (*grpc.ClientConn, error) {
+
+	creds := grpc.WithInsecure()
+
+	if *f.useTLS {
+
+		tlsConfig := &tls.Config{ServerName: *f.tlsServerName}
+
+		if *f.tlsCA != "" {
+
+			caPEM, err := ioutil.ReadFile(*f.tlsCA)
+
+			if err != nil {
+				return nil, err
+			}
+
+			pool := x509.NewCertPool()
+
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return nil, fmt.Errorf("no CA certificate found in %v", *f.tlsCA)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if *f.tlsCert != "" || *f.tlsKey != "" {
+
+			cert, err := tls.LoadX509KeyPair(*f.tlsCert, *f.tlsKey)
+
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	return grpc.Dial(*f.target, creds)
+}
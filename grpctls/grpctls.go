@@ -0,0 +1,298 @@
+// Package grpctls handles the TLS / mutual TLS settings of the MDT dial-out gRPC listener
+package grpctls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Settings represents the TLS options of the gRPC Telemetry listener
+type Settings struct {
+	// Server certificate and private key in PEM format
+	CertFile string
+	KeyFile  string
+
+	// Client CA bundle. When set, routers must present a certificate signed by one of these CAs (mTLS)
+	ClientCAFile string
+
+	// List of accepted client certificate Common Names / SANs. Empty list accepts any verified certificate
+	AllowedNames []string
+
+	// Enforce the Telemetry node_id_str to match the client certificate Common Name or one of its SANs
+	VerifyNodeID bool
+
+	// Interval at which certificate files are checked for rotation
+	ReloadInterval time.Duration
+}
+
+// Enabled returns whether TLS has been configured on the gRPC listener
+func (s Settings) Enabled() bool {
+	return s.CertFile != "" && s.KeyFile != ""
+}
+
+// MutualTLS returns whether client certificates are required on the gRPC listener
+func (s Settings) MutualTLS() bool {
+	return s.Enabled() && s.ClientCAFile != ""
+}
+
+// SettingsFromConfig builds the TLS settings from the grpc.tls configuration section
+func SettingsFromConfig(c config.GRPCTLS) Settings {
+
+	return Settings{
+		CertFile:       c.CertFile,
+		KeyFile:        c.KeyFile,
+		ClientCAFile:   c.ClientCAFile,
+		AllowedNames:   c.AllowedNames,
+		VerifyNodeID:   c.VerifyNodeID,
+		ReloadInterval: c.ReloadInterval,
+	}
+}
+
+// CertReloader keeps the server certificate and client CA pool in memory and reloads them
+// whenever the files are modified on disk, so certificates can be rotated without restarting the collector
+type CertReloader struct {
+	settings Settings
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+// NewCertReloader loads the certificate files for the first time and returns a CertReloader instance
+func NewCertReloader(s Settings) (*CertReloader, error) {
+
+	if !s.Enabled() {
+		return nil, errors.New("TLS certificate and key files must both be set")
+	}
+
+	r := &CertReloader{
+		settings: s,
+		modTimes: make(map[string]time.Time),
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Watch will periodically check whether certificate files were modified until the context is cancelled
+func (r *CertReloader) Watch(ctx context.Context) {
+
+	ticker := time.NewTicker(r.settings.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.filesChanged() {
+				continue
+			}
+
+			if err := r.reload(); err != nil {
+				logging.PeppaMonLog("error",
+					"Failed to reload gRPC TLS certificates. Keeping previous ones: %v", err)
+				continue
+			}
+
+			logging.PeppaMonLog("info", "gRPC TLS certificates successfully reloaded")
+		}
+	}
+}
+
+// TLSConfig returns the server tls.Config. The certificate and client CA pool are looked up
+// on each handshake so that reloaded files are used for new connections
+func (r *CertReloader) TLSConfig() *tls.Config {
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+
+			if r.clientCAs != nil {
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+				cfg.ClientCAs = r.clientCAs
+				cfg.VerifyPeerCertificate = r.verifyAllowedNames
+			}
+
+			return cfg, nil
+		},
+	}
+}
+
+// verifyAllowedNames rejects client certificates whose Common Name or SANs are not part of the allowed names
+func (r *CertReloader) verifyAllowedNames(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+
+	if len(r.settings.AllowedNames) == 0 {
+		return nil
+	}
+
+	for _, chain := range verifiedChains {
+
+		if len(chain) == 0 {
+			continue
+		}
+
+		for _, id := range certIdentities(chain[0]) {
+			for _, allowed := range r.settings.AllowedNames {
+				if strings.EqualFold(id, allowed) {
+					return nil
+				}
+			}
+		}
+	}
+
+	return errors.New("client certificate name is not part of the allowed names")
+}
+
+func (r *CertReloader) filesChanged() bool {
+
+	for _, f := range r.files() {
+
+		fi, err := os.Stat(f)
+
+		if err != nil {
+			logging.PeppaMonLog("error", "Failed to check gRPC TLS file %v: %v", f, err)
+			continue
+		}
+
+		r.mu.RLock()
+		previous := r.modTimes[f]
+		r.mu.RUnlock()
+
+		if !fi.ModTime().Equal(previous) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *CertReloader) files() []string {
+
+	f := []string{r.settings.CertFile, r.settings.KeyFile}
+
+	if r.settings.ClientCAFile != "" {
+		f = append(f, r.settings.ClientCAFile)
+	}
+
+	return f
+}
+
+func (r *CertReloader) reload() error {
+
+	modTimes := make(map[string]time.Time)
+
+	for _, f := range r.files() {
+		fi, err := os.Stat(f)
+
+		if err != nil {
+			return err
+		}
+		modTimes[f] = fi.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.settings.CertFile, r.settings.KeyFile)
+
+	if err != nil {
+		return fmt.Errorf("unable to load server certificate: %v", err)
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.settings.ClientCAFile != "" {
+
+		caPEM, err := ioutil.ReadFile(r.settings.ClientCAFile)
+
+		if err != nil {
+			return fmt.Errorf("unable to read client CA file: %v", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("no valid PEM certificate found in client CA file %v", r.settings.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+
+	return nil
+}
+
+// PeerIdentities returns the Common Name and SANs of the client certificate attached to the gRPC stream context.
+// An empty slice is returned if the stream is not authenticated with a client certificate.
+func PeerIdentities(ctx context.Context) []string {
+
+	p, ok := peer.FromContext(ctx)
+
+	if !ok || p.AuthInfo == nil {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return certIdentities(tlsInfo.State.PeerCertificates[0])
+}
+
+// NodeIDMatchesIdentities verifies the Telemetry node_id_str against the client certificate identities
+func NodeIDMatchesIdentities(node string, identities []string) bool {
+
+	for _, id := range identities {
+		if strings.EqualFold(node, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// certIdentities returns the Common Name, DNS and IP SANs of a certificate
+func certIdentities(c *x509.Certificate) []string {
+
+	ids := make([]string, 0, 1+len(c.DNSNames)+len(c.IPAddresses))
+
+	if c.Subject.CommonName != "" {
+		ids = append(ids, c.Subject.CommonName)
+	}
+
+	ids = append(ids, c.DNSNames...)
+
+	for _, ip := range c.IPAddresses {
+		ids = append(ids, ip.String())
+	}
+
+	return ids
+}
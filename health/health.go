@@ -0,0 +1,164 @@
+// Package health exposes the liveness, readiness and dependencies endpoints of the Peppamon collector
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// Timeout of each dependency check
+const checkTimeout = 3 * time.Second
+
+// Check verifies a dependency is available
+type Check func(ctx context.Context) error
+
+// Checker represents the dependency checks of a health endpoint
+type Checker struct {
+	mu     *sync.Mutex
+	checks map[string]Check
+
+	// Set to 1 once the shutdown started so traffic drains from the collector
+	shuttingDown int32
+}
+
+// Status represents the JSON payload of the health endpoints
+type Status struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckStatus `json:"checks,omitempty"`
+}
+
+// CheckStatus represents the result of a dependency check
+type CheckStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// NewChecker will create a new instance of dependency checks
+func NewChecker() *Checker {
+
+	return &Checker{
+		mu:     &sync.Mutex{},
+		checks: make(map[string]Check),
+	}
+}
+
+// AddCheck registers a dependency verified by the health endpoint of the checker
+func (c *Checker) AddCheck(name string, check Check) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = check
+}
+
+// ShutDown flips the checker status to failing
+func (c *Checker) ShutDown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+}
+
+// Ready runs the dependency checks concurrently and returns whether they all passed
+func (c *Checker) Ready(ctx context.Context) (bool, Status) {
+
+	c.mu.Lock()
+
+	names := make([]string, 0, len(c.checks))
+	checks := make([]Check, 0, len(c.checks))
+
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		checks = append(checks, c.checks[name])
+	}
+
+	c.mu.Unlock()
+
+	results := make([]CheckStatus, len(checks))
+	wg := sync.WaitGroup{}
+
+	for i, check := range checks {
+
+		wg.Add(1)
+
+		go func(i int, check Check) {
+			defer wg.Done()
+
+			ctxCheck, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			results[i] = CheckStatus{Status: statusOK}
+
+			if err := check(ctxCheck); err != nil {
+				results[i] = CheckStatus{Status: statusFail, Error: err.Error()}
+			}
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	ready := atomic.LoadInt32(&c.shuttingDown) == 0
+	status := Status{Status: statusOK, Checks: make(map[string]CheckStatus, len(checks)+1)}
+
+	if !ready {
+		status.Checks["shutdown"] = CheckStatus{Status: statusFail, Error: "collector is shutting down"}
+	}
+
+	for i, name := range names {
+		status.Checks[name] = results[i]
+
+		if results[i].Status != statusOK {
+			ready = false
+		}
+	}
+
+	if !ready {
+		status.Status = statusFail
+	}
+
+	return ready, status
+}
+
+// LivenessHandler reports the process is alive
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeStatus(w, http.StatusOK, Status{Status: statusOK})
+}
+
+// Handler reports whether the dependency checks passed
+func (c *Checker) Handler(w http.ResponseWriter, r *http.Request) {
+
+	ready, status := c.Ready(r.Context())
+
+	code := http.StatusOK
+
+	if !ready {
+		code = http.StatusServiceUnavailable
+	}
+
+	writeStatus(w, code, status)
+}
+
+func writeStatus(w http.ResponseWriter, code int, status Status) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to render health status %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+// Package influxdb writes the Telemetry metrics samples in InfluxDB line protocol to an InfluxDB HTTP endpoint
+// or to a file
+package influxdb
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+// Settings represents the InfluxDB line protocol sinks settings
+type Settings struct {
+	// InfluxDB write endpoint URL including the database or bucket parameters.
+	// i.e. http://influxdb:8086/write?db=telemetry or http://influxdb:8086/api/v2/write?org=noc&bucket=telemetry
+	URL string
+
+	// InfluxDB 2.x API token or InfluxDB 1.x credentials
+	Token    string
+	Username string
+	Password string
+
+	// HTTP request timeout
+	Timeout time.Duration
+
+	// File the line protocol is appended to
+	File string
+
+	// Batching of the samples
+	Batch batch.Settings
+}
+
+// HTTPEnabled returns whether the InfluxDB HTTP sink is configured
+func (s Settings) HTTPEnabled() bool {
+	return s.URL != ""
+}
+
+// FileEnabled returns whether the line protocol file sink is configured
+func (s Settings) FileEnabled() bool {
+	return s.File != ""
+}
+
+// SettingsFromConfig builds the InfluxDB sinks settings from the outputs.influxdb configuration section
+func SettingsFromConfig(c config.InfluxDB) Settings {
+
+	return Settings{
+		URL:      c.URL,
+		Token:    c.Token,
+		Username: c.Username,
+		Password: c.Password,
+		Timeout:  c.Timeout,
+		File:     c.File,
+		Batch:    batch.SettingsFromConfig(c.Batch),
+	}
+}
+
+// HTTPWriter batches the published samples and writes them to an InfluxDB HTTP endpoint.
+// It implements the metrics.Sink interface.
+type HTTPWriter struct {
+	*batch.Queue
+
+	settings Settings
+	client   *http.Client
+}
+
+// NewHTTPWriter returns an InfluxDB HTTP writer. Samples are sent once Run is started
+func NewHTTPWriter(s Settings) *HTTPWriter {
+
+	w := &HTTPWriter{
+		settings: s,
+		client:   &http.Client{Timeout: s.Timeout},
+	}
+
+	w.Queue = batch.NewQueue("influxdb", s.Batch, w.write)
+
+	return w
+}
+
+// write posts a batch of samples in line protocol
+func (w *HTTPWriter) write(ctx context.Context, samples []metrics.Sample) (bool, error) {
+
+	body := encodeLines(samples)
+
+	if len(body) == 0 {
+		return true, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.settings.URL, bytes.NewReader(body))
+
+	if err != nil {
+		return false, err
+	}
+
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("User-Agent", "peppamon")
+
+	if w.settings.Token != "" {
+		req.Header.Set("Authorization", "Token "+w.settings.Token)
+	} else if w.settings.Username != "" {
+		req.SetBasicAuth(w.settings.Username, w.settings.Password)
+	}
+
+	return batch.Post(w.client, req)
+}
+
+// FileWriter batches the published samples and appends them in line protocol to a file.
+// It implements the metrics.Sink interface.
+type FileWriter struct {
+	*batch.Queue
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileWriter opens the line protocol file in append mode. Samples are written once Run is started
+func NewFileWriter(s Settings) (*FileWriter, error) {
+
+	f, err := os.OpenFile(s.File, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	w := &FileWriter{file: f}
+
+	w.Queue = batch.NewQueue("influxdb_file", s.Batch, w.write)
+
+	return w, nil
+}
+
+// write appends a batch of samples in line protocol
+func (w *FileWriter) write(ctx context.Context, samples []metrics.Sample) (bool, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.file.Write(encodeLines(samples)); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Close closes the line protocol file
+func (w *FileWriter) Close() error {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file.Close()
+}
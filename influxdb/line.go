@@ -0,0 +1,62 @@
+package influxdb
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// encodeLines converts samples into InfluxDB line protocol. i.e.
+//
+//	cisco_iosxe_iosd_cpu_busy_percent,node=csr1000v-1,period=5_seconds value=12 1593628810000000000
+//
+// The metric name is used as measurement, labels as tags and the value is stored in the "value" field.
+// Samples with NaN or infinite values are skipped as line protocol cannot represent them.
+func encodeLines(samples []metrics.Sample) []byte {
+
+	var b []byte
+
+	for _, s := range samples {
+
+		if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+			continue
+		}
+
+		b = append(b, measurementEscaper.Replace(s.Name)...)
+
+		labels := make([]metrics.Label, len(s.Labels))
+		copy(labels, s.Labels)
+
+		// Sorted tags improve InfluxDB write performance
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+		for _, l := range labels {
+
+			// Empty tag values are not allowed in line protocol
+			if l.Value == "" {
+				continue
+			}
+
+			b = append(b, ',')
+			b = append(b, tagEscaper.Replace(l.Name)...)
+			b = append(b, '=')
+			b = append(b, tagEscaper.Replace(l.Value)...)
+		}
+
+		b = append(b, " value="...)
+		b = strconv.AppendFloat(b, s.Value, 'g', -1, 64)
+		b = append(b, ' ')
+		b = strconv.AppendInt(b, s.Timestamp.UnixNano(), 10)
+		b = append(b, '\n')
+	}
+
+	return b
+}
@@ -0,0 +1,105 @@
+package influxdb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+func TestEncodeLines(t *testing.T) {
+
+	ts := time.Unix(1593628810, 0)
+
+	tests := []struct {
+		name   string
+		sample metrics.Sample
+		want   string
+	}{
+		{
+			name: "tags sorted",
+			sample: metrics.Sample{
+				Name:   "cisco_iosxe_iosd_cpu_busy_percent",
+				Labels: []metrics.Label{{Name: "period", Value: "5_seconds"}, {Name: "node", Value: "csr1000v-1"}},
+				Value:  12,
+			},
+			want: "cisco_iosxe_iosd_cpu_busy_percent,node=csr1000v-1,period=5_seconds value=12 1593628810000000000\n",
+		},
+		{
+			name:   "measurement spaces and commas",
+			sample: metrics.Sample{Name: "cpu busy,percent", Value: 1},
+			want:   `cpu\ busy\,percent value=1 1593628810000000000` + "\n",
+		},
+		{
+			name:   "measurement equals signs and quotes",
+			sample: metrics.Sample{Name: `cpu="busy"`, Value: 1},
+			want:   `cpu="busy" value=1 1593628810000000000` + "\n",
+		},
+		{
+			name:   "tag key spaces, commas and equals signs",
+			sample: metrics.Sample{Name: "m", Labels: []metrics.Label{{Name: "if name,id=1", Value: "Gi1"}}, Value: 1},
+			want:   `m,if\ name\,id\=1=Gi1 value=1 1593628810000000000` + "\n",
+		},
+		{
+			name: "tag value spaces, commas and equals signs",
+			sample: metrics.Sample{
+				Name:   "m",
+				Labels: []metrics.Label{{Name: "description", Value: "uplink to core, vlan=10"}},
+				Value:  1,
+			},
+			want: `m,description=uplink\ to\ core\,\ vlan\=10 value=1 1593628810000000000` + "\n",
+		},
+		{
+			name:   "tag value quotes",
+			sample: metrics.Sample{Name: "m", Labels: []metrics.Label{{Name: "description", Value: `"uplink"`}}, Value: 1},
+			want:   `m,description="uplink" value=1 1593628810000000000` + "\n",
+		},
+		{
+			name:   "empty tag value",
+			sample: metrics.Sample{Name: "m", Labels: []metrics.Label{{Name: "vrf", Value: ""}, {Name: "node", Value: "r1"}}, Value: 1},
+			want:   "m,node=r1 value=1 1593628810000000000\n",
+		},
+		{
+			name:   "float value",
+			sample: metrics.Sample{Name: "m", Value: 0.25},
+			want:   "m value=0.25 1593628810000000000\n",
+		},
+		{
+			name:   "large value",
+			sample: metrics.Sample{Name: "m", Value: 1e21},
+			want:   "m value=1e+21 1593628810000000000\n",
+		},
+		{
+			name:   "NaN value",
+			sample: metrics.Sample{Name: "m", Value: math.NaN()},
+		},
+		{
+			name:   "infinite value",
+			sample: metrics.Sample{Name: "m", Value: math.Inf(-1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			tt.sample.Timestamp = ts
+
+			if got := string(encodeLines([]metrics.Sample{tt.sample})); got != tt.want {
+				t.Errorf("encodeLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeLinesKeepsLabelsOrder(t *testing.T) {
+
+	labels := []metrics.Label{{Name: "z", Value: "1"}, {Name: "a", Value: "2"}}
+
+	encodeLines([]metrics.Sample{{Name: "m", Labels: labels, Value: 1}})
+
+	// The sample labels are shared with the other sinks
+	if labels[0].Name != "z" {
+		t.Errorf("encodeLines() sorted the sample labels in place")
+	}
+}
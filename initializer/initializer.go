@@ -34,13 +34,13 @@ func printBanner() {
 	fmt.Printf("\n")
 	banner, err := os.Open("banner.txt")
 	if err != nil {
-		logging.PeppaMonLog("error", "Not able to load banner: ", err.Error())
+		logging.PeppaMonLog("error", "Not able to load banner: %v", err)
 	}
 	defer banner.Close()
 
 	_, err = io.Copy(os.Stdout, banner)
 	if err != nil {
-		logging.PeppaMonLog("error", "Not able to load banner: ", err.Error())
+		logging.PeppaMonLog("error", "Not able to load banner: %v", err)
 	}
 	fmt.Printf("\n\n")
 }
@@ -65,7 +65,7 @@ func printPlatformDetails() {
 	platform, err := host.Info()
 
 	if err != nil {
-		logging.PeppaMonLog("error", "Unable to fetch platform details:", err.Error())
+		logging.PeppaMonLog("error", "Unable to fetch platform details: %v", err)
 	} else {
 		fmt.Println(
 			logging.UnderlineText("Hostname:"),
@@ -79,7 +79,7 @@ func printPlatformDetails() {
 
 	cpuDetails, err := cpu.Info()
 	if err != nil {
-		logging.PeppaMonLog("error", "Unable to fetch CPU details:", err.Error())
+		logging.PeppaMonLog("error", "Unable to fetch CPU details: %v", err)
 	} else {
 		fmt.Println(logging.UnderlineText("CPU Model:"), logging.InfoMessage(cpuDetails[0].ModelName))
 		fmt.Println(logging.UnderlineText("CPU Core(s):"), logging.InfoMessage(runtime.NumCPU()))
@@ -89,7 +89,7 @@ func printPlatformDetails() {
 	diskUsage, err := disk.Usage("/")
 
 	if err != nil {
-		logging.PeppaMonLog("error", "Unable to fetch disk Usage details:", err.Error())
+		logging.PeppaMonLog("error", "Unable to fetch disk Usage details: %v", err)
 	} else {
 		diskUsageRounded := strconv.Itoa(int(math.Round(diskUsage.UsedPercent)))
 
@@ -100,7 +100,7 @@ func printPlatformDetails() {
 	memUsage, err := mem.VirtualMemory()
 
 	if err != nil {
-		logging.PeppaMonLog("error", "Unable to fetch Memory details:", err.Error())
+		logging.PeppaMonLog("error", "Unable to fetch Memory details: %v", err)
 	} else {
 		memUsageRounded := strconv.Itoa(int(math.Round(memUsage.UsedPercent)))
 		fmt.Println(
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metadb"
+)
+
+// Pagination of the inventory listings
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// Time range of the metadata changes returned when the request does not set it
+const defaultChangesRange = 24 * time.Hour
+
+// inventoryPage represents the payload of the inventory listings
+type inventoryPage struct {
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+	Items  interface{} `json:"items"`
+}
+
+// changesResponse represents the payload of the metadata changes endpoints
+type changesResponse struct {
+	Node    string          `json:"node"`
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	Changes []metadb.Change `json:"changes"`
+}
+
+// devicesHandler lists the devices with metadata recorded.
+// Query parameters other than limit and offset filter the devices on the column values.
+func devicesHandler(w http.ResponseWriter, req *http.Request) {
+
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	db, q, ok := inventoryRequest(w, req)
+
+	if !ok {
+		return
+	}
+
+	devices, total, err := db.ListDevices(req.Context(), q)
+
+	writeInventoryPage(w, "devices", devices, total, q, err)
+}
+
+// deviceHandler serves the data of a device under /api/devices/{node} and its inventory listings under
+// /api/devices/{node}/interfaces, bgp-peers, hardware, ip-sla and changes
+func deviceHandler(w http.ResponseWriter, req *http.Request) {
+
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/api/devices/"), "/"), "/")
+
+	node := path[0]
+
+	if node == "" || len(path) > 2 {
+		http.NotFound(w, req)
+		return
+	}
+
+	if len(path) == 2 && path[1] == "changes" {
+		changesForNode(w, req, node)
+		return
+	}
+
+	db, q, ok := inventoryRequest(w, req)
+
+	if !ok {
+		return
+	}
+
+	if len(path) == 1 {
+
+		device, err := db.GetDevice(req.Context(), node)
+
+		if errors.Is(err, metadb.ErrDeviceNotFound) {
+			http.Error(w, "device "+node+" not found", http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to retrieve device %v from metadata DB: %v", node, err)
+
+			http.Error(w, "failed to retrieve device", http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, device)
+		return
+	}
+
+	var (
+		items interface{}
+		total int
+		err   error
+	)
+
+	switch path[1] {
+	case "interfaces":
+		items, total, err = db.ListInterfaces(req.Context(), node, q)
+	case "bgp-peers":
+		items, total, err = db.ListBgpPeers(req.Context(), node, q)
+	case "hardware":
+		items, total, err = db.ListHardware(req.Context(), node, q)
+	case "ip-sla":
+		items, total, err = db.ListIPSlaEntries(req.Context(), node, q)
+	default:
+		http.NotFound(w, req)
+		return
+	}
+
+	writeInventoryPage(w, path[1], items, total, q, err)
+}
+
+// inventoryRequest returns the metadata store and the listing query of the request.
+// The error response is written when the request cannot be served.
+func inventoryRequest(w http.ResponseWriter, req *http.Request) (metadb.Store, metadb.ListQuery, bool) {
+
+	q := metadb.ListQuery{
+		Filters: make(map[string]string),
+		Limit:   defaultPageLimit,
+	}
+
+	for name, values := range req.URL.Query() {
+
+		value := values[len(values)-1]
+
+		switch name {
+		case "limit":
+			limit, err := strconv.Atoi(value)
+
+			if err != nil || limit < 1 || limit > maxPageLimit {
+				http.Error(w, "limit must be between 1 and "+strconv.Itoa(maxPageLimit), http.StatusBadRequest)
+				return nil, q, false
+			}
+			q.Limit = limit
+
+		case "offset":
+			offset, err := strconv.Atoi(value)
+
+			if err != nil || offset < 0 {
+				http.Error(w, "offset must not be negative", http.StatusBadRequest)
+				return nil, q, false
+			}
+			q.Offset = offset
+
+		default:
+			q.Filters[name] = value
+		}
+	}
+
+	db := metadb.Instance()
+
+	if db == nil {
+		http.Error(w, "metadata database not available", http.StatusServiceUnavailable)
+		return nil, q, false
+	}
+
+	return db, q, true
+}
+
+// writeInventoryPage renders a page of an inventory listing or the listing error
+func writeInventoryPage(w http.ResponseWriter, listing string, items interface{}, total int, q metadb.ListQuery,
+	err error) {
+
+	if errors.Is(err, metadb.ErrInvalidFilter) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to list %v from metadata DB: %v", listing, err)
+
+		http.Error(w, "failed to list "+listing, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, inventoryPage{Total: total, Limit: q.Limit, Offset: q.Offset, Items: items})
+}
+
+// changesHandler returns the interfaces, BGP peers and hardware inventory changes of the node query parameter
+func changesHandler(w http.ResponseWriter, req *http.Request) {
+
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	node := req.URL.Query().Get("node")
+
+	if node == "" {
+		http.Error(w, "missing node query parameter", http.StatusBadRequest)
+		return
+	}
+
+	changesForNode(w, req, node)
+}
+
+// changesForNode returns the metadata changes of the node.
+// The from and to query parameters are RFC 3339 timestamps and default to the last 24 hours.
+func changesForNode(w http.ResponseWriter, req *http.Request, node string) {
+
+	q := req.URL.Query()
+
+	to := time.Now().UTC()
+
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+
+		if err != nil {
+			http.Error(w, "invalid to query parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultChangesRange)
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+
+		if err != nil {
+			http.Error(w, "invalid from query parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	db := metadb.Instance()
+
+	if db == nil {
+		http.Error(w, "metadata database not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	changes, err := db.ListChanges(req.Context(), node, from, to)
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to list metadata changes of node %v: %v", node, err)
+
+		http.Error(w, "failed to list metadata changes", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, changesResponse{Node: node, From: from, To: to, Changes: changes})
+}
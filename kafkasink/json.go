@@ -0,0 +1,136 @@
+package kafkasink
+
+import (
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// telemetryRecord represents the JSON value of a decoded Telemetry message record
+type telemetryRecord struct {
+	NodeID              string                   `json:"node_id_str"`
+	SubscriptionID      string                   `json:"subscription_id_str,omitempty"`
+	EncodingPath        string                   `json:"encoding_path"`
+	CollectionID        uint64                   `json:"collection_id"`
+	CollectionStartTime uint64                   `json:"collection_start_time,omitempty"`
+	MsgTimestamp        uint64                   `json:"msg_timestamp"`
+	CollectionEndTime   uint64                   `json:"collection_end_time,omitempty"`
+	Fields              []map[string]interface{} `json:"fields"`
+}
+
+// sampleRecord represents the JSON value of a normalised sample record
+type sampleRecord struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Value     float64           `json:"value"`
+	Timestamp int64             `json:"timestamp"`
+	Type      string            `json:"type"`
+}
+
+func newTelemetryRecord(msg *telemetry.Telemetry) telemetryRecord {
+
+	r := telemetryRecord{
+		NodeID:              msg.GetNodeIdStr(),
+		SubscriptionID:      msg.GetSubscriptionIdStr(),
+		EncodingPath:        msg.GetEncodingPath(),
+		CollectionID:        msg.GetCollectionId(),
+		CollectionStartTime: msg.GetCollectionStartTime(),
+		MsgTimestamp:        msg.GetMsgTimestamp(),
+		CollectionEndTime:   msg.GetCollectionEndTime(),
+		Fields:              make([]map[string]interface{}, 0, len(msg.GetDataGpbkv())),
+	}
+
+	for _, row := range msg.GetDataGpbkv() {
+
+		rowObj := fieldsToJSON(row.GetFields())
+
+		if row.GetTimestamp() != 0 {
+			rowObj["timestamp"] = row.GetTimestamp()
+		}
+		r.Fields = append(r.Fields, rowObj)
+	}
+
+	return r
+}
+
+// fieldsToJSON converts kvGPB fields into a JSON object. Fields repeated with the same name become arrays
+func fieldsToJSON(fields []*telemetry.TelemetryField) map[string]interface{} {
+
+	obj := make(map[string]interface{}, len(fields))
+
+	for _, f := range fields {
+
+		var v interface{}
+
+		if len(f.GetFields()) > 0 {
+			v = fieldsToJSON(f.GetFields())
+		} else {
+			v = fieldValue(f)
+		}
+
+		existing, ok := obj[f.GetName()]
+
+		if !ok {
+			obj[f.GetName()] = v
+			continue
+		}
+
+		if list, isList := existing.([]interface{}); isList {
+			obj[f.GetName()] = append(list, v)
+		} else {
+			obj[f.GetName()] = []interface{}{existing, v}
+		}
+	}
+
+	return obj
+}
+
+func fieldValue(f *telemetry.TelemetryField) interface{} {
+
+	switch v := f.ValueByType.(type) {
+	case *telemetry.TelemetryField_BytesValue:
+		return v.BytesValue
+	case *telemetry.TelemetryField_StringValue:
+		return v.StringValue
+	case *telemetry.TelemetryField_BoolValue:
+		return v.BoolValue
+	case *telemetry.TelemetryField_Uint32Value:
+		return v.Uint32Value
+	case *telemetry.TelemetryField_Uint64Value:
+		return v.Uint64Value
+	case *telemetry.TelemetryField_Sint32Value:
+		return v.Sint32Value
+	case *telemetry.TelemetryField_Sint64Value:
+		return v.Sint64Value
+	case *telemetry.TelemetryField_DoubleValue:
+		return v.DoubleValue
+	case *telemetry.TelemetryField_FloatValue:
+		return v.FloatValue
+	}
+
+	return nil
+}
+
+func newSampleRecord(s metrics.Sample) sampleRecord {
+
+	r := sampleRecord{
+		Name:      s.Name,
+		Labels:    make(map[string]string, len(s.Labels)),
+		Value:     s.Value,
+		Timestamp: s.Timestamp.UnixNano() / 1e6,
+		Type:      "untyped",
+	}
+
+	for _, l := range s.Labels {
+		r.Labels[l.Name] = l.Value
+	}
+
+	switch s.Type {
+	case prometheus.CounterValue:
+		r.Type = "counter"
+	case prometheus.GaugeValue:
+		r.Type = "gauge"
+	}
+
+	return r
+}
@@ -0,0 +1,376 @@
+// Package kafkasink produces the decoded Telemetry messages and the normalised metrics samples to Kafka topics
+// keyed by Telemetry node so stream processing jobs can consume the devices telemetry
+package kafkasink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// Placeholder replaced by the sanitized YANG encoding path in topic names
+	topicPathPlaceholder = "{path}"
+	maxTopicNameLength   = 249
+)
+
+// Record types and results of the RecordsCounter
+const (
+	recordTypeTelemetry  = "telemetry"
+	recordTypeSample     = "sample"
+	recordResultSent     = "sent"
+	recordResultFailed   = "failed"
+	recordResultDropped  = "dropped"
+	recordResultEncoding = "encoding_error"
+)
+
+// RecordsCounter counts the Kafka records processed by type (telemetry, sample) and result
+var RecordsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "peppamon_kafka_records_total",
+		Help: "The number of Kafka records processed by the Kafka output sink by type and result",
+	},
+	[]string{"type", "result"},
+)
+
+// Settings represents the Kafka output sink settings
+type Settings struct {
+	// Kafka bootstrap brokers
+	Brokers []string
+
+	// Topic of the decoded Telemetry messages. Empty disables them.
+	// {path} is replaced by the YANG encoding path. i.e. peppamon.telemetry.{path}
+	TelemetryTopic string
+
+	// Topic of the normalised metrics samples. Empty disables them. {path} is supported as well
+	SamplesTopic string
+
+	// Compression codec: none, gzip, snappy, lz4 or zstd
+	Compression string
+
+	// Acknowledgements required from the brokers: none (at most once), one (leader) or all (in-sync replicas)
+	RequiredAcks string
+
+	// Number of delivery attempts of a batch before its records are dropped
+	MaxAttempts int
+
+	// Maximum number of records per batch and maximum time records wait before being sent
+	BatchSize    int
+	BatchTimeout time.Duration
+
+	// Maximum number of records waiting to be sent. Further records are dropped
+	QueueSize int
+
+	// Timeout of a batch write to the brokers
+	WriteTimeout time.Duration
+}
+
+// Enabled returns whether the Kafka output sink is configured
+func (s Settings) Enabled() bool {
+	return len(s.Brokers) > 0 && (s.TelemetryTopic != "" || s.SamplesTopic != "")
+}
+
+// SettingsFromConfig builds the Kafka output sink settings from the outputs.kafka configuration section
+func SettingsFromConfig(c config.Kafka) Settings {
+
+	return Settings{
+		Brokers:        c.Brokers,
+		TelemetryTopic: c.TelemetryTopic,
+		SamplesTopic:   c.SamplesTopic,
+		Compression:    c.Compression,
+		RequiredAcks:   c.RequiredAcks,
+		MaxAttempts:    c.MaxAttempts,
+		BatchSize:      c.BatchSize,
+		BatchTimeout:   c.BatchTimeout,
+		QueueSize:      c.QueueSize,
+		WriteTimeout:   c.WriteTimeout,
+	}
+}
+
+// Producer represents the Kafka client writing the records. kafka.Writer implements it, a stand-in
+// can be used to run the sink without brokers
+type Producer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// NewProducer returns a kafka-go Writer producing to the brokers with records partitioned by key
+func NewProducer(s Settings) (Producer, error) {
+
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(s.Brokers...),
+		Balancer:     &kafka.Hash{},
+		MaxAttempts:  s.MaxAttempts,
+		BatchSize:    s.BatchSize,
+		WriteTimeout: s.WriteTimeout,
+
+		// Records are already batched by the sink. Do not wait for partial batches per partition
+		BatchTimeout: 10 * time.Millisecond,
+	}
+
+	switch strings.ToLower(s.RequiredAcks) {
+	case "none":
+		w.RequiredAcks = kafka.RequireNone
+	case "one":
+		w.RequiredAcks = kafka.RequireOne
+	case "all":
+		w.RequiredAcks = kafka.RequireAll
+	default:
+		return nil, fmt.Errorf("unsupported Kafka required acks %v", s.RequiredAcks)
+	}
+
+	switch strings.ToLower(s.Compression) {
+	case "none":
+	case "gzip":
+		w.Compression = kafka.Gzip
+	case "snappy":
+		w.Compression = kafka.Snappy
+	case "lz4":
+		w.Compression = kafka.Lz4
+	case "zstd":
+		w.Compression = kafka.Zstd
+	default:
+		return nil, fmt.Errorf("unsupported Kafka compression %v", s.Compression)
+	}
+
+	return w, nil
+}
+
+// record represents a Kafka record waiting to be encoded and produced.
+// Value is either a decoded Telemetry message or a sample
+type record struct {
+	recordType string
+	topic      string
+	key        string
+	msg        *telemetry.Telemetry
+	sample     metrics.Sample
+}
+
+// Sink produces the decoded Telemetry messages and the metrics samples to Kafka.
+// It implements both the metrics.Sink and metrics.MessageSink interfaces.
+type Sink struct {
+	settings Settings
+	producer Producer
+	queue    chan record
+}
+
+// NewSink returns a Kafka output sink writing to the given producer. Records are sent once Run is started
+func NewSink(s Settings, p Producer) *Sink {
+
+	return &Sink{
+		settings: s,
+		producer: p,
+		queue:    make(chan record, s.QueueSize),
+	}
+}
+
+// PublishMessage queues the decoded Telemetry message keyed by node
+func (k *Sink) PublishMessage(msg *telemetry.Telemetry) {
+
+	if k.settings.TelemetryTopic == "" {
+		return
+	}
+
+	k.enqueue(record{
+		recordType: recordTypeTelemetry,
+		topic:      topicName(k.settings.TelemetryTopic, msg.GetEncodingPath()),
+		key:        msg.GetNodeIdStr(),
+		msg:        msg,
+	})
+}
+
+// Publish queues the samples of a collection round keyed by node
+func (k *Sink) Publish(r *metrics.Round) {
+
+	if k.settings.SamplesTopic == "" {
+		return
+	}
+
+	topic := topicName(k.settings.SamplesTopic, r.Source.Path)
+
+	for _, s := range r.Samples {
+		k.enqueue(record{
+			recordType: recordTypeSample,
+			topic:      topic,
+			key:        r.Source.NodeID,
+			sample:     s,
+		})
+	}
+}
+
+// Remove implements the metrics.Sink interface. Records already produced cannot be withdrawn
+func (k *Sink) Remove(src metrics.Source) {}
+
+func (k *Sink) enqueue(r record) {
+
+	select {
+	case k.queue <- r:
+	default:
+		RecordsCounter.WithLabelValues(r.recordType, recordResultDropped).Inc()
+	}
+}
+
+// Run produces the queued records in batches until the context is cancelled, then drains the queue
+// and closes the producer
+func (k *Sink) Run(ctx context.Context) {
+
+	defer func() {
+		if err := k.producer.Close(); err != nil {
+			logging.PeppaMonLog("error", "Failed to close Kafka producer %v", err)
+		}
+	}()
+
+	ticker := time.NewTicker(k.settings.BatchTimeout)
+	defer ticker.Stop()
+
+	pending := make([]record, 0, k.settings.BatchSize)
+
+	for {
+		select {
+		case <-ctx.Done():
+			k.drain(pending)
+			return
+
+		case r := <-k.queue:
+			pending = append(pending, r)
+
+			if len(pending) >= k.settings.BatchSize {
+				k.produce(ctx, pending)
+				pending = pending[:0]
+			}
+
+		case <-ticker.C:
+			if len(pending) > 0 {
+				k.produce(ctx, pending)
+				pending = pending[:0]
+			}
+		}
+	}
+}
+
+// drain produces the pending and queued records within batch.DrainTimeout
+func (k *Sink) drain(pending []record) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), batch.DrainTimeout)
+	defer cancel()
+
+	for {
+
+		if ctx.Err() != nil {
+
+			dropped := len(pending) + len(k.queue)
+
+			for _, r := range pending {
+				RecordsCounter.WithLabelValues(r.recordType, recordResultDropped).Inc()
+			}
+
+			for len(k.queue) > 0 {
+				RecordsCounter.WithLabelValues((<-k.queue).recordType, recordResultDropped).Inc()
+			}
+
+			logging.PeppaMonLog(
+				"warning",
+				"Kafka output sink did not drain in %v. Dropped %d record(s)", batch.DrainTimeout, dropped)
+			return
+		}
+
+		select {
+		case r := <-k.queue:
+			pending = append(pending, r)
+
+			if len(pending) < k.settings.BatchSize {
+				continue
+			}
+
+		default:
+			if len(pending) > 0 {
+				k.produce(ctx, pending)
+			}
+			return
+		}
+
+		k.produce(ctx, pending)
+		pending = pending[:0]
+	}
+}
+
+// produce encodes records in JSON and writes them to the producer which retries up to MaxAttempts
+func (k *Sink) produce(ctx context.Context, records []record) {
+
+	msgs := make([]kafka.Message, 0, len(records))
+	counts := make(map[string]int)
+
+	for _, r := range records {
+
+		var value []byte
+		var err error
+
+		if r.msg != nil {
+			value, err = json.Marshal(newTelemetryRecord(r.msg))
+		} else {
+			value, err = json.Marshal(newSampleRecord(r.sample))
+		}
+
+		if err != nil {
+			RecordsCounter.WithLabelValues(r.recordType, recordResultEncoding).Inc()
+			continue
+		}
+
+		msgs = append(msgs, kafka.Message{
+			Topic: r.topic,
+			Key:   []byte(r.key),
+			Value: value,
+		})
+		counts[r.recordType]++
+	}
+
+	result := recordResultSent
+
+	if err := k.producer.WriteMessages(ctx, msgs...); err != nil {
+
+		result = recordResultFailed
+
+		logging.PeppaMonLog(
+			"error",
+			"Failed to produce %d record(s) to Kafka: %v", len(msgs), err)
+	}
+
+	for recordType, n := range counts {
+		RecordsCounter.WithLabelValues(recordType, result).Add(float64(n))
+	}
+}
+
+// topicName replaces the {path} placeholder with the YANG encoding path restricted to the Kafka topic characters.
+// i.e. Cisco-IOS-XE-interfaces-oper:interfaces/interface becomes Cisco-IOS-XE-interfaces-oper.interfaces.interface
+func topicName(template string, encodingPath string) string {
+
+	if !strings.Contains(template, topicPathPlaceholder) {
+		return template
+	}
+
+	path := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '.'
+	}, strings.Trim(encodingPath, "/"))
+
+	topic := strings.Replace(template, topicPathPlaceholder, path, -1)
+
+	if len(topic) > maxTopicNameLength {
+		topic = topic[:maxTopicNameLength]
+	}
+
+	return topic
+}
@@ -0,0 +1,394 @@
+package kafkasink
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/segmentio/kafka-go"
+)
+
+const interfacesPath = "Cisco-IOS-XE-interfaces-oper:interfaces/interface"
+
+// producer records the batches written in place of the brokers
+type producer struct {
+	mu      sync.Mutex
+	batches [][]kafka.Message
+	closed  bool
+
+	// Error returned by the writes
+	err error
+}
+
+func (p *producer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.batches = append(p.batches, msgs)
+
+	return p.err
+}
+
+func (p *producer) Close() error {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+
+	return nil
+}
+
+func (p *producer) written() [][]kafka.Message {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([][]kafka.Message(nil), p.batches...)
+}
+
+// counted returns the number of records counted for the type and result
+func counted(recordType string, result string) float64 {
+	return testutil.ToFloat64(RecordsCounter.WithLabelValues(recordType, result))
+}
+
+func testSettings() Settings {
+
+	return Settings{
+		Brokers:        []string{"kafka:9092"},
+		TelemetryTopic: "peppamon.telemetry.{path}",
+		SamplesTopic:   "peppamon.samples",
+		BatchSize:      100,
+		BatchTimeout:   time.Hour,
+		QueueSize:      100,
+	}
+}
+
+// runSink runs the sink until stop is called, which waits for Run to return
+func runSink(k *Sink) (stop func()) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		k.Run(ctx)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func testMessage() *telemetry.Telemetry {
+
+	return &telemetry.Telemetry{
+		NodeId:       &telemetry.Telemetry_NodeIdStr{NodeIdStr: "r1"},
+		Subscription: &telemetry.Telemetry_SubscriptionIdStr{SubscriptionIdStr: "101"},
+		EncodingPath: interfacesPath,
+		CollectionId: 5,
+		MsgTimestamp: 1000,
+		DataGpbkv: []*telemetry.TelemetryField{{Timestamp: 1000, Fields: []*telemetry.TelemetryField{
+			{Name: "keys", Fields: []*telemetry.TelemetryField{
+				{Name: "name", ValueByType: &telemetry.TelemetryField_StringValue{StringValue: "Gi1"}},
+			}},
+			{Name: "content", Fields: []*telemetry.TelemetryField{
+				{Name: "in-octets", ValueByType: &telemetry.TelemetryField_Uint64Value{Uint64Value: 10}},
+				{Name: "ip", ValueByType: &telemetry.TelemetryField_StringValue{StringValue: "192.0.2.1"}},
+				{Name: "ip", ValueByType: &telemetry.TelemetryField_StringValue{StringValue: "192.0.2.2"}},
+				{Name: "enabled", ValueByType: &telemetry.TelemetryField_BoolValue{BoolValue: true}},
+			}},
+		}}},
+	}
+}
+
+func testRound(node string, n int) *metrics.Round {
+
+	r := &metrics.Round{Source: metrics.Source{NodeID: node, Path: interfacesPath}}
+
+	for i := 0; i < n; i++ {
+		r.Samples = append(r.Samples, metrics.Sample{
+			Name:      "cisco_iosxe_if_in_octets",
+			Labels:    []metrics.Label{{Name: "node", Value: node}, {Name: "interface", Value: "Gi1"}},
+			Value:     float64(i),
+			Timestamp: time.Unix(1, 500*int64(time.Millisecond)),
+			Type:      prometheus.CounterValue,
+		})
+	}
+
+	return r
+}
+
+func TestSinkEncoding(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		publish   func(k *Sink)
+		wantTopic string
+		wantKey   string
+		wantValue string
+	}{
+		{
+			name:      "telemetry message",
+			publish:   func(k *Sink) { k.PublishMessage(testMessage()) },
+			wantTopic: "peppamon.telemetry.Cisco-IOS-XE-interfaces-oper.interfaces.interface",
+			wantKey:   "r1",
+			wantValue: `{"node_id_str":"r1","subscription_id_str":"101","encoding_path":"` + interfacesPath + `",` +
+				`"collection_id":5,"msg_timestamp":1000,"fields":[{"content":{"enabled":true,"in-octets":10,` +
+				`"ip":["192.0.2.1","192.0.2.2"]},"keys":{"name":"Gi1"},"timestamp":1000}]}`,
+		},
+		{
+			name:      "sample",
+			publish:   func(k *Sink) { k.Publish(testRound("r2", 1)) },
+			wantTopic: "peppamon.samples",
+			wantKey:   "r2",
+			wantValue: `{"name":"cisco_iosxe_if_in_octets","labels":{"interface":"Gi1","node":"r2"},"value":0,` +
+				`"timestamp":1500,"type":"counter"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			p := &producer{}
+			k := NewSink(testSettings(), p)
+
+			tt.publish(k)
+			runSink(k)()
+
+			batches := p.written()
+
+			if len(batches) != 1 || len(batches[0]) != 1 {
+				t.Fatalf("batches = %v, want a single record", batches)
+			}
+
+			m := batches[0][0]
+
+			if m.Topic != tt.wantTopic || string(m.Key) != tt.wantKey {
+				t.Errorf("record topic %v key %s, want %v %v", m.Topic, m.Key, tt.wantTopic, tt.wantKey)
+			}
+
+			if string(m.Value) != tt.wantValue {
+				t.Errorf("record value =\n%s\nwant\n%s", m.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestSinkDisabledTopics(t *testing.T) {
+
+	s := testSettings()
+	s.TelemetryTopic = ""
+	s.SamplesTopic = ""
+
+	k := NewSink(s, &producer{})
+
+	k.PublishMessage(testMessage())
+	k.Publish(testRound("r1", 1))
+
+	if len(k.queue) != 0 {
+		t.Errorf("%d record(s) queued with the topics disabled, want none", len(k.queue))
+	}
+}
+
+func TestSinkBatches(t *testing.T) {
+
+	s := testSettings()
+	s.BatchSize = 2
+
+	p := &producer{}
+	k := NewSink(s, p)
+
+	sentBefore := counted(recordTypeSample, recordResultSent)
+
+	// Full batches are produced as they fill up, the remaining records when the sink stops
+	k.Publish(testRound("r1", 3))
+	k.Publish(testRound("r2", 2))
+
+	stop := runSink(k)
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for len(p.written()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stop()
+
+	var sizes []int
+	var keys []string
+
+	for _, b := range p.written() {
+
+		sizes = append(sizes, len(b))
+
+		for _, m := range b {
+			keys = append(keys, string(m.Key))
+		}
+	}
+
+	if want := []int{2, 2, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("batches = %v, want %v", sizes, want)
+	}
+
+	// Records are keyed by node so the records of a node are kept in order within a partition
+	if want := []string{"r1", "r1", "r1", "r2", "r2"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	if got := counted(recordTypeSample, recordResultSent) - sentBefore; got != 5 {
+		t.Errorf("sent records counted = %v, want 5", got)
+	}
+
+	if !p.closed {
+		t.Error("producer not closed when the sink stopped")
+	}
+}
+
+func TestSinkBatchTimeout(t *testing.T) {
+
+	s := testSettings()
+	s.BatchTimeout = 10 * time.Millisecond
+
+	p := &producer{}
+	k := NewSink(s, p)
+
+	stop := runSink(k)
+	defer stop()
+
+	k.PublishMessage(testMessage())
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for len(p.written()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("partial batch not produced after the batch timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSinkDropsWhenFull(t *testing.T) {
+
+	s := testSettings()
+	s.QueueSize = 2
+
+	k := NewSink(s, &producer{})
+
+	droppedBefore := counted(recordTypeSample, recordResultDropped)
+
+	k.Publish(testRound("r1", 3))
+
+	if got := counted(recordTypeSample, recordResultDropped) - droppedBefore; got != 1 {
+		t.Errorf("dropped records counted = %v, want 1", got)
+	}
+}
+
+func TestSinkWriteFailure(t *testing.T) {
+
+	p := &producer{err: errors.New("leader not available")}
+	k := NewSink(testSettings(), p)
+
+	failedBefore := counted(recordTypeTelemetry, recordResultFailed)
+
+	k.PublishMessage(testMessage())
+	runSink(k)()
+
+	if got := counted(recordTypeTelemetry, recordResultFailed) - failedBefore; got != 1 {
+		t.Errorf("failed records counted = %v, want 1", got)
+	}
+}
+
+func TestTopicName(t *testing.T) {
+
+	long := make([]byte, 300)
+
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		want     string
+	}{
+		{name: "no placeholder", template: "peppamon", path: interfacesPath, want: "peppamon"},
+		{
+			name:     "path",
+			template: "peppamon.{path}",
+			path:     "/" + interfacesPath,
+			want:     "peppamon.Cisco-IOS-XE-interfaces-oper.interfaces.interface",
+		},
+		{name: "list keys", template: "{path}", path: "a:b/c[name=x y]", want: "a.b.c.name.x.y."},
+		{name: "truncated", template: "t.{path}", path: string(long), want: "t." + string(long[:247])},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topicName(tt.template, tt.path); got != tt.want {
+				t.Errorf("topicName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProducer(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		compression     string
+		acks            string
+		wantCompression kafka.Compression
+		wantAcks        kafka.RequiredAcks
+		wantErr         string
+	}{
+		{name: "defaults", compression: "none", acks: "all", wantAcks: kafka.RequireAll},
+		{name: "zstd leader", compression: "ZSTD", acks: "one", wantCompression: kafka.Zstd, wantAcks: kafka.RequireOne},
+		{name: "snappy at most once", compression: "snappy", acks: "none", wantCompression: kafka.Snappy},
+		{name: "compression", compression: "brotli", acks: "all", wantErr: "unsupported Kafka compression brotli"},
+		{name: "acks", compression: "none", acks: "-1", wantErr: "unsupported Kafka required acks -1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := testSettings()
+			s.Compression = tt.compression
+			s.RequiredAcks = tt.acks
+
+			p, err := NewProducer(s)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("NewProducer() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewProducer() error = %v", err)
+			}
+
+			w := p.(*kafka.Writer)
+
+			if w.Compression != tt.wantCompression || w.RequiredAcks != tt.wantAcks {
+				t.Errorf("NewProducer() compression %v acks %v, want %v %v",
+					w.Compression, w.RequiredAcks, tt.wantCompression, tt.wantAcks)
+			}
+
+			if _, ok := w.Balancer.(*kafka.Hash); !ok {
+				t.Errorf("NewProducer() balancer = %T, want records partitioned by key", w.Balancer)
+			}
+		})
+	}
+}
@@ -1,42 +1,161 @@
 package kvstore
 
 import (
+	"context"
 	"net"
-	"os"
+	"strconv"
 
 	"github.com/go-redis/redis/v7"
+	"github.com/lucabrasi83/peppamon_cisco/config"
 )
 
-var kvStoreClient = newKVStoreClient()
+// Redis client. nil when the KV store is not configured
+var kvStoreClient *redis.Client
 
-func newKVStoreClient() *redis.Client {
+// Configure creates the Redis client when the KV store host is set
+func Configure(cfg config.KVStore) {
+
+	if cfg.Host == "" {
+		return
+	}
+
+	kvStoreClient = newKVStoreClient(cfg)
+}
+
+func newKVStoreClient(cfg config.KVStore) *redis.Client {
 	// Set Redis Client options
 	redisClient := redis.NewClient(&redis.Options{
-		Addr:         os.Getenv("PEPPAMON_KV_HOST") + ":6379",
-		Password:     os.Getenv("PEPPAMON_KV_PASSWORD"),
-		DB:           0, // use default DB,
-		PoolSize:     20,
-		MinIdleConns: 5,
+		Addr:         net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
 	})
 
 	return redisClient
 }
 
-func InsertNewTelemetryHost(ip net.IPAddr, kv map[string]interface{}) error {
+// Enabled returns whether the Redis KV store is configured
+func Enabled() bool {
+	return kvStoreClient != nil
+}
+
+// Ping checks the Redis KV store answers
+func Ping(ctx context.Context) error {
+	return kvStoreClient.WithContext(ctx).Ping().Err()
+}
+
+// Redis keys of the device registry. Each device attributes are stored in a hash, the set lists the devices.
+const (
+	devicesKey      = "peppamon:devices"
+	deviceKeyPrefix = "peppamon:device:"
+)
+
+// SaveDevice replaces the attributes of the device in the registry
+func SaveDevice(node string, attributes map[string]string) error {
+
+	values := make([]interface{}, 0, 2*len(attributes))
+
+	for k, v := range attributes {
+		values = append(values, k, v)
+	}
+
+	_, err := kvStoreClient.TxPipelined(func(pipe redis.Pipeliner) error {
+
+		pipe.Del(deviceKeyPrefix + node)
+
+		if len(values) > 0 {
+			pipe.HSet(deviceKeyPrefix+node, values...)
+		}
+		pipe.SAdd(devicesKey, node)
+
+		return nil
+	})
+
+	return err
+}
+
+// createDeviceScript registers the device only when missing from the devices set, along with its attributes hash.
+// The script runs atomically so two concurrent registrations of a device cannot both succeed.
+var createDeviceScript = redis.NewScript(`
+if redis.call('SADD', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('DEL', KEYS[2])
+if #ARGV > 1 then
+	redis.call('HSET', KEYS[2], unpack(ARGV, 2))
+end
+return 1
+`)
+
+// CreateDevice registers the device with its attributes and returns false when it is already registered
+func CreateDevice(node string, attributes map[string]string) (bool, error) {
+
+	args := make([]interface{}, 0, 1+2*len(attributes))
+	args = append(args, node)
+
+	for k, v := range attributes {
+		args = append(args, k, v)
+	}
+
+	created, err := createDeviceScript.Run(kvStoreClient, []string{devicesKey, deviceKeyPrefix + node}, args...).Int()
+
+	if err != nil {
+		return false, err
+	}
+
+	return created == 1, nil
+}
+
+// DeleteDevice removes the device from the registry and returns whether it was registered
+func DeleteDevice(node string) (bool, error) {
+
+	var removed *redis.IntCmd
+
+	_, err := kvStoreClient.TxPipelined(func(pipe redis.Pipeliner) error {
+
+		pipe.Del(deviceKeyPrefix + node)
+		removed = pipe.SRem(devicesKey, node)
+
+		return nil
+	})
 
-	err := kvStoreClient.HSet(ip.IP.String(), "hostname", kv["hostname"]).Err()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return removed.Val() > 0, nil
 }
 
-func LookupTelemetryHost(ip net.IPAddr) (map[string]string, error) {
-	res, err := kvStoreClient.HGetAll(ip.IP.String()).Result()
+// LoadDevices returns the attributes of the registered devices
+func LoadDevices() (map[string]map[string]string, error) {
+
+	nodes, err := kvStoreClient.SMembers(devicesKey).Result()
 
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+
+	cmds := make(map[string]*redis.StringStringMapCmd, len(nodes))
+
+	_, err = kvStoreClient.Pipelined(func(pipe redis.Pipeliner) error {
+
+		for _, node := range nodes {
+			cmds[node] = pipe.HGetAll(deviceKeyPrefix + node)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make(map[string]map[string]string, len(nodes))
+
+	for node, cmd := range cmds {
+		devices[node] = cmd.Val()
+	}
+
+	return devices, nil
 }
@@ -2,28 +2,40 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"sync"
-	"time"
+	"sync/atomic"
 
-	"github.com/gogo/protobuf/jsonpb"
-	"github.com/gogo/protobuf/proto"
 	grpcRecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/capture"
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/decoder"
+	"github.com/lucabrasi83/peppamon_cisco/devices"
+	"github.com/lucabrasi83/peppamon_cisco/dialin"
+	"github.com/lucabrasi83/peppamon_cisco/grpctls"
+	"github.com/lucabrasi83/peppamon_cisco/health"
+	"github.com/lucabrasi83/peppamon_cisco/initializer"
+	"github.com/lucabrasi83/peppamon_cisco/kvstore"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 	"github.com/lucabrasi83/peppamon_cisco/metadb"
 	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	// Compact GPB models of the supported YANG paths
+	_ "github.com/lucabrasi83/peppamon_cisco/proto/gpbmodels"
 	mdt_dialout "github.com/lucabrasi83/peppamon_cisco/proto/mdt_grpc_dialout"
-	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/lucabrasi83/peppamon_cisco/selfmetrics"
+	"github.com/lucabrasi83/peppamon_cisco/sessions"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -32,23 +44,84 @@ import (
 type HighObsSrv struct {
 	exp *metrics.Collector
 	mu  *sync.Mutex
+
+	// Enforce Telemetry node_id_str to match the client TLS certificate identity
+	verifyNodeID bool
+
+	// Raw dial-out messages capture. nil when disabled
+	recorder *capture.Recorder
+
+	// Policy and counters of the YANG encoding paths without parser
+	unsupported *metrics.UnsupportedPaths
+
+	// Device registry. nil when the KV store is disabled
+	registry *devices.Registry
 }
 
 var (
-	// Register Peppamon Prometheus Collector
+	// Peppamon Collector dispatching the Telemetry metrics to the output sinks
 	collector = metrics.NewCollector()
+
+	// Active Telemetry dial-out sessions
+	dialoutSessions = sessions.NewRegistry()
 )
 
 func init() {
 
-	prometheus.MustRegister(collector)
+	prometheus.MustRegister(batch.SamplesCounter)
+
+	// Peppamon self-instrumentation
+	prometheus.MustRegister(selfmetrics.Collector())
+	selfmetrics.AddCacheSize("collector_staging", collector.StagedSamples)
 }
 
 func main() {
 
+	// Initializer Banner and binary metadata
+	initializer.Initialize()
+
+	// Sub-commands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			runReplay(os.Args[2:])
+			return
+		case "simulate":
+			runSimulate(os.Args[2:])
+			return
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		}
+	}
+
+	cfg := loadConfig(os.Args[1:])
+
 	// Release Postgres Connection Pool
-	defer metadb.ConnPool.Close()
-	lis, err := net.Listen("tcp", ":50051")
+	defer metadb.Close()
+
+	// Context to stop background routines such as TLS certificates reload
+	ctxBackground, cancelBackground := context.WithCancel(context.Background())
+	defer cancelBackground()
+
+	// Metadata persistence is optional and connects in the background when Postgres is unreachable
+	metadb.Start(ctxBackground, cfg.MetaDB)
+
+	kvstore.Configure(cfg.KVStore)
+
+	// Device registry cached from the KV store, its attributes are added as labels to the metrics of the devices
+	deviceRegistry := setupDeviceRegistry(ctxBackground, cfg.Registry)
+
+	// Register declarative YANG path to metrics mappings
+	loadMetricMappings(cfg.Metrics)
+
+	// Apply the unsupported YANG encoding paths policy
+	unsupportedPaths := setupUnsupportedPaths(cfg.Metrics)
+
+	// Collection rounds missing their last message are published before their metrics could expire
+	collector.SetCollectionRoundTimeout(metrics.CollectionRoundTimeoutFromConfig(cfg.Metrics))
+
+	lis, err := net.Listen("tcp", cfg.GRPC.ListenAddress)
 
 	if err != nil {
 		logging.PeppaMonLog(
@@ -59,19 +132,97 @@ func main() {
 
 	// Set gRPC Server Keepalive Settings
 	grpcServerKeepalives := keepalive.ServerParameters{
-		MaxConnectionIdle: 5 * time.Minute,
-		Time:              30 * time.Second,
-		Timeout:           time.Minute,
+		MaxConnectionIdle: cfg.GRPC.Keepalive.MaxConnectionIdle,
+		Time:              cfg.GRPC.Keepalive.Time,
+		Timeout:           cfg.GRPC.Keepalive.Timeout,
 	}
 	grpcServerKeepaliveOptions := grpc.KeepaliveParams(grpcServerKeepalives)
 
-	// Create gRPC Server with options and middleware
-	s := grpc.NewServer(
+	grpcServerOptions := []grpc.ServerOption{
 		grpcServerKeepaliveOptions,
-		grpc.StreamInterceptor(grpcRecovery.StreamServerInterceptor()),
-	)
+		grpc.StreamInterceptor(grpcRecovery.StreamServerInterceptor(
+			grpcRecovery.WithRecoveryHandler(recoverStreamPanic))),
+	}
+
+	// Enable the metrics output sinks
+	outputSinks := setupOutputSinks(ctxBackground, cfg)
+
+	// Set gRPC Server TLS Settings if certificates are provided
+	tlsSettings := grpctls.SettingsFromConfig(cfg.GRPC.TLS)
 
-	mdt_dialout.RegisterGRPCMdtDialoutServer(s, &HighObsSrv{exp: collector})
+	if tlsSettings.Enabled() {
+
+		certReloader, errTLS := grpctls.NewCertReloader(tlsSettings)
+
+		if errTLS != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to load Peppamon Telemetry gRPC TLS certificates %v", errTLS)
+		}
+
+		go certReloader.Watch(ctxBackground)
+
+		grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(certReloader.TLSConfig())))
+
+		logging.PeppaMonLog(
+			"info",
+			"Peppamon Telemetry gRPC collector TLS enabled (mutual TLS: %v)", tlsSettings.MutualTLS())
+	} else {
+		logging.PeppaMonLog(
+			"warning",
+			"Peppamon Telemetry gRPC collector TLS disabled. Telemetry streams will be received in cleartext")
+	}
+
+	// Start gNMI dial-in subscriptions if devices are configured
+	if cfg.DialIn.TargetsFile != "" {
+
+		gnmiTargets, errTargets := dialin.LoadTargets(cfg.DialIn.TargetsFile)
+
+		if errTargets != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to load gNMI dial-in targets %v", errTargets)
+		}
+
+		logging.PeppaMonLog(
+			"info",
+			"Starting gNMI dial-in subscriptions to %v device(s)...", len(gnmiTargets))
+
+		dialin.NewManager(collector, gnmiTargets).Start(ctxBackground)
+	}
+
+	// Create gRPC Server with options and middleware
+	s := grpc.NewServer(grpcServerOptions...)
+
+	telemetrySrv := &HighObsSrv{
+		exp:          collector,
+		verifyNodeID: tlsSettings.MutualTLS() && tlsSettings.VerifyNodeID,
+		unsupported:  unsupportedPaths,
+		registry:     deviceRegistry,
+	}
+
+	// Record raw dial-out messages to capture files for offline replay
+	captureSettings := capture.SettingsFromConfig(cfg.Capture)
+
+	if captureSettings.Enabled() {
+
+		recorder, errCapture := capture.NewRecorder(captureSettings)
+
+		if errCapture != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to start Telemetry capture %v", errCapture)
+		}
+		defer recorder.Close()
+
+		telemetrySrv.recorder = recorder
+
+		logging.PeppaMonLog(
+			"info",
+			"Capturing %v Telemetry messages to %v", captureSettings.Mode, captureSettings.Dir)
+	}
+
+	mdt_dialout.RegisterGRPCMdtDialoutServer(s, telemetrySrv)
 
 	logging.PeppaMonLog(
 		"info",
@@ -81,12 +232,55 @@ func main() {
 	ch := make(chan os.Signal, 1)
 
 	// Write in Channel in case of OS request to shut process
-	signal.Notify(ch, os.Interrupt)
+	signal.Notify(ch, shutdownSignals...)
+
+	promHTTPSrv := http.Server{Addr: cfg.HTTP.ListenAddress}
+
+	// Readiness checks
+	var grpcServing int32
+	readiness := health.NewChecker()
+
+	readiness.AddCheck("grpc", func(ctx context.Context) error {
+
+		if atomic.LoadInt32(&grpcServing) == 0 {
+			return fmt.Errorf("gRPC server is not serving")
+		}
+
+		conn, errDial := (&net.Dialer{}).DialContext(ctx, "tcp", lis.Addr().String())
+
+		if errDial != nil {
+			return errDial
+		}
+
+		return conn.Close()
+	})
+
+	// The metadata database and KV store health is reported on the dependencies endpoint, and on readiness unless
+	// disabled as the metadata writes are queued and retried while they are unavailable
+	dependencies := health.NewChecker()
 
-	promHTTPSrv := http.Server{Addr: ":2112"}
+	addDependencyCheck := func(name string, check health.Check) {
+
+		dependencies.AddCheck(name, check)
+
+		if cfg.HTTP.ReadinessDependencies {
+			readiness.AddCheck(name, check)
+		}
+	}
+
+	if cfg.MetaDB.Enabled() {
+		addDependencyCheck(cfg.MetaDB.Backend, metadb.Ping)
+	}
+
+	if kvstore.Enabled() {
+		addDependencyCheck("redis", kvstore.Ping)
+	}
 
 	// Start Peppamon gRPC Telemetry Collector
 	go func() {
+		atomic.StoreInt32(&grpcServing, 1)
+		defer atomic.StoreInt32(&grpcServing, 0)
+
 		if err := s.Serve(lis); err != nil {
 
 			logging.PeppaMonLog(
@@ -104,23 +298,29 @@ func main() {
 
 		http.Handle("/metrics", promhttp.Handler())
 
-		//http.HandleFunc("/telemetry-device", addTelemetryDeviceHandler)
+		http.HandleFunc("/healthz", health.LivenessHandler)
 
-		http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-			_, errWelcomePage := w.Write([]byte(`<html>
-             <head><title>Peppamon Cisco Telemetry Exporter</title></head>
-             <body>
-             <h1>Peppamon Cisco Telemetry Exporter</h1>
-             <p><a href="/metrics">Metrics</a></p>
-             </body>
-             </html>`))
+		http.HandleFunc("/readyz", readiness.Handler)
 
-			if errWelcomePage != nil {
-				logging.PeppaMonLog(
-					"error",
-					"Failed to render Welcome page %v", err)
-			}
-		})
+		http.HandleFunc("/healthz/dependencies", dependencies.Handler)
+
+		http.HandleFunc("/api/unsupported-paths", unsupportedPathsHandler(unsupportedPaths))
+
+		http.HandleFunc("/api/sessions", sessionsHandler(dialoutSessions))
+
+		http.HandleFunc("/api/config", configHandler(cfg))
+
+		http.HandleFunc("/api/changes", changesHandler)
+
+		http.HandleFunc("/api/devices", devicesHandler)
+
+		http.HandleFunc("/api/devices/", deviceHandler)
+
+		http.HandleFunc(registryDevicesPath, registryDevicesHandler(deviceRegistry))
+
+		http.HandleFunc(registryDevicesPath+"/", registryDeviceHandler(deviceRegistry))
+
+		http.HandleFunc("/", statusPageHandler(dialoutSessions))
 
 		if err := promHTTPSrv.ListenAndServe(); err != http.ErrServerClosed {
 			logging.PeppaMonLog(
@@ -129,23 +329,29 @@ func main() {
 		}
 	}()
 
-	// Block main function from exiting until ch receives value
-	<-ch
-	logging.PeppaMonLog("warning", "Shutting down Peppamon server...")
+	// Block main function from exiting until a shutdown signal is received
+	gracefulShutdown{
+		readiness:  readiness,
+		drainDelay: cfg.HTTP.DrainDelay,
+		stopServers: func() {
 
-	// Stop Prom HTTP, GRPC server
-	ctxPromHTTP, ctxCancel := context.WithTimeout(context.Background(), 3*time.Second)
+			// Stop Prom HTTP, GRPC server
+			ctxPromHTTP, ctxCancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 
-	defer ctxCancel()
+			defer ctxCancel()
 
-	errPromHTTPShut := promHTTPSrv.Shutdown(ctxPromHTTP)
+			errPromHTTPShut := promHTTPSrv.Shutdown(ctxPromHTTP)
 
-	if errPromHTTPShut != nil {
-		logging.PeppaMonLog(
-			"warning",
-			"Error while shutting down Prometheus HTTP Server %v", errPromHTTPShut)
-	}
-	s.Stop()
+			if errPromHTTPShut != nil {
+				logging.PeppaMonLog(
+					"warning",
+					"Error while shutting down Prometheus HTTP Server %v", errPromHTTPShut)
+			}
+			s.Stop()
+		},
+		cancelBackground: cancelBackground,
+		outputSinks:      outputSinks,
+	}.wait(ch)
 
 }
 
@@ -166,9 +372,21 @@ func (s *HighObsSrv) MdtDialout(stream mdt_dialout.GRPCMdtDialout_MdtDialoutServ
 		"info",
 		"Client Socket %v sending gRPC Telemetry Stream...", clientIPSocket)
 
+	selfmetrics.StreamOpened("dialout")
+	defer selfmetrics.StreamClosed("dialout")
+
+	session := dialoutSessions.Open(clientIPSocket)
+	defer dialoutSessions.Close(session)
+
+	// Client certificate Common Name and SANs used to verify the Telemetry node_id_str
+	peerIdentities := grpctls.PeerIdentities(stream.Context())
+
 	// Make sure we only the Telemetry subscription once to avoid flooding stdout
 	logFlag := false
 
+	// Unsupported YANG paths already logged for this stream
+	unsupportedLogged := make(map[string]bool)
+
 	// Start Telemetry gRPC stream receive
 	for {
 		req, err := stream.Recv()
@@ -185,12 +403,7 @@ func (s *HighObsSrv) MdtDialout(stream mdt_dialout.GRPCMdtDialout_MdtDialoutServ
 				"Error while reading client %v stream: %v", clientIPSocket, err)
 
 			// Removing Metrics from cache if client disconnected
-			s.exp.Mutex.Lock()
-			if _, ok := s.exp.Metrics[telemetrySource]; ok {
-
-				delete(s.exp.Metrics, telemetrySource)
-			}
-			s.exp.Mutex.Unlock()
+			s.exp.RemoveSource(telemetrySource)
 
 			return status.Errorf(
 				codes.Aborted,
@@ -201,166 +414,215 @@ func (s *HighObsSrv) MdtDialout(stream mdt_dialout.GRPCMdtDialout_MdtDialoutServ
 		// Get gRPC stream data
 		data := req.GetData()
 
-		// msg is of type Cisco Telemetry
-		msg := &telemetry.Telemetry{}
-
-		// Unmarshal Telemetry protocol buffer message
-		err = proto.Unmarshal(data, msg)
+		// Decode kvGPB, compact GPB or JSON payload into a Cisco Telemetry message carrying kvGPB rows
+		msg, encoding, err := decoder.Decode(data)
 
 		if err != nil {
+
+			selfmetrics.UnmarshalFailed(encoding.String())
+
+			// Node ID unknown, capture under the client IP
+			if s.recorder != nil {
+				clientHost, _, _ := net.SplitHostPort(clientIPSocket)
+				s.captureMsg(clientHost, req)
+			}
+
+			// Skip the message, the following ones of the stream may be decoded
 			logging.PeppaMonLog(
 				"error",
-				"Error while unmarshaling Proto message from client %v : %v", err, clientIPSocket)
+				"Skipping %v Telemetry message from client %v that could not be decoded: %v",
+				encoding, clientIPSocket, err)
 
-			return status.Errorf(
-				codes.Internal,
-				"unable to unmarshal protocol buffer message",
-			)
+			continue
 		}
 
-		// The Metrics Source represents the metrics cache key and is a combination of the Telemetry NodeID
-		// and YANG encoding path
-		msgPath := msg.GetEncodingPath()
 		telemetryNodeID := msg.GetNodeIdStr()
-		telemetrySource = metrics.Source{NodeID: telemetryNodeID, Path: msgPath}
 
-		// Instantiate Device Metrics Cache
-		devMutex := &sync.Mutex{}
-		deviceMetrics := &metrics.DeviceGroupedMetrics{Mutex: devMutex}
+		selfmetrics.MessageReceived(telemetryNodeID, msg.GetEncodingPath())
+		session.Record(telemetryNodeID, msg.GetSubscriptionIdStr(), msg.GetEncodingPath(), len(data))
 
-		// If Metric cache key already exists, invalidate and remove it
-		// Otherwise dashboard may show arbitrary / constant values
-		// See https://stackoverflow.com/questions/57304563/prometheus-exporter-direct-instrumentation-vs-custom-collector
-		s.exp.Mutex.Lock()
+		// Ensure the device streaming Telemetry is the one identified by the client certificate
+		if s.verifyNodeID && !grpctls.NodeIDMatchesIdentities(telemetryNodeID, peerIdentities) {
+			logging.PeppaMonLog(
+				"error",
+				"Client %v node ID %v does not match client certificate identities %v",
+				clientIPSocket, telemetryNodeID, peerIdentities)
 
-		if _, ok := s.exp.Metrics[telemetrySource]; ok {
-			delete(s.exp.Metrics, telemetrySource)
+			return status.Errorf(
+				codes.PermissionDenied,
+				fmt.Sprintf("Node ID %v does not match client certificate", telemetryNodeID))
 		}
 
-		// Set the metric cache key to include the Telemetry NodeIF and the YANG encoding path
-		s.exp.Metrics[telemetrySource] = deviceMetrics
-		s.exp.Mutex.Unlock()
-
 		// Limit logging of Telemetry client connections
 		if !logFlag {
 			logging.PeppaMonLog(
 				"info",
-				"Telemetry Subscription Request Received - Client %v - Node %v - YANG Model Path %v",
-				clientIPSocket, msg.GetNodeIdStr(), msg.GetEncodingPath(),
+				"Telemetry Subscription Request Received - Client %v - Node %v - YANG Model Path %v - Encoding %v",
+				clientIPSocket, msg.GetNodeIdStr(), msg.GetEncodingPath(), encoding,
 			)
 		}
 		logFlag = true
 
-		// Flag to determine whether the Telemetry device streams accepted YANG Node path
-		yangPathSupported := false
-
-		// Convert Proto Msg Timestamp to type Time for Prometheus metric
-		timestamp := msg.GetMsgTimestamp()
-		promTimestamp := time.Unix(int64(timestamp)/1000, 0)
-
-		// Get Node ID from Telemetry Message
-		node := msg.GetNodeIdStr()
-
-		// Map Node IP Address to Hostname from KV Store
-		//ipAddr, err := net.ResolveIPAddr("ip", node)
-		//
-		//if err != nil {
-		//	logging.PeppaMonLog("error", "unable to decode value %v as a valid IP Address with error %v", node, err)
-		//	return status.Errorf(
-		//		codes.InvalidArgument,
-		//		fmt.Sprintf("Unable to decode value %v as a valid IP Address", node))
-		//}
-
-		//telemetryDevice, err := kvstore.LookupTelemetryHost(*ipAddr)
-		//
-		//if err != nil {
-		//	logging.PeppaMonLog("error", "unable to lookup key %v in KV Config Store with error %v",
-		//		ipAddr.IP.String(), err)
-		//
-		//	return status.Errorf(
-		//		codes.InvalidArgument,
-		//		fmt.Sprintf("Unable to verify device %v in KV Store", node))
-		//}
-		//
-		//if _, ok := telemetryDevice["hostname"]; !ok {
-		//	logging.PeppaMonLog("error", "unable to verify device %v in KV Config store", node)
-		//	return status.Errorf(
-		//		codes.InvalidArgument,
-		//		fmt.Sprintf("Unable to verify device %v in KV Store", node))
-		//}
-
-		for _, m := range metrics.CiscoMetricRegistrar {
-			if msg.EncodingPath == m.EncodingPath {
-
-				yangPathSupported = true
-
-				go m.RecordMetricFunc(msg, deviceMetrics, promTimestamp.UTC(), node)
-			}
+		// Reject the devices missing from the device registry when registration is required
+		if s.registry != nil && s.registry.RequireRegistration() && !s.registry.Registered(telemetryNodeID) {
+			logging.PeppaMonLog(
+				"error",
+				"Client %v node ID %v is not registered in the device registry", clientIPSocket, telemetryNodeID)
+
+			return status.Errorf(
+				codes.PermissionDenied,
+				fmt.Sprintf("Node ID %v is not registered", telemetryNodeID))
 		}
 
+		// Capture before dispatch so messages crashing the parsers are recorded
+		if s.recorder != nil && s.recorder.CapturesAll() {
+			s.captureMsg(telemetryNodeID, req)
+		}
+
+		// Dispatch Telemetry message to the parsers of its YANG Node Path
+		var yangPathSupported bool
+		telemetrySource, yangPathSupported = s.exp.RecordTelemetryMsg(msg)
+
 		if !yangPathSupported {
 
-			// TEMP : Log undesired YANG metrics path as JSON for development purpose
-			j := jsonpb.Marshaler{}
-			s, _ := j.MarshalToString(msg)
-			ioutil.WriteFile("log.json", []byte(s), 0644)
+			if s.recorder != nil && !s.recorder.CapturesAll() {
+				s.captureMsg(telemetryNodeID, req)
+			}
+
+			if s.unsupported.Policy() == metrics.UnsupportedPathReject {
+				logging.PeppaMonLog(
+					"error",
+					"Received Telemetry message from client %v  (Device Name %v) for unsupported YANG Node Path %v",
+					clientIPSocket, msg.GetNodeIdStr(), msg.GetEncodingPath())
+
+				return status.Errorf(
+					codes.InvalidArgument,
+					fmt.Sprintf("YANG Node Path %v Telemetry subscription not supported", msg.GetEncodingPath()))
+			}
+
+			// Other sensor paths of the device share the stream so keep it open
+			if !unsupportedLogged[msg.GetEncodingPath()] {
+				unsupportedLogged[msg.GetEncodingPath()] = true
+
+				logging.PeppaMonLog(
+					"warning",
+					"Received Telemetry message from client %v  (Device Name %v) for unsupported YANG Node Path %v. "+
+						"Applying %v policy",
+					clientIPSocket, msg.GetNodeIdStr(), msg.GetEncodingPath(), s.unsupported.Policy())
+			}
+		}
+
+	}
+
+}
+
+// recoverStreamPanic counts the panics recovered from the Telemetry stream handlers
+func recoverStreamPanic(p interface{}) error {
+
+	selfmetrics.PanicRecovered()
+
+	logging.PeppaMonLog(
+		"error",
+		"Recovered from panic while handling Telemetry stream: %v", p)
+
+	return status.Errorf(codes.Internal, "%v", p)
+}
+
+// captureMsg records the raw dial-out message to the capture file of the device
+func (s *HighObsSrv) captureMsg(device string, req *mdt_dialout.MdtDialoutArgs) {
 
+	if err := s.recorder.Record(device, req); err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to capture Telemetry message from device %v : %v", device, err)
+	}
+}
+
+// loadConfig resolves the collector configuration from the configuration file, environment and command line
+func loadConfig(args []string) *config.Config {
+
+	cfg, err := config.Load("peppamon", args)
+
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"fatal",
+			"Failed to load Peppamon configuration %v", err)
+	}
+
+	return cfg
+}
+
+// loadMetricMappings registers the declarative YANG path to metrics mappings of metrics.mappings_file
+func loadMetricMappings(cfg config.Metrics) {
+
+	if cfg.MappingsFile != "" {
+
+		if errMappings := metrics.LoadMetricMappings(cfg.MappingsFile); errMappings != nil {
 			logging.PeppaMonLog(
-				"error",
-				"Received Telemetry message from client %v  (Device Name %v) for unsupported YANG Node Path %v",
-				clientIPSocket, msg.GetNodeIdStr(), msg.GetEncodingPath())
+				"fatal",
+				"Failed to load metric mappings file %v", errMappings)
+		}
+	}
+}
 
-			return status.Errorf(
-				codes.InvalidArgument,
-				fmt.Sprintf("YANG Node Path %v Telemetry subscription not supported", msg.GetEncodingPath()))
+// setupUnsupportedPaths applies the metrics.unsupported_paths_policy to the collector and exposes its counters.
+// The passthrough policy enables the generic decoder.
+func setupUnsupportedPaths(cfg config.Metrics) *metrics.UnsupportedPaths {
+
+	unsupportedPaths := metrics.NewUnsupportedPaths(metrics.UnsupportedPathsSettings{Policy: cfg.UnsupportedPathsPolicy})
+
+	prometheus.MustRegister(unsupportedPaths)
+	collector.SetUnsupportedPaths(unsupportedPaths)
+
+	logging.PeppaMonLog(
+		"info",
+		"Applying %v policy to Telemetry messages of unsupported YANG Node Paths", unsupportedPaths.Policy())
+
+	// Flatten the kvGPB rows of unsupported YANG paths into gauges
+	if unsupportedPaths.Policy() == metrics.UnsupportedPathPassThrough {
+
+		generic, err := metrics.NewGenericDecoder(metrics.GenericSettingsFromConfig(cfg.Generic))
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to create generic Telemetry decoder %v", err)
 		}
 
+		collector.SetGenericDecoder(generic)
+
+		logging.PeppaMonLog(
+			"info",
+			"Generic Telemetry decoder enabled for unsupported YANG Node Paths")
 	}
 
+	return unsupportedPaths
 }
 
-//type telemetryDeviceKVStore struct {
-//	IPAddress string `json:"ipAddress"`
-//	Hostname  string `json:"hostname"`
-//}
-
-//func addTelemetryDeviceHandler(w http.ResponseWriter, r *http.Request) {
-//
-//	if r.Method != "POST" {
-//		w.WriteHeader(http.StatusBadRequest)
-//		return
-//	}
-//
-//	data := telemetryDeviceKVStore{}
-//	err := json.NewDecoder(r.Body).Decode(&data)
-//
-//	if err != nil {
-//		logging.PeppaMonLog("error", "unable to decode JSON payload while creating new telemetry device: %v", err)
-//		w.WriteHeader(http.StatusBadRequest)
-//		return
-//	}
-//
-//	ipAddr, err := net.ResolveIPAddr("ip", data.IPAddress)
-//
-//	if err != nil {
-//		logging.PeppaMonLog("error", "unable to validate IP Address %v with error %v", data.IPAddress, err)
-//		w.WriteHeader(http.StatusBadRequest)
-//		return
-//	}
-//
-//	if data.Hostname == "" {
-//		w.WriteHeader(http.StatusBadRequest)
-//		return
-//	}
-//
-//	err = kvstore.InsertNewTelemetryHost(*ipAddr, map[string]interface{}{
-//		"hostname": data.Hostname,
-//	})
-//	if err != nil {
-//		logging.PeppaMonLog("error", "unable to create host in KV Config Store with error %v", err)
-//		w.WriteHeader(http.StatusBadRequest)
-//		return
-//	}
-//	w.Header().Set("Content-Type", "application/json")
-//	w.WriteHeader(http.StatusCreated)
-//}
+// setupDeviceRegistry loads the device registry from the KV store and registers its attributes as metric labels.
+// It returns nil when the KV store is disabled.
+func setupDeviceRegistry(ctx context.Context, cfg config.Registry) *devices.Registry {
+
+	if !kvstore.Enabled() {
+		return nil
+	}
+
+	registry := devices.NewRegistry(cfg)
+	registry.Start(ctx)
+
+	selfmetrics.AddCacheSize("device_registry", registry.Size)
+
+	if len(cfg.Labels) > 0 {
+		collector.SetNodeLabeler(registry)
+
+		logging.PeppaMonLog(
+			"info",
+			"Device registry attributes %v added as metric labels", cfg.Labels)
+	}
+
+	return registry
+}
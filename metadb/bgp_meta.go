@@ -3,6 +3,7 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
@@ -27,7 +28,9 @@ type bgpAFIDBObject struct {
 }
 
 // PersistsBgpAfiMetadata will save the BGP Address Family metadata in the Telemetry Meta DB
-func (p *peppamonMetaDB) PersistsBgpAfiMetadata(bgpAfiMeta []map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsBgpAfiMetadata(bgpAfiMeta []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("bgp_afi_meta", time.Now(), &err)
 
 	// Sanitize Data First
 	// Ensure Telemetry data from device and DB are in sync
@@ -94,7 +97,9 @@ func (p *peppamonMetaDB) PersistsBgpAfiMetadata(bgpAfiMeta []map[string]interfac
 	return nil
 }
 
-func (p *peppamonMetaDB) PersistsBgpPeersMetadata(bgpPeers []map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsBgpPeersMetadata(bgpPeers []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("bgp_neighbors_meta", time.Now(), &err)
 
 	// Sanitize Data First
 	// Ensure Telemetry data from device and DB are in sync
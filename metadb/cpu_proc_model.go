@@ -3,13 +3,16 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 )
 
 // PersistsCPUProcMetadata will update the Telemetry Metadata database with interfaces attributes
-func (p *peppamonMetaDB) PersistsCPUProcMetadata(cpuProc []map[string]interface{}) error {
+func (p *peppamonMetaDB) PersistsCPUProcMetadata(cpuProc []map[string]interface{}) (err error) {
+
+	defer observeWrite("cpu_processes_meta", time.Now(), &err)
 
 	// Set Query timeout
 	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
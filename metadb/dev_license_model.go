@@ -3,9 +3,12 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
-func (p *peppamonMetaDB) PersistsDeviceLicenseData(devLicenseData map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsDeviceLicenseData(devLicenseData map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("device_license_meta", time.Now(), &err)
 
 	// Set Query timeout
 	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
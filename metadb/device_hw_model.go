@@ -3,6 +3,7 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
@@ -15,7 +16,9 @@ type devHWInfoObj struct {
 	PartNumber   string
 }
 
-func (p *peppamonMetaDB) PersistsDeviceHWInventory(devHWInventory []map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsDeviceHWInventory(devHWInventory []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("device_hw_info", time.Now(), &err)
 
 	// Sanitize Data First
 	// Ensure Telemetry data from device and DB are in sync
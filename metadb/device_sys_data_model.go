@@ -3,12 +3,15 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 )
 
-func (p *peppamonMetaDB) PersistsDeviceSYSData(devSYSData []map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsDeviceSYSData(devSYSData []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("device_sys_data", time.Now(), &err)
 
 	// Set Query timeout
 	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
@@ -0,0 +1,162 @@
+package metadb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// Change types recorded in the metadata history tables
+const (
+	ChangeAdded    = "added"
+	ChangeModified = "modified"
+	ChangeRemoved  = "removed"
+)
+
+// Change represents a change of the interfaces, BGP peers or hardware inventory metadata of a device
+type Change struct {
+	Table      string    `json:"table"`
+	DeviceID   string    `json:"deviceId"`
+	ObjectKey  string    `json:"objectKey"`
+	ChangeType string    `json:"changeType"`
+	Attribute  *string   `json:"attribute,omitempty"`
+	OldValue   *string   `json:"oldValue,omitempty"`
+	NewValue   *string   `json:"newValue,omitempty"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+// Interval between two purges of the changes older than the history retention
+const historyPurgeInterval = time.Hour
+
+// Timeout of the purge of a history table, deleting the backlog of changes may take longer than a regular query
+const historyPurgeTimeout = 5 * time.Minute
+
+// historyTables lists the metadata history tables
+var historyTables = []string{"interface_meta_history", "bgp_neighbors_meta_history", "device_hw_info_history"}
+
+// sqlPurgeHistory deletes the changes of a history table recorded before an epoch
+const sqlPurgeHistory = "DELETE FROM %v WHERE changed_at < $1"
+
+// sqlListChanges returns the changes of a device between two epochs
+const sqlListChanges = `SELECT * FROM (
+	SELECT 'interface_meta' AS source_table, change_id, device_id, object_key, change_type,
+	attribute, old_value, new_value, changed_at
+	FROM interface_meta_history
+	WHERE device_id = $1 AND changed_at BETWEEN $2 AND $3
+	UNION ALL
+	SELECT 'bgp_neighbors_meta', change_id, device_id, object_key, change_type,
+	attribute, old_value, new_value, changed_at
+	FROM bgp_neighbors_meta_history
+	WHERE device_id = $1 AND changed_at BETWEEN $2 AND $3
+	UNION ALL
+	SELECT 'device_hw_info', change_id, device_id, object_key, change_type,
+	attribute, old_value, new_value, changed_at
+	FROM device_hw_info_history
+	WHERE device_id = $1 AND changed_at BETWEEN $2 AND $3
+) AS changes
+ORDER BY changed_at, source_table, change_id`
+
+// ListChanges returns the metadata changes recorded for the node between from and to included
+func (r metaReader) ListChanges(ctx context.Context, node string, from, to time.Time) ([]Change, error) {
+
+	ctxTimeout, cancelQuery := context.WithTimeout(ctx, shortQueryTimeout)
+
+	defer cancelQuery()
+
+	rows, err := r.query(ctxTimeout, sqlListChanges, node, from.Unix(), to.Unix())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// scanChanges reads the changes returned by the history query
+func scanChanges(rows metaRows) ([]Change, error) {
+
+	changes := make([]Change, 0)
+
+	for rows.Next() {
+
+		var (
+			c         Change
+			changeID  int64
+			changedAt int64
+		)
+
+		err := rows.Scan(
+			&c.Table,
+			&changeID,
+			&c.DeviceID,
+			&c.ObjectKey,
+			&c.ChangeType,
+			&c.Attribute,
+			&c.OldValue,
+			&c.NewValue,
+			&changedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.ChangedAt = time.Unix(changedAt, 0).UTC()
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+// purgeHistory deletes the changes older than the retention from the history tables, once on start and then every
+// historyPurgeInterval until the context is cancelled
+func purgeHistory(ctx context.Context, s Store, retention time.Duration) {
+
+	ticker := time.NewTicker(historyPurgeInterval)
+
+	defer ticker.Stop()
+
+	for {
+		before := time.Now().Add(-retention)
+
+		ctxTimeout, cancelPurge := context.WithTimeout(ctx, historyPurgeTimeout)
+
+		purged, err := s.PurgeHistory(ctxTimeout, before)
+
+		cancelPurge()
+
+		if err != nil && ctx.Err() == nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to purge the metadata history changes recorded before %v: %v", before.UTC(), err)
+		}
+
+		if purged > 0 {
+			logging.PeppaMonLog(
+				"info",
+				"Purged %v metadata history change(s) recorded before %v", purged, before.UTC())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeHistoryStatements returns the statements deleting the changes of each history table
+func purgeHistoryStatements() []string {
+
+	statements := make([]string, 0, len(historyTables))
+
+	for _, table := range historyTables {
+		statements = append(statements, fmt.Sprintf(sqlPurgeHistory, table))
+	}
+
+	return statements
+}
@@ -0,0 +1,81 @@
+package metadb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+)
+
+func TestSQLitePurgeHistory(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "peppamon-metadb")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+
+	s, err := openSQLiteStore(ctx, config.MetaDB{SQLitePath: filepath.Join(dir, "metadata.db")})
+
+	if err != nil {
+		t.Fatalf("openSQLiteStore() error = %v", err)
+	}
+	defer s.Close()
+
+	db := s.(*sqliteStore).db
+	now := time.Now()
+
+	for _, table := range historyTables {
+		for _, age := range []time.Duration{48 * time.Hour, 25 * time.Hour, time.Hour} {
+
+			_, err := db.ExecContext(ctx,
+				"INSERT INTO "+table+" (device_id, object_key, change_type, changed_at) VALUES (?, ?, ?, ?)",
+				"r1", "key", ChangeAdded, now.Add(-age).Unix())
+
+			if err != nil {
+				t.Fatalf("insert into %v error = %v", table, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name       string
+		before     time.Time
+		wantPurged int64
+		wantKept   int
+	}{
+		{name: "nothing older", before: now.Add(-72 * time.Hour), wantPurged: 0, wantKept: 3},
+		{name: "older changes", before: now.Add(-24 * time.Hour), wantPurged: 6, wantKept: 1},
+		{name: "already purged", before: now.Add(-24 * time.Hour), wantPurged: 0, wantKept: 1},
+	}
+
+	for _, tt := range tests {
+
+		purged, err := s.PurgeHistory(ctx, tt.before)
+
+		if err != nil {
+			t.Fatalf("%v: PurgeHistory() error = %v", tt.name, err)
+		}
+
+		if purged != tt.wantPurged {
+			t.Errorf("%v: PurgeHistory() = %v, want %v", tt.name, purged, tt.wantPurged)
+		}
+
+		changes, err := s.ListChanges(ctx, "r1", now.Add(-72*time.Hour), now)
+
+		if err != nil {
+			t.Fatalf("%v: ListChanges() error = %v", tt.name, err)
+		}
+
+		if len(changes) != tt.wantKept*len(historyTables) {
+			t.Errorf("%v: %v changes kept, want %v per table", tt.name, len(changes), tt.wantKept)
+		}
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 )
 
 // PersistsInterfaceMetadata will update the Telemetry Metadata database with interfaces attributes
-func (p *peppamonMetaDB) PersistsInterfaceMetadata(ifMeta []map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsInterfaceMetadata(ifMeta []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("interface_meta", time.Now(), &err)
 
 	// Sanitize Data First
 	// Ensure Telemetry data from device and DB are in sync
@@ -0,0 +1,369 @@
+package metadb
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrDeviceNotFound is returned when no metadata is recorded for the device
+var ErrDeviceNotFound = errors.New("device not found")
+
+// Device represents the system, software and license data of a device
+type Device struct {
+	DeviceID     string     `json:"deviceId"`
+	SWVersion    string     `json:"swVersion"`
+	BootTime     *time.Time `json:"bootTime,omitempty"`
+	LastSeen     *time.Time `json:"lastSeen,omitempty"`
+	ProductID    string     `json:"productId"`
+	SerialNumber string     `json:"serialNumber"`
+	BootLicense  string     `json:"bootLicense"`
+}
+
+// Interface represents the metadata of a device interface.
+// Admin and operational status are 100 when up and 0 otherwise, as exported in the interface metrics.
+type Interface struct {
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	IPv4Address      string    `json:"ipv4Address"`
+	AdminStatus      int64     `json:"adminStatus"`
+	OperStatus       int64     `json:"operStatus"`
+	Speed            int64     `json:"speed"`
+	MTU              int64     `json:"mtu"`
+	PhysicalAddress  string    `json:"physicalAddress"`
+	VRF              string    `json:"vrf"`
+	LastStatusChange string    `json:"lastStatusChange"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// BgpPeer represents the metadata of a BGP neighbor per address family
+type BgpPeer struct {
+	NeighborID        string    `json:"neighborId"`
+	AddressFamilyType string    `json:"addressFamilyType"`
+	AddressFamilyVRF  string    `json:"addressFamilyVrf"`
+	Status            int64     `json:"status"`
+	Uptime            string    `json:"uptime"`
+	RemoteAS          int64     `json:"remoteAs"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// HardwareComponent represents a component of the device hardware inventory
+type HardwareComponent struct {
+	Type             string    `json:"type"`
+	PartNumber       string    `json:"partNumber"`
+	SerialNumber     string    `json:"serialNumber"`
+	Description      string    `json:"description"`
+	Name             string    `json:"name"`
+	FieldReplaceable bool      `json:"fieldReplaceable"`
+	Version          string    `json:"version"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// IPSlaEntry represents the configuration of an IP SLA operation
+type IPSlaEntry struct {
+	EntryID         int64     `json:"entryId"`
+	Type            string    `json:"type"`
+	DestinationIP   string    `json:"destinationIp"`
+	DestinationPort int64     `json:"destinationPort"`
+	DestinationHost string    `json:"destinationHost"`
+	SourceIP        string    `json:"sourceIp"`
+	SourcePort      int64     `json:"sourcePort"`
+	VRF             string    `json:"vrf"`
+	Frequency       int64     `json:"frequency"`
+	DSCP            string    `json:"dscp"`
+	ClassOfService  string    `json:"classOfService"`
+	ReqDataSize     int64     `json:"reqDataSize"`
+	HTTPURL         string    `json:"httpUrl"`
+	HTTPVersion     string    `json:"httpVersion"`
+	HTTPProxy       string    `json:"httpProxy"`
+	HTTPDNSServer   string    `json:"httpDnsServer"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+var (
+	// Devices are the nodes with any metadata recorded
+	devicesResource = listResource{
+		columns: `d.device_id, COALESCE(s.sw_version, ''), COALESCE(s.boot_time_epoch, 0),
+		COALESCE(s.last_seen_epoch, 0), COALESCE(l.product_id, ''), COALESCE(l.serial_number, ''),
+		COALESCE(l.boot_license, '')`,
+		from: `(
+			SELECT device_id FROM device_sys_data
+			UNION SELECT device_id FROM device_license_meta
+			UNION SELECT device_id FROM device_hw_info
+			UNION SELECT device_id FROM interface_meta
+			UNION SELECT device_id FROM bgp_neighbors_meta
+			UNION SELECT device_id FROM bgp_afi_meta
+			UNION SELECT device_id FROM ip_sla_config_meta
+			UNION SELECT device_id FROM cpu_processes_meta
+			UNION SELECT device_id FROM mem_processes_meta
+		) AS d
+		LEFT JOIN device_sys_data s ON s.device_id = d.device_id
+		LEFT JOIN device_license_meta l ON l.device_id = d.device_id`,
+		orderBy:    "d.device_id",
+		nodeColumn: "d.device_id",
+		filters: map[string]listFilter{
+			"swVersion":    {column: "s.sw_version"},
+			"productId":    {column: "l.product_id"},
+			"serialNumber": {column: "l.serial_number"},
+			"bootLicense":  {column: "l.boot_license"},
+		},
+	}
+	interfacesResource = listResource{
+		columns: `interface_name, COALESCE(description, ''), COALESCE(CAST(ipv4_address AS TEXT), ''),
+		COALESCE(admin_status, 0), COALESCE(oper_status, 0), COALESCE(speed, 0), COALESCE(mtu, 0),
+		COALESCE(physical_address, ''), COALESCE(vrf_attached, ''), COALESCE(last_status_change, ''), timestamps`,
+		from:       "interface_meta",
+		orderBy:    "interface_name",
+		nodeColumn: "device_id",
+		filters: map[string]listFilter{
+			"name":        {column: "interface_name"},
+			"adminStatus": {column: "admin_status", numeric: true},
+			"operStatus":  {column: "oper_status", numeric: true},
+			"vrf":         {column: "vrf_attached"},
+		},
+	}
+	bgpPeersResource = listResource{
+		columns: `neighbor_id, address_family_type, address_family_vrf, COALESCE(neighbor_status, 0),
+		COALESCE(uptime, ''), COALESCE(remote_as, 0), timestamps`,
+		from:       "bgp_neighbors_meta",
+		orderBy:    "neighbor_id, address_family_type, address_family_vrf",
+		nodeColumn: "device_id",
+		filters: map[string]listFilter{
+			"neighborId":        {column: "neighbor_id"},
+			"addressFamilyType": {column: "address_family_type"},
+			"vrf":               {column: "address_family_vrf"},
+			"status":            {column: "neighbor_status", numeric: true},
+			"remoteAs":          {column: "remote_as", numeric: true},
+		},
+	}
+	hardwareResource = listResource{
+		columns: `hardware_type, hardware_part_number, serial_number, COALESCE(hardware_description, ''),
+		COALESCE(hardware_device_name, ''), COALESCE(hardware_field_replaceable, FALSE),
+		COALESCE(hardware_version, ''), timestamps`,
+		from:       "device_hw_info",
+		orderBy:    "hardware_type, hardware_part_number, serial_number",
+		nodeColumn: "device_id",
+		filters: map[string]listFilter{
+			"type":         {column: "hardware_type"},
+			"partNumber":   {column: "hardware_part_number"},
+			"serialNumber": {column: "serial_number"},
+		},
+	}
+	ipSlaResource = listResource{
+		columns: `entry_id, COALESCE(type, ''), COALESCE(destination_ip, ''), COALESCE(destination_port, 0),
+		COALESCE(destination_host, ''), COALESCE(source_ip, ''), COALESCE(source_port, 0), COALESCE(vrf, ''),
+		COALESCE(frequency, 0), COALESCE(dscp, ''), COALESCE(class_of_service, ''), COALESCE(req_data_size, 0),
+		COALESCE(http_url, ''), COALESCE(http_version, ''), COALESCE(http_proxy, ''),
+		COALESCE(http_dns_server, ''), timestamps`,
+		from:       "ip_sla_config_meta",
+		orderBy:    "entry_id",
+		nodeColumn: "device_id",
+		filters: map[string]listFilter{
+			"entryId":       {column: "entry_id", numeric: true},
+			"type":          {column: "type"},
+			"destinationIp": {column: "destination_ip"},
+			"vrf":           {column: "vrf"},
+		},
+	}
+)
+
+// ListDevices returns a page of the devices matching the query and the total number of matching devices
+func (r metaReader) ListDevices(ctx context.Context, q ListQuery) ([]Device, int, error) {
+
+	devices := make([]Device, 0)
+
+	total, err := r.list(ctx, devicesResource, "", q, func(rows metaRows) error {
+
+		d, err := scanDevice(rows)
+
+		if err != nil {
+			return err
+		}
+
+		devices = append(devices, d)
+
+		return nil
+	})
+
+	return devices, total, err
+}
+
+// GetDevice returns the system, software and license data of the node
+func (r metaReader) GetDevice(ctx context.Context, node string) (*Device, error) {
+
+	var device *Device
+
+	_, err := r.list(ctx, devicesResource, node, ListQuery{Limit: 1}, func(rows metaRows) error {
+
+		d, err := scanDevice(rows)
+
+		if err != nil {
+			return err
+		}
+
+		device = &d
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if device == nil {
+		return nil, ErrDeviceNotFound
+	}
+
+	return device, nil
+}
+
+// scanDevice reads a device returned by the devices listing
+func scanDevice(rows metaRows) (Device, error) {
+
+	var (
+		d                  Device
+		bootTime, lastSeen int64
+	)
+
+	err := rows.Scan(&d.DeviceID, &d.SWVersion, &bootTime, &lastSeen, &d.ProductID, &d.SerialNumber, &d.BootLicense)
+
+	if err != nil {
+		return d, err
+	}
+
+	d.BootTime = epochTime(bootTime)
+	d.LastSeen = epochTime(lastSeen)
+
+	return d, nil
+}
+
+// ListInterfaces returns a page of the node interfaces matching the query and the total number of matching interfaces
+func (r metaReader) ListInterfaces(ctx context.Context, node string, q ListQuery) ([]Interface, int, error) {
+
+	interfaces := make([]Interface, 0)
+
+	total, err := r.list(ctx, interfacesResource, node, q, func(rows metaRows) error {
+
+		var (
+			i         Interface
+			updatedAt int64
+		)
+
+		err := rows.Scan(&i.Name, &i.Description, &i.IPv4Address, &i.AdminStatus, &i.OperStatus, &i.Speed, &i.MTU,
+			&i.PhysicalAddress, &i.VRF, &i.LastStatusChange, &updatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		i.UpdatedAt = time.Unix(updatedAt, 0).UTC()
+
+		interfaces = append(interfaces, i)
+
+		return nil
+	})
+
+	return interfaces, total, err
+}
+
+// ListBgpPeers returns a page of the node BGP neighbors matching the query and the total number of matching neighbors
+func (r metaReader) ListBgpPeers(ctx context.Context, node string, q ListQuery) ([]BgpPeer, int, error) {
+
+	peers := make([]BgpPeer, 0)
+
+	total, err := r.list(ctx, bgpPeersResource, node, q, func(rows metaRows) error {
+
+		var (
+			p         BgpPeer
+			updatedAt int64
+		)
+
+		err := rows.Scan(&p.NeighborID, &p.AddressFamilyType, &p.AddressFamilyVRF, &p.Status, &p.Uptime, &p.RemoteAS,
+			&updatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		p.UpdatedAt = time.Unix(updatedAt, 0).UTC()
+
+		peers = append(peers, p)
+
+		return nil
+	})
+
+	return peers, total, err
+}
+
+// ListHardware returns a page of the node hardware components matching the query and the total number of
+// matching components
+func (r metaReader) ListHardware(ctx context.Context, node string, q ListQuery) ([]HardwareComponent, int, error) {
+
+	components := make([]HardwareComponent, 0)
+
+	total, err := r.list(ctx, hardwareResource, node, q, func(rows metaRows) error {
+
+		var (
+			h         HardwareComponent
+			updatedAt int64
+		)
+
+		err := rows.Scan(&h.Type, &h.PartNumber, &h.SerialNumber, &h.Description, &h.Name, &h.FieldReplaceable,
+			&h.Version, &updatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		h.UpdatedAt = time.Unix(updatedAt, 0).UTC()
+
+		components = append(components, h)
+
+		return nil
+	})
+
+	return components, total, err
+}
+
+// ListIPSlaEntries returns a page of the node IP SLA operations matching the query and the total number of
+// matching operations
+func (r metaReader) ListIPSlaEntries(ctx context.Context, node string, q ListQuery) ([]IPSlaEntry, int, error) {
+
+	entries := make([]IPSlaEntry, 0)
+
+	total, err := r.list(ctx, ipSlaResource, node, q, func(rows metaRows) error {
+
+		var (
+			e         IPSlaEntry
+			updatedAt int64
+		)
+
+		err := rows.Scan(&e.EntryID, &e.Type, &e.DestinationIP, &e.DestinationPort, &e.DestinationHost, &e.SourceIP,
+			&e.SourcePort, &e.VRF, &e.Frequency, &e.DSCP, &e.ClassOfService, &e.ReqDataSize, &e.HTTPURL,
+			&e.HTTPVersion, &e.HTTPProxy, &e.HTTPDNSServer, &updatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		e.UpdatedAt = time.Unix(updatedAt, 0).UTC()
+
+		entries = append(entries, e)
+
+		return nil
+	})
+
+	return entries, total, err
+}
+
+// epochTime converts the epoch seconds reported by the device, zero when unknown
+func epochTime(epoch int64) *time.Time {
+
+	if epoch == 0 {
+		return nil
+	}
+
+	t := time.Unix(epoch, 0).UTC()
+
+	return &t
+}
@@ -3,12 +3,15 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 )
 
-func (p *peppamonMetaDB) PersistsIPSlaConfigMetadata(ipSLAMeta []map[string]interface{}, node string) error {
+func (p *peppamonMetaDB) PersistsIPSlaConfigMetadata(ipSLAMeta []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("ip_sla_config_meta", time.Now(), &err)
 
 	// Sanitize Data First
 	// Ensure Telemetry data from device and DB are in sync
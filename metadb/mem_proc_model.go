@@ -3,13 +3,16 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 )
 
 // PersistsMemProcMetadata will save the processes memory utilization in the Telemetry Meta DB
-func (p *peppamonMetaDB) PersistsMemProcMetadata(memProc []map[string]interface{}) error {
+func (p *peppamonMetaDB) PersistsMemProcMetadata(memProc []map[string]interface{}) (err error) {
+
+	defer observeWrite("mem_processes_meta", time.Now(), &err)
 
 	// Set Query timeout
 	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
@@ -1,141 +1,120 @@
-// Package metadbdb handles connection and SQL queries to Telemetry Metadata Postgres DB
+// Package metadbdb handles connection and SQL queries to Telemetry Metadata DB
 package metadb
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"net"
-	"os"
+	"errors"
 	"sort"
+	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v4/pgxpool"
-
-	"github.com/lucabrasi83/peppamon_cisco/initializer"
+	"github.com/lucabrasi83/peppamon_cisco/config"
 	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/selfmetrics"
 )
 
-// ConnPool represents the Connection Pool instance
-// db represents an instance of Postgres connection pool
-var ConnPool *pgxpool.Pool
-var DBInstance *peppamonMetaDB
-
-const (
-	shortQueryTimeout = 10 * time.Second
-	//mediumQueryTimeout = 3 * time.Minute
-	//longQueryTimeout   = 10 * time.Minute
+// Storage backend, nil until the Telemetry metadata database is connected
+var (
+	mu    = &sync.RWMutex{}
+	store Store
 )
 
-type peppamonMetaDB struct {
-	db *pgxpool.Pool
-}
-
-// init() function will establish DB connection pool while package is being loaded.
-func init() {
+// errNotConnected is returned while the Telemetry metadata database is disabled or not yet connected
+var errNotConnected = errors.New("metadata database not connected")
 
-	// Initializer Banner and binary metadata
-	initializer.Initialize()
+// Query timeout set from the configuration
+var shortQueryTimeout = 10 * time.Second
 
-	// Check Environment Variables for Postgres DB Credentials
-	if os.Getenv("PEPPAMON_METADB_USERNAME") == "" || os.Getenv("PEPPAMON_METADB_PASSWORD") == "" {
-		logging.PeppaMonLog("fatal",
-			"Missing Environment Variable(s) for PostgresDB Connection not set ",
-			"(PEPPAMON_METADB_USERNAME / PEPPAMON_METADB_PASSWORD)")
-	}
+//const (
+//	mediumQueryTimeout = 3 * time.Minute
+//	longQueryTimeout   = 10 * time.Minute
+//)
 
-	// Check Environment Variables for Postgres Hostname
-	if os.Getenv("PEPPAMON_METADB_HOST") == "" {
-		logging.PeppaMonLog("fatal",
-			"Missing Environment Variable for PostgresDB Hostname ",
-			"PEPPAMON_METADB_HOST")
-	}
+// Start connects the Telemetry metadata database when enabled in the configuration.
+// When the database cannot be reached, the connection is retried in the background until the context is done
+// while the metadata persistence is skipped.
+func Start(ctx context.Context, cfg config.MetaDB) {
 
-	// Check Environment Variables for Postgres Database Name
-	if os.Getenv("PEPPAMON_METADB_DATABASE_NAME") == "" {
-		logging.PeppaMonLog("fatal",
-			"Missing Environment Variable for PostgresDB Database Name ",
-			"PEPPAMON_METADB_DATABASE_NAME")
+	if !cfg.Enabled() {
+		logging.PeppaMonLog("info", "Telemetry metadata database disabled. Metadata persistence skipped")
+		return
 	}
 
-	// Create a certificate pool from the system certificate authority
-	certPool, _ := x509.SystemCertPool()
+	shortQueryTimeout = cfg.QueryTimeout
 
-	var err error
+	if err := connect(ctx, cfg); err == nil {
+		return
+	}
 
-	// pgx v4 requires config struct to be generated using ParseConfig method
-	poolConfig, errParsePool := pgxpool.ParseConfig("")
+	go func() {
 
-	if errParsePool != nil {
-		logging.PeppaMonLog("fatal", "failed to parse DB pool config %v", errParsePool)
-	}
+		t := time.NewTicker(cfg.RetryInterval)
+		defer t.Stop()
 
-	// Set Connection Parameters
-	poolConfig.MaxConns = 50
-	poolConfig.HealthCheckPeriod = 1 * time.Minute
-	poolConfig.ConnConfig.Host = os.Getenv("PEPPAMON_METADB_HOST")
-	poolConfig.ConnConfig.User = os.Getenv("PEPPAMON_METADB_USERNAME")
-	poolConfig.ConnConfig.Password = os.Getenv("PEPPAMON_METADB_PASSWORD")
-	poolConfig.ConnConfig.Database = os.Getenv("PEPPAMON_METADB_DATABASE_NAME")
-
-	poolConfig.ConnConfig.TLSConfig =
-		&tls.Config{
-			ServerName:         os.Getenv("PEPPAMON_METADB_HOST"),
-			RootCAs:            certPool,
-			InsecureSkipVerify: true,
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := connect(ctx, cfg); err == nil {
+					return
+				}
+			}
 		}
+	}()
+}
 
-	poolConfig.ConnConfig.DialFunc =
-		(&net.Dialer{
-			KeepAlive: 30 * time.Second,
-			Timeout:   1 * time.Minute,
-		}).DialContext
+// Instance returns the Telemetry metadata store or nil when the database is disabled or not yet connected.
+// Writes are queued and persisted in the background.
+func Instance() Store {
 
-	ConnPool, err = pgxpool.ConnectConfig(context.Background(), poolConfig)
+	mu.RLock()
+	defer mu.RUnlock()
 
-	if err != nil {
-		logging.PeppaMonLog(
-			"fatal",
-			"Unable to Create Postgres Connection Pool: %v", err)
-	} else {
-		logging.PeppaMonLog("info", "Database Connection Pool successfully created")
-	}
-
-	// Instantiate DB object after successful connection
-	DBInstance = newDBPool(ConnPool)
+	return store
+}
 
-	postgresVersion := DBInstance.displayPostgresVersion()
+// Close flushes the queued writes and releases the Telemetry metadata store connections
+func Close() {
 
-	logging.PeppaMonLog("info", "Postgres SQL Version: %v", postgresVersion)
+	mu.Lock()
+	defer mu.Unlock()
 
+	if store != nil {
+		store.Close()
+	}
+	store = nil
 }
 
-func newDBPool(pool *pgxpool.Pool) *peppamonMetaDB {
+// Ping checks the Telemetry metadata store can reach the database
+func Ping(ctx context.Context) error {
 
-	return &peppamonMetaDB{
-		db: pool,
-	}
-}
+	s := Instance()
 
-func (p *peppamonMetaDB) displayPostgresVersion() string {
-	var version string
+	if s == nil {
+		return errNotConnected
+	}
 
-	// Set Query timeout
-	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
+	return s.Ping(ctx)
+}
 
-	defer cancelQuery()
+// connect opens the Telemetry metadata store
+func connect(ctx context.Context, cfg config.MetaDB) error {
 
-	err := p.db.QueryRow(ctxTimeout, "SELECT version()").Scan(&version)
+	s, err := openStore(ctx, cfg)
 
 	if err != nil {
 		logging.PeppaMonLog(
-			"error",
-			"Failed to retrieve Postgres Version: ",
-			err.Error())
+			"warning",
+			"Unable to open %v metadata database, retrying in %v: %v", cfg.Backend, cfg.RetryInterval, err)
+		return err
 	}
 
-	return version
+	mu.Lock()
+	store = newQueuedStore(s, cfg)
+	mu.Unlock()
 
+	return nil
 }
 
 // normalizeString is a helper function that converts empty string to nil pointer.
@@ -157,3 +136,8 @@ func binarySearchSanitizeDB(indexes []int, item int) bool {
 	}
 	return false
 }
+
+// observeWrite records the duration and result of a Telemetry metadata write in the self-metrics
+func observeWrite(table string, start time.Time, err *error) {
+	selfmetrics.ObserveMetaDB(table, time.Since(start), *err)
+}
@@ -0,0 +1,354 @@
+package metadb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// Postgres advisory lock key serializing the schema migrations of concurrent collector instances
+const migrationLockKey = 0x70657070616d6f6e
+
+// Timeout of the schema migrations
+const migrationTimeout = 5 * time.Minute
+
+const sqlCreateMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// MigrationStatus represents the state of a Telemetry metadata schema migration
+type MigrationStatus struct {
+	Version int
+	Name    string
+
+	// Zero while the migration is pending
+	AppliedAt time.Time
+
+	// Set when the migration was applied by a more recent Peppamon version
+	Unknown bool
+}
+
+// Applied returns whether the migration is applied to the database
+func (s MigrationStatus) Applied() bool {
+	return !s.AppliedAt.IsZero()
+}
+
+// Migrator applies the Telemetry metadata schema migrations
+type Migrator struct {
+	db *pgxpool.Pool
+}
+
+// querier represents a Postgres connection or pool
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// NewMigrator will create a new instance of the schema migrator connected to the Telemetry metadata database
+func NewMigrator(ctx context.Context, cfg config.MetaDB) (*Migrator, error) {
+
+	pool, err := newPool(ctx, cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: pool}, nil
+}
+
+// Close releases the migrator Postgres connection pool
+func (m *Migrator) Close() {
+	m.db.Close()
+}
+
+// Status returns the state of the known and applied migrations sorted by version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	return migrationStatus(ctx, m.db)
+}
+
+// Up applies the pending migrations and returns them
+func (m *Migrator) Up(ctx context.Context) ([]MigrationStatus, error) {
+
+	var done []MigrationStatus
+
+	err := withMigrationLock(ctx, m.db, func(conn *pgxpool.Conn) error {
+
+		statuses, err := migrationStatus(ctx, conn)
+
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+
+			if s.Applied() {
+				continue
+			}
+
+			mig := findMigration(s.Version)
+
+			if err := applyMigration(ctx, conn, mig.up,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", mig.version, mig.name); err != nil {
+				return fmt.Errorf("migration %v %v failed: %v", mig.version, mig.name, err)
+			}
+
+			s.AppliedAt = time.Now()
+			done = append(done, s)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverts the given number of most recent applied migrations and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]MigrationStatus, error) {
+
+	var done []MigrationStatus
+
+	err := withMigrationLock(ctx, m.db, func(conn *pgxpool.Conn) error {
+
+		statuses, err := migrationStatus(ctx, conn)
+
+		if err != nil {
+			return err
+		}
+
+		for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+
+			s := statuses[i]
+
+			if !s.Applied() {
+				continue
+			}
+
+			if s.Unknown {
+				return fmt.Errorf("migration %v %v was applied by a more recent Peppamon version", s.Version, s.Name)
+			}
+
+			mig := findMigration(s.Version)
+
+			if err := applyMigration(ctx, conn, mig.down,
+				"DELETE FROM schema_migrations WHERE version = $1", mig.version); err != nil {
+				return fmt.Errorf("revert of migration %v %v failed: %v", mig.version, mig.name, err)
+			}
+
+			done = append(done, s)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// checkSchema verifies the Telemetry metadata schema is up to date when the collector connects to the database.
+// Pending migrations are applied when the automatic migration is enabled.
+func checkSchema(ctx context.Context, pool *pgxpool.Pool, autoMigrate bool) {
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, migrationTimeout)
+	defer cancel()
+
+	if autoMigrate {
+
+		applied, err := (&Migrator{db: pool}).Up(ctxTimeout)
+
+		for _, s := range applied {
+			logging.PeppaMonLog("info", "Applied metadata schema migration %v %v", s.Version, s.Name)
+		}
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to migrate the metadata database schema: %v", err)
+		}
+	}
+
+	statuses, err := migrationStatus(ctxTimeout, pool)
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to check the metadata database schema: %v", err)
+		return
+	}
+
+	var pending, unknown int
+
+	for _, s := range statuses {
+		switch {
+		case s.Unknown:
+			unknown++
+		case !s.Applied():
+			pending++
+		}
+	}
+
+	if pending > 0 {
+		logging.PeppaMonLog(
+			"error",
+			"Metadata database schema is missing %v migration(s). Run peppamon migrate up", pending)
+	}
+
+	if unknown > 0 {
+		logging.PeppaMonLog(
+			"warning",
+			"Metadata database schema has %v migration(s) applied by a more recent Peppamon version", unknown)
+	}
+}
+
+// migrationStatus merges the known migrations with those recorded in the database
+func migrationStatus(ctx context.Context, q querier) ([]MigrationStatus, error) {
+
+	applied, err := appliedMigrations(ctx, q)
+
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+
+	for _, mig := range migrations {
+
+		s := MigrationStatus{Version: mig.version, Name: mig.name}
+
+		if a, ok := applied[mig.version]; ok {
+			s.AppliedAt = a.AppliedAt
+			delete(applied, mig.version)
+		}
+		statuses = append(statuses, s)
+	}
+
+	for _, a := range applied {
+		a.Unknown = true
+		statuses = append(statuses, a)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// appliedMigrations returns the migrations recorded in the database by version
+func appliedMigrations(ctx context.Context, q querier) (map[int]MigrationStatus, error) {
+
+	applied := make(map[int]MigrationStatus)
+
+	// Nothing applied yet when the migrations table does not exist
+	rows, err := q.Query(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var exists bool
+
+	for rows.Next() {
+		if err := rows.Scan(&exists); err != nil {
+			rows.Close()
+			return nil, err
+		}
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err = q.Query(ctx, "SELECT version, name, applied_at FROM schema_migrations")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var s MigrationStatus
+
+		if err := rows.Scan(&s.Version, &s.Name, &s.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[s.Version] = s
+	}
+
+	return applied, rows.Err()
+}
+
+// applyMigration runs the migration SQL and records it in the migrations table within a transaction
+func applyMigration(ctx context.Context, conn *pgxpool.Conn, sql string, record string, args ...interface{}) error {
+
+	tx, err := conn.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// withMigrationLock runs the function on a connection holding the migrations advisory lock
+func withMigrationLock(ctx context.Context, pool *pgxpool.Pool, f func(conn *pgxpool.Conn) error) error {
+
+	conn, err := pool.Acquire(ctx)
+
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", int64(migrationLockKey)); err != nil {
+		return err
+	}
+
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", int64(migrationLockKey)); err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to release the metadata schema migrations lock %v", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, sqlCreateMigrationsTable); err != nil {
+		return err
+	}
+
+	return f(conn)
+}
+
+func findMigration(version int) migration {
+
+	for _, mig := range migrations {
+		if mig.version == version {
+			return mig
+		}
+	}
+
+	return migration{}
+}
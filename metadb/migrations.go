@@ -0,0 +1,414 @@
+package metadb
+
+// migration represents a versioned change of the Telemetry metadata schema
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+
+	// SQLite schema change when the Postgres SQL is not compatible
+	sqlite string
+}
+
+// sqliteUp returns the SQL applying the migration to the SQLite database
+func (m migration) sqliteUp() string {
+
+	if m.sqlite != "" {
+		return m.sqlite
+	}
+
+	return m.up
+}
+
+// migrations is the ordered list of the Telemetry metadata schema changes. Applied migrations must never be modified,
+// schema changes are appended as new versions.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create_metadata_tables",
+		// Tables are created only when missing so databases provisioned by hand are adopted as is.
+		// The statements are also valid SQLite.
+		up: `
+CREATE TABLE IF NOT EXISTS interface_meta (
+	device_id          TEXT NOT NULL,
+	timestamps         BIGINT NOT NULL,
+	interface_name     TEXT NOT NULL,
+	description        TEXT,
+	ipv4_address       INET,
+	admin_status       SMALLINT,
+	oper_status        SMALLINT,
+	speed              BIGINT,
+	mtu                BIGINT,
+	physical_address   TEXT,
+	vrf_attached       TEXT,
+	last_status_change TEXT,
+	PRIMARY KEY (device_id, interface_name)
+);
+
+CREATE TABLE IF NOT EXISTS bgp_neighbors_meta (
+	device_id           TEXT NOT NULL,
+	neighbor_id         TEXT NOT NULL,
+	address_family_type TEXT NOT NULL,
+	address_family_vrf  TEXT NOT NULL,
+	timestamps          BIGINT NOT NULL,
+	neighbor_status     SMALLINT,
+	uptime              TEXT,
+	remote_as           BIGINT,
+	PRIMARY KEY (device_id, neighbor_id, address_family_type, address_family_vrf)
+);
+
+CREATE TABLE IF NOT EXISTS bgp_afi_meta (
+	device_id      TEXT NOT NULL,
+	afi_type       TEXT NOT NULL,
+	vrf_name       TEXT NOT NULL,
+	timestamps     BIGINT NOT NULL,
+	total_prefixes BIGINT,
+	total_paths    BIGINT,
+	PRIMARY KEY (device_id, afi_type, vrf_name)
+);
+
+CREATE TABLE IF NOT EXISTS cpu_processes_meta (
+	device_id               TEXT NOT NULL,
+	cpu_process_name        TEXT NOT NULL,
+	timestamps              BIGINT NOT NULL,
+	cpu_process_pid         BIGINT,
+	cpu_proc_avg_runtime    BIGINT,
+	cpu_proc_busy_avg_5_sec DOUBLE PRECISION,
+	cpu_proc_busy_avg_1_min DOUBLE PRECISION,
+	cpu_proc_busy_avg_5_min DOUBLE PRECISION,
+	PRIMARY KEY (device_id, cpu_process_name)
+);
+
+CREATE TABLE IF NOT EXISTS mem_processes_meta (
+	device_id        TEXT NOT NULL,
+	mem_process_name TEXT NOT NULL,
+	timestamps       BIGINT NOT NULL,
+	mem_process_pid  BIGINT,
+	allocated_memory NUMERIC(20),
+	freed_memory     NUMERIC(20),
+	holding_memory   NUMERIC(20),
+	PRIMARY KEY (device_id, mem_process_name)
+);
+
+CREATE TABLE IF NOT EXISTS device_hw_info (
+	device_id                  TEXT NOT NULL,
+	hardware_type              TEXT NOT NULL,
+	hardware_part_number       TEXT NOT NULL,
+	serial_number              TEXT NOT NULL,
+	timestamps                 BIGINT NOT NULL,
+	hardware_description       TEXT,
+	hardware_device_name       TEXT,
+	hardware_field_replaceable BOOLEAN,
+	hardware_version           TEXT,
+	PRIMARY KEY (device_id, hardware_type, hardware_part_number, serial_number)
+);
+
+CREATE TABLE IF NOT EXISTS device_sys_data (
+	device_id       TEXT PRIMARY KEY,
+	timestamps      BIGINT NOT NULL,
+	last_seen_epoch BIGINT,
+	boot_time_epoch BIGINT,
+	sw_version      TEXT
+);
+
+CREATE TABLE IF NOT EXISTS device_license_meta (
+	device_id     TEXT PRIMARY KEY,
+	timestamps    BIGINT NOT NULL,
+	product_id    TEXT,
+	serial_number TEXT,
+	boot_license  TEXT
+);
+
+CREATE TABLE IF NOT EXISTS ip_sla_config_meta (
+	device_id        TEXT NOT NULL,
+	entry_id         INTEGER NOT NULL,
+	timestamps       BIGINT NOT NULL,
+	type             TEXT,
+	destination_ip   TEXT,
+	destination_port INTEGER,
+	destination_host TEXT,
+	source_ip        TEXT,
+	source_port      INTEGER,
+	vrf              TEXT,
+	frequency        INTEGER,
+	dscp             TEXT,
+	class_of_service TEXT,
+	req_data_size    INTEGER,
+	http_url         TEXT,
+	http_version     TEXT,
+	http_proxy       TEXT,
+	http_dns_server  TEXT,
+	PRIMARY KEY (device_id, entry_id)
+);
+`,
+		down: `
+DROP TABLE IF EXISTS ip_sla_config_meta;
+DROP TABLE IF EXISTS device_license_meta;
+DROP TABLE IF EXISTS device_sys_data;
+DROP TABLE IF EXISTS device_hw_info;
+DROP TABLE IF EXISTS mem_processes_meta;
+DROP TABLE IF EXISTS cpu_processes_meta;
+DROP TABLE IF EXISTS bgp_afi_meta;
+DROP TABLE IF EXISTS bgp_neighbors_meta;
+DROP TABLE IF EXISTS interface_meta;
+`,
+	},
+	{
+		version: 2,
+		name:    "create_metadata_history",
+		// Changes are recorded by triggers so both the upserts and the sanitisation deletes are tracked.
+		// The object key joins the key columns of the row, the timestamps column and the attributes updated on every
+		// Telemetry message such as the BGP peer uptime are not tracked.
+		up: `
+CREATE TABLE IF NOT EXISTS interface_meta_history (
+	change_id   BIGSERIAL PRIMARY KEY,
+	device_id   TEXT NOT NULL,
+	object_key  TEXT NOT NULL,
+	change_type TEXT NOT NULL,
+	attribute   TEXT,
+	old_value   TEXT,
+	new_value   TEXT,
+	changed_at  BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS interface_meta_history_device_idx ON interface_meta_history (device_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS bgp_neighbors_meta_history (
+	change_id   BIGSERIAL PRIMARY KEY,
+	device_id   TEXT NOT NULL,
+	object_key  TEXT NOT NULL,
+	change_type TEXT NOT NULL,
+	attribute   TEXT,
+	old_value   TEXT,
+	new_value   TEXT,
+	changed_at  BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bgp_neighbors_meta_history_device_idx ON bgp_neighbors_meta_history (device_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS device_hw_info_history (
+	change_id   BIGSERIAL PRIMARY KEY,
+	device_id   TEXT NOT NULL,
+	object_key  TEXT NOT NULL,
+	change_type TEXT NOT NULL,
+	attribute   TEXT,
+	old_value   TEXT,
+	new_value   TEXT,
+	changed_at  BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS device_hw_info_history_device_idx ON device_hw_info_history (device_id, changed_at);
+
+-- Records the row changes in the <table>_history table.
+-- Arguments are the comma separated key columns and the comma separated attributes not tracked.
+CREATE OR REPLACE FUNCTION record_metadata_change() RETURNS trigger AS $$
+DECLARE
+	key_columns TEXT[] := string_to_array(TG_ARGV[0], ',');
+	ignored     TEXT[] := string_to_array(TG_ARGV[1], ',') || ARRAY['device_id', 'timestamps'];
+	changed_row JSONB;
+	obj_key     TEXT;
+	now_epoch   BIGINT := extract(epoch FROM now())::BIGINT;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		changed_row := to_jsonb(OLD);
+	ELSE
+		changed_row := to_jsonb(NEW);
+	END IF;
+
+	SELECT string_agg(changed_row ->> k.col, '/' ORDER BY k.pos)
+	INTO obj_key
+	FROM unnest(key_columns) WITH ORDINALITY AS k(col, pos);
+
+	IF TG_OP = 'UPDATE' THEN
+		EXECUTE format(
+			'INSERT INTO %I (device_id, object_key, change_type, attribute, old_value, new_value, changed_at)
+			 SELECT $1, $2, ''modified'', n.key, o.value, n.value, $3
+			 FROM jsonb_each_text($4) n
+			 JOIN jsonb_each_text($5) o ON o.key = n.key
+			 WHERE n.key <> ALL ($6)
+			 AND n.value IS DISTINCT FROM o.value',
+			TG_TABLE_NAME || '_history')
+		USING changed_row ->> 'device_id', obj_key, now_epoch, changed_row, to_jsonb(OLD), ignored || key_columns;
+	ELSE
+		EXECUTE format(
+			'INSERT INTO %I (device_id, object_key, change_type, changed_at) VALUES ($1, $2, $3, $4)',
+			TG_TABLE_NAME || '_history')
+		USING changed_row ->> 'device_id', obj_key, CASE TG_OP WHEN 'INSERT' THEN 'added' ELSE 'removed' END,
+		now_epoch;
+	END IF;
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS interface_meta_history_trg ON interface_meta;
+CREATE TRIGGER interface_meta_history_trg
+	AFTER INSERT OR UPDATE OR DELETE ON interface_meta
+	FOR EACH ROW EXECUTE PROCEDURE record_metadata_change('interface_name', '');
+
+DROP TRIGGER IF EXISTS bgp_neighbors_meta_history_trg ON bgp_neighbors_meta;
+CREATE TRIGGER bgp_neighbors_meta_history_trg
+	AFTER INSERT OR UPDATE OR DELETE ON bgp_neighbors_meta
+	FOR EACH ROW EXECUTE PROCEDURE record_metadata_change('neighbor_id,address_family_type,address_family_vrf', 'uptime');
+
+DROP TRIGGER IF EXISTS device_hw_info_history_trg ON device_hw_info;
+CREATE TRIGGER device_hw_info_history_trg
+	AFTER INSERT OR UPDATE OR DELETE ON device_hw_info
+	FOR EACH ROW EXECUTE PROCEDURE record_metadata_change('hardware_type,hardware_part_number,serial_number', '');
+`,
+		down: `
+DROP TRIGGER IF EXISTS device_hw_info_history_trg ON device_hw_info;
+DROP TRIGGER IF EXISTS bgp_neighbors_meta_history_trg ON bgp_neighbors_meta;
+DROP TRIGGER IF EXISTS interface_meta_history_trg ON interface_meta;
+DROP FUNCTION IF EXISTS record_metadata_change();
+DROP TABLE IF EXISTS device_hw_info_history;
+DROP TABLE IF EXISTS bgp_neighbors_meta_history;
+DROP TABLE IF EXISTS interface_meta_history;
+`,
+		// SQLite triggers cannot take arguments, each table has its own triggers listing the tracked attributes
+		sqlite: `
+CREATE TABLE IF NOT EXISTS interface_meta_history (
+	change_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+	device_id   TEXT NOT NULL,
+	object_key  TEXT NOT NULL,
+	change_type TEXT NOT NULL,
+	attribute   TEXT,
+	old_value   TEXT,
+	new_value   TEXT,
+	changed_at  BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS interface_meta_history_device_idx ON interface_meta_history (device_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS bgp_neighbors_meta_history (
+	change_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+	device_id   TEXT NOT NULL,
+	object_key  TEXT NOT NULL,
+	change_type TEXT NOT NULL,
+	attribute   TEXT,
+	old_value   TEXT,
+	new_value   TEXT,
+	changed_at  BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bgp_neighbors_meta_history_device_idx ON bgp_neighbors_meta_history (device_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS device_hw_info_history (
+	change_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+	device_id   TEXT NOT NULL,
+	object_key  TEXT NOT NULL,
+	change_type TEXT NOT NULL,
+	attribute   TEXT,
+	old_value   TEXT,
+	new_value   TEXT,
+	changed_at  BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS device_hw_info_history_device_idx ON device_hw_info_history (device_id, changed_at);
+
+CREATE TRIGGER IF NOT EXISTS interface_meta_added AFTER INSERT ON interface_meta
+BEGIN
+	INSERT INTO interface_meta_history (device_id, object_key, change_type, changed_at)
+	VALUES (NEW.device_id, NEW.interface_name, 'added', CAST(strftime('%s', 'now') AS INTEGER));
+END;
+
+CREATE TRIGGER IF NOT EXISTS interface_meta_removed AFTER DELETE ON interface_meta
+BEGIN
+	INSERT INTO interface_meta_history (device_id, object_key, change_type, changed_at)
+	VALUES (OLD.device_id, OLD.interface_name, 'removed', CAST(strftime('%s', 'now') AS INTEGER));
+END;
+
+CREATE TRIGGER IF NOT EXISTS interface_meta_modified AFTER UPDATE ON interface_meta
+BEGIN
+	INSERT INTO interface_meta_history (device_id, object_key, change_type, attribute, old_value, new_value, changed_at)
+	SELECT NEW.device_id, NEW.interface_name, 'modified', attribute, old_value, new_value,
+		CAST(strftime('%s', 'now') AS INTEGER)
+	FROM (
+		SELECT 'description' AS attribute, OLD.description AS old_value, NEW.description AS new_value
+		UNION ALL SELECT 'ipv4_address', OLD.ipv4_address, NEW.ipv4_address
+		UNION ALL SELECT 'admin_status', OLD.admin_status, NEW.admin_status
+		UNION ALL SELECT 'oper_status', OLD.oper_status, NEW.oper_status
+		UNION ALL SELECT 'speed', OLD.speed, NEW.speed
+		UNION ALL SELECT 'mtu', OLD.mtu, NEW.mtu
+		UNION ALL SELECT 'physical_address', OLD.physical_address, NEW.physical_address
+		UNION ALL SELECT 'vrf_attached', OLD.vrf_attached, NEW.vrf_attached
+		UNION ALL SELECT 'last_status_change', OLD.last_status_change, NEW.last_status_change
+	)
+	WHERE old_value IS NOT new_value;
+END;
+
+CREATE TRIGGER IF NOT EXISTS bgp_neighbors_meta_added AFTER INSERT ON bgp_neighbors_meta
+BEGIN
+	INSERT INTO bgp_neighbors_meta_history (device_id, object_key, change_type, changed_at)
+	VALUES (NEW.device_id, NEW.neighbor_id || '/' || NEW.address_family_type || '/' || NEW.address_family_vrf, 'added',
+		CAST(strftime('%s', 'now') AS INTEGER));
+END;
+
+CREATE TRIGGER IF NOT EXISTS bgp_neighbors_meta_removed AFTER DELETE ON bgp_neighbors_meta
+BEGIN
+	INSERT INTO bgp_neighbors_meta_history (device_id, object_key, change_type, changed_at)
+	VALUES (OLD.device_id, OLD.neighbor_id || '/' || OLD.address_family_type || '/' || OLD.address_family_vrf, 'removed',
+		CAST(strftime('%s', 'now') AS INTEGER));
+END;
+
+CREATE TRIGGER IF NOT EXISTS bgp_neighbors_meta_modified AFTER UPDATE ON bgp_neighbors_meta
+BEGIN
+	INSERT INTO bgp_neighbors_meta_history (device_id, object_key, change_type, attribute, old_value, new_value,
+		changed_at)
+	SELECT NEW.device_id, NEW.neighbor_id || '/' || NEW.address_family_type || '/' || NEW.address_family_vrf,
+		'modified', attribute, old_value, new_value, CAST(strftime('%s', 'now') AS INTEGER)
+	FROM (
+		SELECT 'neighbor_status' AS attribute, OLD.neighbor_status AS old_value, NEW.neighbor_status AS new_value
+		UNION ALL SELECT 'remote_as', OLD.remote_as, NEW.remote_as
+	)
+	WHERE old_value IS NOT new_value;
+END;
+
+CREATE TRIGGER IF NOT EXISTS device_hw_info_added AFTER INSERT ON device_hw_info
+BEGIN
+	INSERT INTO device_hw_info_history (device_id, object_key, change_type, changed_at)
+	VALUES (NEW.device_id, NEW.hardware_type || '/' || NEW.hardware_part_number || '/' || NEW.serial_number, 'added',
+		CAST(strftime('%s', 'now') AS INTEGER));
+END;
+
+CREATE TRIGGER IF NOT EXISTS device_hw_info_removed AFTER DELETE ON device_hw_info
+BEGIN
+	INSERT INTO device_hw_info_history (device_id, object_key, change_type, changed_at)
+	VALUES (OLD.device_id, OLD.hardware_type || '/' || OLD.hardware_part_number || '/' || OLD.serial_number, 'removed',
+		CAST(strftime('%s', 'now') AS INTEGER));
+END;
+
+CREATE TRIGGER IF NOT EXISTS device_hw_info_modified AFTER UPDATE ON device_hw_info
+BEGIN
+	INSERT INTO device_hw_info_history (device_id, object_key, change_type, attribute, old_value, new_value, changed_at)
+	SELECT NEW.device_id, NEW.hardware_type || '/' || NEW.hardware_part_number || '/' || NEW.serial_number,
+		'modified', attribute, old_value, new_value, CAST(strftime('%s', 'now') AS INTEGER)
+	FROM (
+		SELECT 'hardware_description' AS attribute, OLD.hardware_description AS old_value,
+			NEW.hardware_description AS new_value
+		UNION ALL SELECT 'hardware_device_name', OLD.hardware_device_name, NEW.hardware_device_name
+		UNION ALL SELECT 'hardware_field_replaceable', OLD.hardware_field_replaceable, NEW.hardware_field_replaceable
+		UNION ALL SELECT 'hardware_version', OLD.hardware_version, NEW.hardware_version
+	)
+	WHERE old_value IS NOT new_value;
+END;
+`,
+	},
+	{
+		version: 3,
+		name:    "index_metadata_history_changed_at",
+		// Index of the retention purge deleting the changes older than a time across all the devices.
+		// The statements are also valid SQLite.
+		up: `
+CREATE INDEX IF NOT EXISTS interface_meta_history_changed_at_idx ON interface_meta_history (changed_at);
+CREATE INDEX IF NOT EXISTS bgp_neighbors_meta_history_changed_at_idx ON bgp_neighbors_meta_history (changed_at);
+CREATE INDEX IF NOT EXISTS device_hw_info_history_changed_at_idx ON device_hw_info_history (changed_at);
+`,
+		down: `
+DROP INDEX IF EXISTS device_hw_info_history_changed_at_idx;
+DROP INDEX IF EXISTS bgp_neighbors_meta_history_changed_at_idx;
+DROP INDEX IF EXISTS interface_meta_history_changed_at_idx;
+`,
+	},
+}
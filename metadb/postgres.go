@@ -0,0 +1,149 @@
+package metadb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// peppamonMetaDB represents the Postgres Telemetry metadata store
+type peppamonMetaDB struct {
+	metaReader
+
+	db *pgxpool.Pool
+}
+
+// openPostgresStore establishes the Postgres connection pool of the Telemetry metadata database
+func openPostgresStore(ctx context.Context, cfg config.MetaDB) (Store, error) {
+
+	pool, err := newPool(ctx, cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logging.PeppaMonLog("info", "Database Connection Pool successfully created")
+
+	// Instantiate DB object after successful connection
+	db := newDBPool(pool)
+
+	postgresVersion := db.displayPostgresVersion()
+
+	logging.PeppaMonLog("info", "Postgres SQL Version: %v", postgresVersion)
+
+	checkSchema(ctx, pool, cfg.AutoMigrate)
+
+	return db, nil
+}
+
+// newPool creates a Postgres connection pool from the configuration
+func newPool(ctx context.Context, cfg config.MetaDB) (*pgxpool.Pool, error) {
+
+	// Create a certificate pool from the system certificate authority
+	certPool, _ := x509.SystemCertPool()
+
+	// pgx v4 requires config struct to be generated using ParseConfig method
+	poolConfig, errParsePool := pgxpool.ParseConfig("")
+
+	if errParsePool != nil {
+		return nil, fmt.Errorf("failed to parse DB pool config %v", errParsePool)
+	}
+
+	// Set Connection Parameters
+	poolConfig.MaxConns = int32(cfg.MaxConns)
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	poolConfig.ConnConfig.Host = cfg.Host
+	poolConfig.ConnConfig.Port = uint16(cfg.Port)
+	poolConfig.ConnConfig.User = cfg.Username
+	poolConfig.ConnConfig.Password = cfg.Password
+	poolConfig.ConnConfig.Database = cfg.Database
+
+	poolConfig.ConnConfig.TLSConfig =
+		&tls.Config{
+			ServerName:         cfg.Host,
+			RootCAs:            certPool,
+			InsecureSkipVerify: cfg.TLSSkipVerify,
+		}
+
+	poolConfig.ConnConfig.DialFunc =
+		(&net.Dialer{
+			KeepAlive: 30 * time.Second,
+			Timeout:   cfg.ConnectTimeout,
+		}).DialContext
+
+	return pgxpool.ConnectConfig(ctx, poolConfig)
+}
+
+func newDBPool(pool *pgxpool.Pool) *peppamonMetaDB {
+
+	return &peppamonMetaDB{
+		metaReader: newPgxReader(pool),
+		db:         pool,
+	}
+}
+
+func (p *peppamonMetaDB) displayPostgresVersion() string {
+	var version string
+
+	// Set Query timeout
+	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
+
+	defer cancelQuery()
+
+	err := p.db.QueryRow(ctxTimeout, "SELECT version()").Scan(&version)
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to retrieve Postgres Version: %v",
+			err)
+	}
+
+	return version
+
+}
+
+// Ping checks a connection of the Postgres pool can reach the database
+func (p *peppamonMetaDB) Ping(ctx context.Context) error {
+
+	conn, err := p.db.Acquire(ctx)
+
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	return conn.Conn().Ping(ctx)
+}
+
+// PurgeHistory deletes the changes recorded before the time from the history tables
+func (p *peppamonMetaDB) PurgeHistory(ctx context.Context, before time.Time) (int64, error) {
+
+	var purged int64
+
+	for _, stmt := range purgeHistoryStatements() {
+
+		tag, err := p.db.Exec(ctx, stmt, before.Unix())
+
+		if err != nil {
+			return purged, err
+		}
+
+		purged += tag.RowsAffected()
+	}
+
+	return purged, nil
+}
+
+// Close releases the Postgres connection pool
+func (p *peppamonMetaDB) Close() {
+	p.db.Close()
+}
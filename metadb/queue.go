@@ -0,0 +1,393 @@
+package metadb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/selfmetrics"
+)
+
+// Upper bound of the delay between two retries of a failed write
+const maxRetryBackoff = time.Minute
+
+// Reasons of the metadata writes dropped by the write queues
+const (
+	dropQueueFull        = "queue_full"
+	dropRetriesExhausted = "retries_exhausted"
+	dropPermanentError   = "permanent_error"
+)
+
+// queuedStore represents the Telemetry metadata store fronted by per-table write-behind queues.
+// The Persists* operations return once the write is queued. Writes dropped because a queue is full are counted
+// and logged once per flush rather than returned to the parsers, so a saturated queue does not flood the log.
+type queuedStore struct {
+	store  Store
+	queues map[string]*writeQueue
+
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// writeQueue represents the pending writes of a metadata table, coalesced per node
+type writeQueue struct {
+	table    string
+	settings config.MetaDBQueue
+
+	mu      *sync.Mutex
+	pending map[string]*queuedWrite
+
+	// Writes dropped because the queue was full since the last flush
+	dropped int
+
+	// Signals the queue reached the flush size
+	flushCh chan struct{}
+}
+
+// queuedWrite represents a pending write of the metadata of a node
+type queuedWrite struct {
+	node  string
+	write func(s Store) error
+
+	attempts  int
+	notBefore time.Time
+}
+
+// newQueuedStore starts the write queues of the metadata tables in front of the store, and the purge of the history
+// changes older than the retention
+func newQueuedStore(s Store, cfg config.MetaDB) *queuedStore {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	q := &queuedStore{
+		store:  s,
+		queues: make(map[string]*writeQueue),
+		cancel: cancel,
+		wg:     &sync.WaitGroup{},
+	}
+
+	for _, table := range []string{
+		"interface_meta", "bgp_neighbors_meta", "bgp_afi_meta", "cpu_processes_meta", "mem_processes_meta",
+		"device_hw_info", "device_sys_data", "device_license_meta", "ip_sla_config_meta",
+	} {
+		wq := &writeQueue{
+			table:    table,
+			settings: cfg.Queue,
+			mu:       &sync.Mutex{},
+			pending:  make(map[string]*queuedWrite),
+			flushCh:  make(chan struct{}, 1),
+		}
+		q.queues[table] = wq
+
+		selfmetrics.AddMetaDBQueueDepth(table, wq.depth)
+
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			wq.run(ctx, s)
+		}()
+	}
+
+	// History kept forever without retention
+	if cfg.HistoryRetention > 0 {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			purgeHistory(ctx, s, cfg.HistoryRetention)
+		}()
+	}
+
+	return q
+}
+
+func (q *queuedStore) PersistsInterfaceMetadata(ifMeta []map[string]interface{}, node string) error {
+	return q.queues["interface_meta"].enqueue(node, func(s Store) error {
+		return s.PersistsInterfaceMetadata(ifMeta, node)
+	})
+}
+
+func (q *queuedStore) PersistsBgpPeersMetadata(bgpPeers []map[string]interface{}, node string) error {
+	return q.queues["bgp_neighbors_meta"].enqueue(node, func(s Store) error {
+		return s.PersistsBgpPeersMetadata(bgpPeers, node)
+	})
+}
+
+func (q *queuedStore) PersistsBgpAfiMetadata(bgpAfiMeta []map[string]interface{}, node string) error {
+	return q.queues["bgp_afi_meta"].enqueue(node, func(s Store) error {
+		return s.PersistsBgpAfiMetadata(bgpAfiMeta, node)
+	})
+}
+
+func (q *queuedStore) PersistsCPUProcMetadata(cpuProc []map[string]interface{}) error {
+	return q.queues["cpu_processes_meta"].enqueue(rowsNode(cpuProc), func(s Store) error {
+		return s.PersistsCPUProcMetadata(cpuProc)
+	})
+}
+
+func (q *queuedStore) PersistsMemProcMetadata(memProc []map[string]interface{}) error {
+	return q.queues["mem_processes_meta"].enqueue(rowsNode(memProc), func(s Store) error {
+		return s.PersistsMemProcMetadata(memProc)
+	})
+}
+
+func (q *queuedStore) PersistsDeviceHWInventory(devHWInventory []map[string]interface{}, node string) error {
+	return q.queues["device_hw_info"].enqueue(node, func(s Store) error {
+		return s.PersistsDeviceHWInventory(devHWInventory, node)
+	})
+}
+
+func (q *queuedStore) PersistsDeviceSYSData(devSYSData []map[string]interface{}, node string) error {
+	return q.queues["device_sys_data"].enqueue(node, func(s Store) error {
+		return s.PersistsDeviceSYSData(devSYSData, node)
+	})
+}
+
+func (q *queuedStore) PersistsDeviceLicenseData(devLicenseData map[string]interface{}, node string) error {
+	return q.queues["device_license_meta"].enqueue(node, func(s Store) error {
+		return s.PersistsDeviceLicenseData(devLicenseData, node)
+	})
+}
+
+func (q *queuedStore) PersistsIPSlaConfigMetadata(ipSLAMeta []map[string]interface{}, node string) error {
+	return q.queues["ip_sla_config_meta"].enqueue(node, func(s Store) error {
+		return s.PersistsIPSlaConfigMetadata(ipSLAMeta, node)
+	})
+}
+
+// Reads are served by the store, the writes still queued are not included
+
+func (q *queuedStore) ListChanges(ctx context.Context, node string, from, to time.Time) ([]Change, error) {
+	return q.store.ListChanges(ctx, node, from, to)
+}
+
+func (q *queuedStore) PurgeHistory(ctx context.Context, before time.Time) (int64, error) {
+	return q.store.PurgeHistory(ctx, before)
+}
+
+func (q *queuedStore) ListDevices(ctx context.Context, lq ListQuery) ([]Device, int, error) {
+	return q.store.ListDevices(ctx, lq)
+}
+
+func (q *queuedStore) GetDevice(ctx context.Context, node string) (*Device, error) {
+	return q.store.GetDevice(ctx, node)
+}
+
+func (q *queuedStore) ListInterfaces(ctx context.Context, node string, lq ListQuery) ([]Interface, int, error) {
+	return q.store.ListInterfaces(ctx, node, lq)
+}
+
+func (q *queuedStore) ListBgpPeers(ctx context.Context, node string, lq ListQuery) ([]BgpPeer, int, error) {
+	return q.store.ListBgpPeers(ctx, node, lq)
+}
+
+func (q *queuedStore) ListHardware(ctx context.Context, node string, lq ListQuery) ([]HardwareComponent, int, error) {
+	return q.store.ListHardware(ctx, node, lq)
+}
+
+func (q *queuedStore) ListIPSlaEntries(ctx context.Context, node string, lq ListQuery) ([]IPSlaEntry, int, error) {
+	return q.store.ListIPSlaEntries(ctx, node, lq)
+}
+
+// Ping checks the store behind the queues is reachable
+func (q *queuedStore) Ping(ctx context.Context) error {
+	return q.store.Ping(ctx)
+}
+
+// Close flushes the pending writes and releases the store
+func (q *queuedStore) Close() {
+
+	q.cancel()
+	q.wg.Wait()
+
+	q.store.Close()
+}
+
+// enqueue queues the write of the node metadata, replacing its pending write if any
+func (wq *writeQueue) enqueue(node string, write func(s Store) error) error {
+
+	wq.mu.Lock()
+
+	if _, ok := wq.pending[node]; ok {
+		selfmetrics.MetaDBWriteCoalesced(wq.table)
+	} else if len(wq.pending) >= wq.settings.Size {
+		wq.dropped++
+		wq.mu.Unlock()
+		selfmetrics.MetaDBWriteDropped(wq.table, dropQueueFull)
+		return nil
+	}
+
+	wq.pending[node] = &queuedWrite{node: node, write: write}
+	full := len(wq.pending) >= wq.settings.FlushSize
+
+	wq.mu.Unlock()
+
+	if full {
+		select {
+		case wq.flushCh <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// run flushes the queue on interval or once it reaches the flush size until the context is done
+func (wq *writeQueue) run(ctx context.Context, s Store) {
+
+	t := time.NewTicker(wq.settings.FlushInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wq.flush(s, true)
+			return
+		case <-t.C:
+			wq.flush(s, false)
+		case <-wq.flushCh:
+			wq.flush(s, false)
+		}
+	}
+}
+
+// flush writes the pending writes due for a retry. The final flush writes all of them without retry.
+func (wq *writeQueue) flush(s Store, final bool) {
+
+	now := time.Now()
+
+	wq.mu.Lock()
+
+	var due []*queuedWrite
+
+	for node, w := range wq.pending {
+		if final || !now.Before(w.notBefore) {
+			due = append(due, w)
+			delete(wq.pending, node)
+		}
+	}
+
+	dropped := wq.dropped
+	wq.dropped = 0
+
+	wq.mu.Unlock()
+
+	if dropped > 0 {
+		logging.PeppaMonLog(
+			"warning",
+			"Dropped %v %v metadata write(s) since the last flush as the write queue is full", dropped, wq.table)
+	}
+
+	for _, w := range due {
+
+		err := w.write(s)
+
+		if err == nil {
+			continue
+		}
+
+		if !isTransient(err) {
+			selfmetrics.MetaDBWriteDropped(wq.table, dropPermanentError)
+			logging.PeppaMonLog(
+				"error",
+				"Failed to persist %v metadata for node %v: %v", wq.table, w.node, err)
+			continue
+		}
+
+		if final || w.attempts >= wq.settings.MaxRetries {
+			selfmetrics.MetaDBWriteDropped(wq.table, dropRetriesExhausted)
+			logging.PeppaMonLog(
+				"error",
+				"Failed to persist %v metadata for node %v after %v attempt(s): %v",
+				wq.table, w.node, w.attempts+1, err)
+			continue
+		}
+
+		wq.retry(w)
+	}
+}
+
+// retry queues back a failed write unless a more recent write of the node is pending
+func (wq *writeQueue) retry(w *queuedWrite) {
+
+	backoff := wq.settings.RetryBackoff << uint(w.attempts)
+
+	if backoff > maxRetryBackoff || backoff <= 0 {
+		backoff = maxRetryBackoff
+	}
+
+	w.attempts++
+	w.notBefore = time.Now().Add(backoff)
+
+	wq.mu.Lock()
+	defer wq.mu.Unlock()
+
+	if _, ok := wq.pending[w.node]; ok {
+		return
+	}
+
+	wq.pending[w.node] = w
+	selfmetrics.MetaDBWriteRetried(wq.table)
+}
+
+// depth returns the number of nodes with a pending write
+func (wq *writeQueue) depth() int {
+
+	wq.mu.Lock()
+	defer wq.mu.Unlock()
+
+	return len(wq.pending)
+}
+
+// rowsNode returns the node of the metadata rows
+func rowsNode(rows []map[string]interface{}) string {
+
+	for _, r := range rows {
+		if node, ok := r["node_id"].(string); ok {
+			return node
+		}
+	}
+
+	return ""
+}
+
+// isTransient returns whether a failed write may succeed when retried
+func isTransient(err error) bool {
+
+	if pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var netErr net.Error
+
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) && len(pgErr.Code) >= 2 {
+
+		// Connection exception, transaction rollback, insufficient resources, operator intervention, lock not available
+		switch pgErr.Code[:2] {
+		case "08", "40", "53", "57":
+			return true
+		}
+
+		return pgErr.Code == "55P03"
+	}
+
+	var sqliteErr sqlite3.Error
+
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	return false
+}
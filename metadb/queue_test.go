@@ -0,0 +1,315 @@
+package metadb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+)
+
+// writeRecorder records the metadata writes flushed by a write queue
+type writeRecorder struct {
+	mu     sync.Mutex
+	writes []string
+}
+
+// write returns a queued write recording its value once flushed and failing with err
+func (r *writeRecorder) write(value string, err error) func(s Store) error {
+
+	return func(s Store) error {
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.writes = append(r.writes, value)
+
+		return err
+	}
+}
+
+func (r *writeRecorder) flushed() []string {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	writes := append([]string(nil), r.writes...)
+	sort.Strings(writes)
+
+	return writes
+}
+
+func newTestWriteQueue(settings config.MetaDBQueue) *writeQueue {
+
+	return &writeQueue{
+		table:    "interface_meta",
+		settings: settings,
+		mu:       &sync.Mutex{},
+		pending:  make(map[string]*queuedWrite),
+		flushCh:  make(chan struct{}, 1),
+	}
+}
+
+var testQueueSettings = config.MetaDBQueue{
+	Size:          3,
+	FlushSize:     2,
+	FlushInterval: time.Hour,
+	MaxRetries:    1,
+	RetryBackoff:  time.Millisecond,
+}
+
+func TestWriteQueueEnqueue(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		nodes       []string
+		wantDepth   int
+		wantDropped int
+		wantFlush   bool
+		wantWrite   []string
+	}{
+		{
+			name:      "single write",
+			nodes:     []string{"r1"},
+			wantDepth: 1,
+			wantWrite: []string{"r1-0"},
+		},
+		{
+			name:      "coalesced per node, last write wins",
+			nodes:     []string{"r1", "r1", "r1"},
+			wantDepth: 1,
+			wantWrite: []string{"r1-2"},
+		},
+		{
+			name:      "flush size reached",
+			nodes:     []string{"r1", "r2"},
+			wantDepth: 2,
+			wantFlush: true,
+			wantWrite: []string{"r1-0", "r2-1"},
+		},
+		{
+			name:        "queue full drops the writes of other nodes",
+			nodes:       []string{"r1", "r2", "r3", "r4", "r1"},
+			wantDepth:   3,
+			wantDropped: 1,
+			wantFlush:   true,
+			wantWrite:   []string{"r1-4", "r2-1", "r3-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			wq := newTestWriteQueue(testQueueSettings)
+			rec := &writeRecorder{}
+
+			for i, node := range tt.nodes {
+				if err := wq.enqueue(node, rec.write(node+"-"+string(rune('0'+i)), nil)); err != nil {
+					t.Fatalf("enqueue(%v) error = %v", node, err)
+				}
+			}
+
+			if d := wq.depth(); d != tt.wantDepth {
+				t.Errorf("depth() = %v, want %v", d, tt.wantDepth)
+			}
+
+			if wq.dropped != tt.wantDropped {
+				t.Errorf("dropped writes = %v, want %v", wq.dropped, tt.wantDropped)
+			}
+
+			select {
+			case <-wq.flushCh:
+				if !tt.wantFlush {
+					t.Errorf("flush signalled before the flush size")
+				}
+			default:
+				if tt.wantFlush {
+					t.Errorf("flush not signalled at the flush size")
+				}
+			}
+
+			wq.flush(nil, false)
+
+			if got := rec.flushed(); !equalStrings(got, tt.wantWrite) {
+				t.Errorf("flushed writes = %v, want %v", got, tt.wantWrite)
+			}
+
+			if d := wq.depth(); d != 0 {
+				t.Errorf("depth() after flush = %v, want 0", d)
+			}
+
+			if wq.dropped != 0 {
+				t.Errorf("dropped writes not reset by the flush")
+			}
+		})
+	}
+}
+
+func TestWriteQueueFlushErrors(t *testing.T) {
+
+	transient := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	permanent := errors.New("syntax error")
+
+	tests := []struct {
+		name      string
+		err       error
+		final     bool
+		wantDepth int
+	}{
+		{name: "transient error retried", err: transient, wantDepth: 1},
+		{name: "permanent error dropped", err: permanent},
+		{name: "final flush does not retry", err: transient, final: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			wq := newTestWriteQueue(testQueueSettings)
+			rec := &writeRecorder{}
+
+			if err := wq.enqueue("r1", rec.write("r1", tt.err)); err != nil {
+				t.Fatalf("enqueue() error = %v", err)
+			}
+
+			wq.flush(nil, tt.final)
+
+			if d := wq.depth(); d != tt.wantDepth {
+				t.Errorf("depth() = %v, want %v", d, tt.wantDepth)
+			}
+		})
+	}
+}
+
+func TestWriteQueueRetry(t *testing.T) {
+
+	transient := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	settings := testQueueSettings
+	settings.RetryBackoff = 50 * time.Millisecond
+
+	wq := newTestWriteQueue(settings)
+	rec := &writeRecorder{}
+
+	if err := wq.enqueue("r1", rec.write("r1-0", transient)); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	wq.flush(nil, false)
+
+	// The retry waits for its backoff
+	wq.flush(nil, false)
+
+	if got := rec.flushed(); len(got) != 1 {
+		t.Fatalf("flushed writes before the backoff = %v, want a single attempt", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	// Retries are exhausted after MaxRetries
+	wq.flush(nil, false)
+
+	if d := wq.depth(); d != 0 {
+		t.Errorf("depth() after the last retry = %v, want 0", d)
+	}
+
+	// A more recent write of the node replaces the failed write
+	if err := wq.enqueue("r1", rec.write("r1-1", transient)); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	w := wq.pending["r1"]
+	delete(wq.pending, "r1")
+
+	if err := wq.enqueue("r1", rec.write("r1-2", nil)); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	wq.retry(w)
+	wq.flush(nil, false)
+
+	if got := rec.flushed(); !equalStrings(got, []string{"r1-0", "r1-0", "r1-2"}) {
+		t.Errorf("flushed writes = %v, want the failed write superseded by the recent one", got)
+	}
+}
+
+func TestWriteQueueRun(t *testing.T) {
+
+	settings := testQueueSettings
+	settings.FlushInterval = 10 * time.Millisecond
+
+	wq := newTestWriteQueue(settings)
+	rec := &writeRecorder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		wq.run(ctx, nil)
+		close(done)
+	}()
+
+	// Flushed on interval below the flush size
+	if err := wq.enqueue("r1", rec.write("r1", nil)); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+
+	for len(rec.flushed()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if got := rec.flushed(); !equalStrings(got, []string{"r1"}) {
+		t.Fatalf("flushed writes on interval = %v, want [r1]", got)
+	}
+
+	// Pending writes are flushed on shutdown
+	if err := wq.enqueue("r2", rec.write("r2", nil)); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	cancel()
+	<-done
+
+	if got := rec.flushed(); !equalStrings(got, []string{"r1", "r2"}) {
+		t.Errorf("flushed writes on shutdown = %v, want [r1 r2]", got)
+	}
+}
+
+func TestRowsNode(t *testing.T) {
+
+	tests := []struct {
+		name string
+		rows []map[string]interface{}
+		want string
+	}{
+		{name: "no rows"},
+		{name: "node of the first row", rows: []map[string]interface{}{{"node_id": "r1"}, {"node_id": "r2"}}, want: "r1"},
+		{name: "rows without node", rows: []map[string]interface{}{{"pid": 1}, {"node_id": "r2"}}, want: "r2"},
+	}
+
+	for _, tt := range tests {
+		if got := rowsNode(tt.rows); got != tt.want {
+			t.Errorf("%v: rowsNode() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,215 @@
+package metadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrInvalidFilter is returned when a listing filter is not supported or its value is invalid
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// metaRows represents a result set of the read queries for both backends
+type metaRows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+	Close()
+}
+
+// metaReader runs the read queries of the Telemetry metadata store.
+// Read queries are written once for both backends, SQLite binds the numbered parameters by position.
+type metaReader struct {
+	query func(ctx context.Context, sqlQuery string, args ...interface{}) (metaRows, error)
+}
+
+// ListQuery represents the filters and pagination of a metadata listing
+type ListQuery struct {
+	// Column values to match, keyed by filter name
+	Filters map[string]string
+
+	Limit  int
+	Offset int
+}
+
+// listResource describes how a metadata listing is queried
+type listResource struct {
+	columns string
+	from    string
+	orderBy string
+
+	// Column matching the node, the listing is not restricted to a node when empty
+	nodeColumn string
+
+	filters map[string]listFilter
+}
+
+// listFilter represents a filter of a metadata listing
+type listFilter struct {
+	column  string
+	numeric bool
+}
+
+// newPgxReader returns the reader running the queries on the Postgres pool
+func newPgxReader(pool *pgxpool.Pool) metaReader {
+
+	return metaReader{
+		query: func(ctx context.Context, sqlQuery string, args ...interface{}) (metaRows, error) {
+			return pool.Query(ctx, sqlQuery, args...)
+		},
+	}
+}
+
+// sqlRows adapts the database/sql result set to metaRows
+type sqlRows struct {
+	*sql.Rows
+}
+
+func (r sqlRows) Close() {
+	_ = r.Rows.Close()
+}
+
+// newSQLReader returns the reader running the queries on a database/sql connection pool
+func newSQLReader(db *sql.DB) metaReader {
+
+	return metaReader{
+		query: func(ctx context.Context, sqlQuery string, args ...interface{}) (metaRows, error) {
+
+			rows, err := db.QueryContext(ctx, sqlQuery, args...)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return sqlRows{rows}, nil
+		},
+	}
+}
+
+// list returns the total number of rows of the resource matching the query and scans the requested page
+func (r metaReader) list(ctx context.Context, res listResource, node string, q ListQuery,
+	scan func(rows metaRows) error) (int, error) {
+
+	where, args, err := res.where(node, q.Filters)
+
+	if err != nil {
+		return 0, err
+	}
+
+	ctxTimeout, cancelQuery := context.WithTimeout(ctx, shortQueryTimeout)
+
+	defer cancelQuery()
+
+	var total int
+
+	countRows, err := r.query(ctxTimeout, fmt.Sprintf("SELECT COUNT(*) FROM %v%v", res.from, where), args...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for countRows.Next() {
+		if err := countRows.Scan(&total); err != nil {
+			countRows.Close()
+			return 0, err
+		}
+	}
+
+	countRows.Close()
+
+	if err := countRows.Err(); err != nil {
+		return 0, err
+	}
+
+	sqlQuery := fmt.Sprintf("SELECT %v FROM %v%v ORDER BY %v LIMIT $%v OFFSET $%v",
+		res.columns, res.from, where, res.orderBy, len(args)+1, len(args)+2)
+
+	rows, err := r.query(ctxTimeout, sqlQuery, append(args, q.Limit, q.Offset)...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, rows.Err()
+}
+
+// where returns the WHERE clause and its arguments matching the node and the filters
+func (res listResource) where(node string, filters map[string]string) (string, []interface{}, error) {
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if node != "" && res.nodeColumn != "" {
+		args = append(args, node)
+		conditions = append(conditions, fmt.Sprintf("%v = $%v", res.nodeColumn, len(args)))
+	}
+
+	// Sort the filters so the statement is the same for a given set of filters
+	names := make([]string, 0, len(filters))
+
+	for name := range filters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		f, ok := res.filters[name]
+
+		if !ok {
+			return "", nil, fmt.Errorf("%w %v, supported filters: %v",
+				ErrInvalidFilter, name, strings.Join(res.filterNames(), ", "))
+		}
+
+		var arg interface{} = filters[name]
+
+		if f.numeric {
+			n, err := strconv.ParseInt(filters[name], 10, 64)
+
+			if err != nil {
+				return "", nil, fmt.Errorf("%w %v: %v is not a number", ErrInvalidFilter, name, filters[name])
+			}
+			arg = n
+		}
+
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf("%v = $%v", f.column, len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args, nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// filterNames returns the sorted filters supported by the listing
+func (res listResource) filterNames() []string {
+
+	names := make([]string, 0, len(res.filters))
+
+	for name := range res.filters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
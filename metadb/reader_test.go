@@ -0,0 +1,109 @@
+package metadb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var testListResource = listResource{
+	columns:    "device_id, interface_name, oper_status",
+	from:       "interface_meta",
+	orderBy:    "interface_name",
+	nodeColumn: "device_id",
+	filters: map[string]listFilter{
+		"name":       {column: "interface_name"},
+		"operStatus": {column: "oper_status", numeric: true},
+		"vrf":        {column: "vrf_attached"},
+	},
+}
+
+func TestListResourceWhere(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		res       listResource
+		node      string
+		filters   map[string]string
+		wantWhere string
+		wantArgs  []interface{}
+		wantErr   string
+	}{
+		{
+			name: "no node nor filter",
+			res:  testListResource,
+		},
+		{
+			name:      "node",
+			res:       testListResource,
+			node:      "r1",
+			wantWhere: " WHERE device_id = $1",
+			wantArgs:  []interface{}{"r1"},
+		},
+		{
+			name:      "listing not restricted to a node",
+			res:       listResource{filters: testListResource.filters},
+			node:      "r1",
+			filters:   map[string]string{"name": "Gi1"},
+			wantWhere: " WHERE interface_name = $1",
+			wantArgs:  []interface{}{"Gi1"},
+		},
+		{
+			name:      "filters sorted after the node",
+			res:       testListResource,
+			node:      "r1",
+			filters:   map[string]string{"vrf": "mgmt", "name": "Gi1"},
+			wantWhere: " WHERE device_id = $1 AND interface_name = $2 AND vrf_attached = $3",
+			wantArgs:  []interface{}{"r1", "Gi1", "mgmt"},
+		},
+		{
+			name:      "numeric filter",
+			res:       testListResource,
+			filters:   map[string]string{"operStatus": "2"},
+			wantWhere: " WHERE oper_status = $1",
+			wantArgs:  []interface{}{int64(2)},
+		},
+		{
+			name:    "numeric filter not a number",
+			res:     testListResource,
+			filters: map[string]string{"operStatus": "up"},
+			wantErr: "invalid filter operStatus: up is not a number",
+		},
+		{
+			name:    "unsupported filter",
+			res:     testListResource,
+			filters: map[string]string{"speed": "1000"},
+			wantErr: "invalid filter speed, supported filters: name, operStatus, vrf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			where, args, err := tt.res.where(tt.node, tt.filters)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("where() error = %v, want %v", err, tt.wantErr)
+				}
+
+				if !errors.Is(err, ErrInvalidFilter) {
+					t.Errorf("where() error does not wrap ErrInvalidFilter")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("where() error = %v", err)
+			}
+
+			if where != tt.wantWhere {
+				t.Errorf("where() = %q, want %q", where, tt.wantWhere)
+			}
+
+			if len(args) != len(tt.wantArgs) || (len(args) > 0 && !reflect.DeepEqual(args, tt.wantArgs)) {
+				t.Errorf("where() args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
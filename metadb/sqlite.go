@@ -0,0 +1,522 @@
+package metadb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	// SQLite database/sql driver
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// sqliteStore represents the embedded SQLite Telemetry metadata store
+type sqliteStore struct {
+	metaReader
+
+	db *sql.DB
+}
+
+// sqliteTable describes how the metadata rows are upserted in a SQLite table.
+// Rows hold the values of the key columns followed by the other columns.
+type sqliteTable struct {
+	name string
+
+	// Primary key columns, starting with device_id
+	keys    []string
+	columns []string
+
+	// Rows of the node missing from the update are deleted
+	sanitize bool
+}
+
+var (
+	sqliteInterfaceMeta = sqliteTable{
+		name: "interface_meta",
+		keys: []string{"device_id", "interface_name"},
+		columns: []string{"timestamps", "description", "ipv4_address", "admin_status", "oper_status", "speed", "mtu",
+			"physical_address", "vrf_attached", "last_status_change"},
+		sanitize: true,
+	}
+	sqliteBgpPeersMeta = sqliteTable{
+		name:     "bgp_neighbors_meta",
+		keys:     []string{"device_id", "neighbor_id", "address_family_type", "address_family_vrf"},
+		columns:  []string{"timestamps", "neighbor_status", "uptime", "remote_as"},
+		sanitize: true,
+	}
+	sqliteBgpAfiMeta = sqliteTable{
+		name:     "bgp_afi_meta",
+		keys:     []string{"device_id", "afi_type", "vrf_name"},
+		columns:  []string{"timestamps", "total_prefixes", "total_paths"},
+		sanitize: true,
+	}
+	sqliteCPUProcMeta = sqliteTable{
+		name: "cpu_processes_meta",
+		keys: []string{"device_id", "cpu_process_name"},
+		columns: []string{"timestamps", "cpu_process_pid", "cpu_proc_avg_runtime", "cpu_proc_busy_avg_5_sec",
+			"cpu_proc_busy_avg_1_min", "cpu_proc_busy_avg_5_min"},
+	}
+	sqliteMemProcMeta = sqliteTable{
+		name:    "mem_processes_meta",
+		keys:    []string{"device_id", "mem_process_name"},
+		columns: []string{"timestamps", "mem_process_pid", "allocated_memory", "freed_memory", "holding_memory"},
+	}
+	sqliteDeviceHWInfo = sqliteTable{
+		name: "device_hw_info",
+		keys: []string{"device_id", "hardware_type", "hardware_part_number", "serial_number"},
+		columns: []string{"timestamps", "hardware_description", "hardware_device_name", "hardware_field_replaceable",
+			"hardware_version"},
+		sanitize: true,
+	}
+	sqliteDeviceSYSData = sqliteTable{
+		name:    "device_sys_data",
+		keys:    []string{"device_id"},
+		columns: []string{"timestamps", "last_seen_epoch", "boot_time_epoch", "sw_version"},
+	}
+	sqliteDeviceLicense = sqliteTable{
+		name:    "device_license_meta",
+		keys:    []string{"device_id"},
+		columns: []string{"timestamps", "product_id", "serial_number", "boot_license"},
+	}
+	sqliteIPSlaConfigMeta = sqliteTable{
+		name: "ip_sla_config_meta",
+		keys: []string{"device_id", "entry_id"},
+		columns: []string{"timestamps", "destination_ip", "destination_port", "source_ip", "source_port", "vrf",
+			"frequency", "type", "dscp", "class_of_service", "req_data_size", "http_url", "http_version", "http_proxy",
+			"http_dns_server", "destination_host"},
+		sanitize: true,
+	}
+)
+
+// openSQLiteStore opens the SQLite Telemetry metadata database file
+func openSQLiteStore(ctx context.Context, cfg config.MetaDB) (Store, error) {
+
+	// Busy timeout lets the writers wait for each other instead of failing on a locked database
+	db, err := sql.Open("sqlite3", "file:"+cfg.SQLitePath+"?_busy_timeout=5000&_journal_mode=WAL")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite supports a single writer
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	if err := migrateSQLite(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate the SQLite schema: %v", err)
+	}
+
+	logging.PeppaMonLog("info", "SQLite metadata database %v successfully opened", cfg.SQLitePath)
+
+	return &sqliteStore{metaReader: newSQLReader(db), db: db}, nil
+}
+
+// migrateSQLite applies the pending schema migrations to the SQLite database
+func migrateSQLite(ctx context.Context, db *sql.DB) error {
+
+	const sqlCreateTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+
+	if _, err := db.ExecContext(ctx, sqlCreateTable); err != nil {
+		return err
+	}
+
+	applied := make(map[int]bool)
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+
+		var version int
+
+		if err := rows.Scan(&version); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		applied[version] = true
+	}
+	_ = rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, mig := range migrations {
+
+		if applied[mig.version] {
+			continue
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, mig.sqliteUp()); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %v %v failed: %v", mig.version, mig.name, err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name) VALUES (?, ?)", mig.version, mig.name); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		logging.PeppaMonLog("info", "Applied metadata schema migration %v %v", mig.version, mig.name)
+	}
+
+	return nil
+}
+
+// PersistsInterfaceMetadata will update the Telemetry Metadata database with interfaces attributes
+func (s *sqliteStore) PersistsInterfaceMetadata(ifMeta []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("interface_meta", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(ifMeta))
+
+	for _, cp := range ifMeta {
+
+		ipv4 := convertStrToIPv4(cp["ipv4_address"].(string), cp["ipv4_subnet_mask"].(string))
+
+		rows = append(rows, []interface{}{
+			cp["node_id"], cp["if_name"],
+			cp["timestamps"], cp["description"], ipv4.String(), cp["admin_status"], cp["oper_status"], cp["speed"],
+			cp["mtu"], cp["physical_address"], cp["vrf"], cp["last_change"],
+		})
+	}
+
+	return s.upsert(sqliteInterfaceMeta, node, rows)
+}
+
+// PersistsBgpPeersMetadata will save the BGP peers metadata in the Telemetry Meta DB
+func (s *sqliteStore) PersistsBgpPeersMetadata(bgpPeers []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("bgp_neighbors_meta", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(bgpPeers))
+
+	for _, cp := range bgpPeers {
+		rows = append(rows, []interface{}{
+			cp["node_id"], cp["neighbor_id"], cp["address_family_type"], cp["address_family_vrf"],
+			cp["timestamps"], cp["neighbor_status"], cp["neighbor_uptime"], cp["neighbor_remote_as"],
+		})
+	}
+
+	return s.upsert(sqliteBgpPeersMeta, node, rows)
+}
+
+// PersistsBgpAfiMetadata will save the BGP Address Family metadata in the Telemetry Meta DB
+func (s *sqliteStore) PersistsBgpAfiMetadata(bgpAfiMeta []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("bgp_afi_meta", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(bgpAfiMeta))
+
+	for _, cp := range bgpAfiMeta {
+		rows = append(rows, []interface{}{
+			cp["node_id"], cp["bgp_address_family_type"], cp["bgp_address_family_vrf"],
+			cp["timestamps"], cp["bgp_afi_total_prefixes"], cp["bgp_afi_total_paths"],
+		})
+	}
+
+	return s.upsert(sqliteBgpAfiMeta, node, rows)
+}
+
+// PersistsCPUProcMetadata will save the processes CPU utilization in the Telemetry Meta DB
+func (s *sqliteStore) PersistsCPUProcMetadata(cpuProc []map[string]interface{}) (err error) {
+
+	defer observeWrite("cpu_processes_meta", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(cpuProc))
+
+	for _, cp := range cpuProc {
+		rows = append(rows, []interface{}{
+			cp["node_id"], cp["proc_name"],
+			cp["timestamps"], cp["pid"], cp["proc_avg_runtime"], cp["cpu_proc_busy_avg_5_sec"],
+			cp["cpu_proc_busy_avg_1_min"], cp["cpu_proc_busy_avg_5_min"],
+		})
+	}
+
+	return s.upsert(sqliteCPUProcMeta, "", rows)
+}
+
+// PersistsMemProcMetadata will save the processes memory utilization in the Telemetry Meta DB
+func (s *sqliteStore) PersistsMemProcMetadata(memProc []map[string]interface{}) (err error) {
+
+	defer observeWrite("mem_processes_meta", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(memProc))
+
+	for _, cp := range memProc {
+		rows = append(rows, []interface{}{
+			cp["node_id"], cp["process_name"],
+			cp["timestamps"], cp["pid"], cp["allocated_memory"], cp["freed_memory"], cp["holding_memory"],
+		})
+	}
+
+	return s.upsert(sqliteMemProcMeta, "", rows)
+}
+
+// PersistsDeviceHWInventory will save the hardware inventory in the Telemetry Meta DB
+func (s *sqliteStore) PersistsDeviceHWInventory(devHWInventory []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("device_hw_info", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(devHWInventory))
+
+	for _, cp := range devHWInventory {
+		rows = append(rows, []interface{}{
+			cp["node"], cp["hw-type"], cp["part-number"], cp["serial-number"],
+			cp["timestamps"], cp["hw-description"], cp["dev-name"], cp["field-replaceable"], cp["version"],
+		})
+	}
+
+	return s.upsert(sqliteDeviceHWInfo, node, rows)
+}
+
+// PersistsDeviceSYSData will save the device system data in the Telemetry Meta DB
+func (s *sqliteStore) PersistsDeviceSYSData(devSYSData []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("device_sys_data", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(devSYSData))
+
+	for _, cp := range devSYSData {
+		rows = append(rows, []interface{}{
+			cp["node"],
+			cp["timestamps"], cp["current-time"], cp["boot-time"], cp["software-version"],
+		})
+	}
+
+	return s.upsert(sqliteDeviceSYSData, node, rows)
+}
+
+// PersistsDeviceLicenseData will save the device license in the Telemetry Meta DB
+func (s *sqliteStore) PersistsDeviceLicenseData(devLicenseData map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("device_license_meta", time.Now(), &err)
+
+	rows := [][]interface{}{{
+		devLicenseData["node"],
+		devLicenseData["timestamps"], devLicenseData["pid"], devLicenseData["sn"], devLicenseData["level"],
+	}}
+
+	return s.upsert(sqliteDeviceLicense, node, rows)
+}
+
+// PersistsIPSlaConfigMetadata will save the IP SLA configuration in the Telemetry Meta DB
+func (s *sqliteStore) PersistsIPSlaConfigMetadata(ipSLAMeta []map[string]interface{}, node string) (err error) {
+
+	defer observeWrite("ip_sla_config_meta", time.Now(), &err)
+
+	rows := make([][]interface{}, 0, len(ipSLAMeta))
+
+	for _, cp := range ipSLAMeta {
+		rows = append(rows, []interface{}{
+			cp["node_id"], cp["sla_number"],
+			cp["timestamps"], cp["destination_ip"], cp["destination_port"], cp["source_ip"], cp["source_port"],
+			cp["vrf"], cp["frequency"], cp["sla_type"], cp["dscp"], cp["class_of_service"], cp["req_data_size"],
+			cp["http_url"], cp["http_version"], cp["http_proxy"], cp["http_dns_server"], cp["destination_host"],
+		})
+	}
+
+	return s.upsert(sqliteIPSlaConfigMeta, node, rows)
+}
+
+// Ping checks the SQLite database is available
+func (s *sqliteStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// PurgeHistory deletes the changes recorded before the time from the history tables
+func (s *sqliteStore) PurgeHistory(ctx context.Context, before time.Time) (int64, error) {
+
+	var purged int64
+
+	for _, stmt := range purgeHistoryStatements() {
+
+		res, err := s.db.ExecContext(ctx, stmt, before.Unix())
+
+		if err != nil {
+			return purged, err
+		}
+
+		n, err := res.RowsAffected()
+
+		if err != nil {
+			return purged, err
+		}
+
+		purged += n
+	}
+
+	return purged, nil
+}
+
+// Close releases the SQLite database
+func (s *sqliteStore) Close() {
+
+	if err := s.db.Close(); err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"Failed to close SQLite metadata database %v", err)
+	}
+}
+
+// upsert inserts or updates the rows within a transaction.
+// When the table is sanitized, the rows of the node missing from the update are deleted first.
+func (s *sqliteStore) upsert(t sqliteTable, node string, rows [][]interface{}) error {
+
+	// Set Query timeout
+	ctxTimeout, cancelQuery := context.WithTimeout(context.Background(), shortQueryTimeout)
+	defer cancelQuery()
+
+	tx, err := s.db.BeginTx(ctxTimeout, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if t.sanitize {
+		if err := t.sanitizeRows(ctxTimeout, tx, node, rows); err != nil {
+			return fmt.Errorf("failed to sanitize %v for node %v: %w", t.name, node, err)
+		}
+	}
+
+	stmt, err := tx.PrepareContext(ctxTimeout, t.upsertQuery())
+
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctxTimeout, row...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// sanitizeRows deletes the rows of the node whose key is not part of the update
+func (t sqliteTable) sanitizeRows(ctx context.Context, tx *sql.Tx, node string, rows [][]interface{}) error {
+
+	updated := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		updated[rowKey(row[:len(t.keys)])] = true
+	}
+
+	dbRows, err := tx.QueryContext(ctx,
+		fmt.Sprintf("SELECT %v FROM %v WHERE device_id = ?", strings.Join(t.keys, ", "), t.name), node)
+
+	if err != nil {
+		return err
+	}
+
+	var stale [][]interface{}
+
+	for dbRows.Next() {
+
+		key := make([]interface{}, len(t.keys))
+		dest := make([]interface{}, len(t.keys))
+
+		for i := range key {
+			dest[i] = &key[i]
+		}
+
+		if err := dbRows.Scan(dest...); err != nil {
+			_ = dbRows.Close()
+			return err
+		}
+
+		if !updated[rowKey(key)] {
+			stale = append(stale, key)
+		}
+	}
+	_ = dbRows.Close()
+
+	if err := dbRows.Err(); err != nil {
+		return err
+	}
+
+	where := make([]string, len(t.keys))
+
+	for i, k := range t.keys {
+		where[i] = k + " = ?"
+	}
+
+	sqlQuery := fmt.Sprintf("DELETE FROM %v WHERE %v", t.name, strings.Join(where, " AND "))
+
+	for _, key := range stale {
+		if _, err := tx.ExecContext(ctx, sqlQuery, key...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertQuery returns the SQL query inserting a row or updating the columns of an existing row
+func (t sqliteTable) upsertQuery() string {
+
+	columns := append(append([]string{}, t.keys...), t.columns...)
+
+	updates := make([]string, len(t.columns))
+
+	for i, c := range t.columns {
+		updates[i] = fmt.Sprintf("%v = excluded.%v", c, c)
+	}
+
+	return fmt.Sprintf("INSERT INTO %v (%v) VALUES (%v) ON CONFLICT (%v) DO UPDATE SET %v",
+		t.name,
+		strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+		strings.Join(t.keys, ", "),
+		strings.Join(updates, ", "))
+}
+
+// rowKey returns a comparable representation of the key column values
+func rowKey(values []interface{}) string {
+
+	key := make([]string, len(values))
+
+	for i, v := range values {
+
+		// Text columns are scanned as bytes
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		key[i] = fmt.Sprint(v)
+	}
+
+	return strings.Join(key, "\x00")
+}
@@ -0,0 +1,64 @@
+package metadb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+)
+
+// Store represents a Telemetry metadata storage backend.
+// The Persists* operations upsert the metadata rows of a node. For the interfaces, BGP peers and address families,
+// hardware inventory and IP SLA config, the rows of the node missing from the Telemetry message are deleted.
+// The interfaces, BGP peers and hardware inventory changes are recorded in history tables purged after the retention.
+type Store interface {
+	PersistsInterfaceMetadata(ifMeta []map[string]interface{}, node string) error
+	PersistsBgpPeersMetadata(bgpPeers []map[string]interface{}, node string) error
+	PersistsBgpAfiMetadata(bgpAfiMeta []map[string]interface{}, node string) error
+	PersistsCPUProcMetadata(cpuProc []map[string]interface{}) error
+	PersistsMemProcMetadata(memProc []map[string]interface{}) error
+	PersistsDeviceHWInventory(devHWInventory []map[string]interface{}, node string) error
+	PersistsDeviceSYSData(devSYSData []map[string]interface{}, node string) error
+	PersistsDeviceLicenseData(devLicenseData map[string]interface{}, node string) error
+	PersistsIPSlaConfigMetadata(ipSLAMeta []map[string]interface{}, node string) error
+
+	// ListChanges returns the interfaces, BGP peers and hardware inventory changes of a node over a time range
+	ListChanges(ctx context.Context, node string, from, to time.Time) ([]Change, error)
+
+	// PurgeHistory deletes the changes recorded before a time and returns the number of changes deleted
+	PurgeHistory(ctx context.Context, before time.Time) (int64, error)
+
+	// Inventory listings return a page of the rows matching the query and the total number of matching rows
+	ListDevices(ctx context.Context, q ListQuery) ([]Device, int, error)
+	GetDevice(ctx context.Context, node string) (*Device, error)
+	ListInterfaces(ctx context.Context, node string, q ListQuery) ([]Interface, int, error)
+	ListBgpPeers(ctx context.Context, node string, q ListQuery) ([]BgpPeer, int, error)
+	ListHardware(ctx context.Context, node string, q ListQuery) ([]HardwareComponent, int, error)
+	ListIPSlaEntries(ctx context.Context, node string, q ListQuery) ([]IPSlaEntry, int, error)
+
+	// Ping checks the backend is reachable
+	Ping(ctx context.Context) error
+
+	// Close releases the backend connections
+	Close()
+}
+
+// Supported Telemetry metadata storage backends
+const (
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+)
+
+// openStore connects the storage backend selected in the configuration and brings its schema up to date
+func openStore(ctx context.Context, cfg config.MetaDB) (Store, error) {
+
+	switch cfg.Backend {
+	case BackendPostgres:
+		return openPostgresStore(ctx, cfg)
+	case BackendSQLite:
+		return openSQLiteStore(ctx, cfg)
+	}
+
+	return nil, fmt.Errorf("unsupported metadata storage backend %q", cfg.Backend)
+}
@@ -12,25 +12,22 @@ import (
 )
 
 var (
-	bgpIpv4NeighborPrefixesRcvd = prometheus.NewDesc(
+	bgpIpv4NeighborPrefixesRcvd = newDesc(
 		"cisco_iosxe_bgp_neighbor_prefixes_received",
 		"The number of prefixes received from BGP IPv4 unicast peer",
 		[]string{"node", "neighbor_id", "address_family", "vrf"},
-		nil,
 	)
 
-	bgpGlobalMeta = prometheus.NewDesc(
+	bgpGlobalMeta = newDesc(
 		"cisco_iosxe_bgp_global_meta",
 		"BGP Local AS number and router-id",
 		[]string{"node", "local_neighbor_id", "local_as"},
-		nil,
 	)
 
-	bgpIpv4PeerStatus = prometheus.NewDesc(
+	bgpIpv4PeerStatus = newDesc(
 		"cisco_iosxe_bgp_neighbor_peer_status",
 		"The status of a BGP IPv4 unicast peer",
 		[]string{"node", "neighbor_id", "address_family", "vrf"},
-		nil,
 	)
 )
 
@@ -209,34 +206,35 @@ func parseBgpIpv4UnicastPB(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics, t
 		strconv.Itoa(int(bgpLocalAS)),
 	)
 
-	// Handle BGP Peers Metadata persistence in separate Go Routine
-	go func() {
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
 
-		if len(BgpIpv4NeighborsSlice) > 0 {
-			err := metadb.DBInstance.PersistsBgpPeersMetadata(BgpIpv4NeighborsSlice, node)
+	if db == nil {
+		return
+	}
 
-			if err != nil {
-				logging.PeppaMonLog(
-					"error",
+	// Queue BGP Peers Metadata persistence
+	if len(BgpIpv4NeighborsSlice) > 0 {
+		err := db.PersistsBgpPeersMetadata(BgpIpv4NeighborsSlice, node)
 
-					"Failed to insert BGP Peers metadata for node %v : %v", node, err)
-			}
-		}
-	}()
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
 
-	// Handle BGP AFI Metadata persistence in separate Go Routine
-	go func() {
+				"Failed to insert BGP Peers metadata for node %v : %v", node, err)
+		}
+	}
 
-		if len(BgpIpv4AFISlice) > 0 {
-			err := metadb.DBInstance.PersistsBgpAfiMetadata(BgpIpv4AFISlice, node)
+	// Queue BGP AFI Metadata persistence
+	if len(BgpIpv4AFISlice) > 0 {
+		err := db.PersistsBgpAfiMetadata(BgpIpv4AFISlice, node)
 
-			if err != nil {
-				logging.PeppaMonLog(
-					"error",
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
 
-					"Failed to insert BGP AFI metadata for node %v : %v", node, err)
-			}
+				"Failed to insert BGP AFI metadata for node %v : %v", node, err)
 		}
-	}()
+	}
 
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/lucabrasi83/peppamon_cisco/logging"
 	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/lucabrasi83/peppamon_cisco/selfmetrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -18,22 +19,40 @@ type Source struct {
 	Path   string
 }
 
-// Collector represents the Peppamon Telemetry collector that will carry all the metrics collected
+// Collector represents the Peppamon Telemetry collector dispatching the metrics decoded from the Telemetry
+// messages to the output sinks
 type Collector struct {
-	Mutex   *sync.Mutex
-	Metrics map[Source]*DeviceGroupedMetrics
-}
+	Mutex *sync.Mutex
+
+	// Collection rounds being assembled before being published to the sinks
+	staging map[Source]*collectionRound
+
+	// Last collection round published per source
+	published map[Source]uint64
+
+	// Outputs receiving the samples of each published collection round
+	sinks []Sink
+
+	// Outputs receiving every decoded Telemetry message
+	messageSinks []MessageSink
 
-// DeviceUnaryMetric represents a single Device Metric
-type DeviceUnaryMetric struct {
-	Mutex  *sync.Mutex
-	Metric prometheus.Metric
+	// Policy and counters of the YANG encoding paths without parser
+	unsupported *UnsupportedPaths
+
+	// Decoder of the YANG encoding paths without parser with the passthrough policy. nil when disabled
+	generic *GenericDecoder
+
+	// Labels added to the samples of each node. nil when disabled
+	nodeLabeler NodeLabeler
+
+	// Time after which a collection round missing its last message is published anyway
+	roundTimeout time.Duration
 }
 
-// DeviceGroupedMetrics represents a set of grouped metrics
+// DeviceGroupedMetrics represents the set of samples decoded from a Telemetry message
 type DeviceGroupedMetrics struct {
 	Mutex   *sync.Mutex
-	Metrics []DeviceUnaryMetric
+	Samples []Sample
 }
 
 // CiscoTelemetryMetric represents a Cisco IOS-XE telemetry metric sent in protocol buffer format
@@ -48,55 +67,103 @@ func NewCollector() *Collector {
 	mu := &sync.Mutex{}
 
 	return &Collector{
-		Mutex:   mu,
-		Metrics: make(map[Source]*DeviceGroupedMetrics),
+		Mutex:        mu,
+		staging:      make(map[Source]*collectionRound),
+		published:    make(map[Source]uint64),
+		roundTimeout: maxCollectionRoundTimeout,
 	}
 }
 
-// Describe method will write metrics descriptors within Prometheus Desc channel
-// and implements prometheus.Collector interface
-func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+// RecordTelemetryMsg will dispatch a decoded Telemetry message to the parsers registered for its YANG encoding path.
+// The decoded samples are staged until the collection round of the message is complete, then published at once
+// to the sinks so a scrape never sees a partially decoded collection round.
+// It returns the metrics cache key and whether the YANG encoding path is supported.
+// Every message reaches the message sinks. Messages of unsupported YANG encoding paths are counted and only reach
+// the generic decoder with the passthrough policy.
+func (c *Collector) RecordTelemetryMsg(msg *telemetry.Telemetry) (Source, bool) {
 
-	// Copy current descriptors in case consumer channel is slow
-	var metricDescriptors []*prometheus.Desc
+	// The Metrics Source represents the metrics cache key and is a combination of the Telemetry NodeID
+	// and YANG encoding path
+	node := msg.GetNodeIdStr()
+	telemetrySource := Source{NodeID: node, Path: msg.GetEncodingPath()}
 
-	c.Mutex.Lock()
-	for _, source := range c.Metrics {
-		for _, metric := range source.Metrics {
-			metricDescriptors = append(metricDescriptors, metric.Metric.Desc())
+	var parsers []CiscoTelemetryMetric
+
+	for _, m := range CiscoMetricRegistrar {
+		if msg.GetEncodingPath() == m.EncodingPath {
+			parsers = append(parsers, m)
 		}
 	}
+
+	c.Mutex.Lock()
+	messageSinks := c.messageSinks
+	unsupported := c.unsupported
+	generic := c.generic
+	nodeLabeler := c.nodeLabeler
 	c.Mutex.Unlock()
 
-	for _, desc := range metricDescriptors {
-		ch <- desc
+	// Raw messages reach the message sinks whatever the unsupported YANG encoding paths policy
+	for _, s := range messageSinks {
+		s.PublishMessage(msg)
 	}
-}
 
-// Collect method implements prometheus.Collector interface and is executed upon each scrape
-// Telemetry Metric cache is sent to Prometheus channel consumer.
-func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	if len(parsers) == 0 {
+		unsupported.Record(telemetrySource)
 
-	// Copy current metrics so we don't lock for very long if channel consumer is slow.
-	var metrics []prometheus.Metric
-	c.Mutex.Lock()
-	for _, deviceMetrics := range c.Metrics {
-		for _, metric := range deviceMetrics.Metrics {
-			metric.Mutex.Lock()
-			metrics = append(metrics, metric.Metric)
-			metric.Mutex.Unlock()
+		if unsupported.Policy() != UnsupportedPathPassThrough {
+			return telemetrySource, false
 		}
+	}
+
+	supported := len(parsers) > 0
 
+	if !supported && generic != nil && generic.Decodes(telemetrySource.Path) {
+		parsers = append(parsers, CiscoTelemetryMetric{
+			EncodingPath:     telemetrySource.Path,
+			RecordMetricFunc: generic.RecordMetricFunc,
+		})
+	}
+
+	if len(parsers) == 0 {
+		return telemetrySource, false
+	}
+
+	// Convert Proto Msg Timestamp to type Time for Prometheus metric
+	timestamp := msg.GetMsgTimestamp()
+	promTimestamp := time.Unix(0, int64(timestamp)*int64(time.Millisecond))
+
+	// Samples of the message are decoded outside of the staging area
+	devMutex := &sync.Mutex{}
+	deviceMetrics := &DeviceGroupedMetrics{Mutex: devMutex}
+
+	for _, m := range parsers {
+		m.RecordMetricFunc(msg, deviceMetrics, promTimestamp.UTC(), node)
 	}
-	c.Mutex.Unlock()
 
-	for _, metric := range metrics {
-		ch <- metric
+	if nodeLabeler != nil {
+		addNodeLabels(deviceMetrics.Samples, nodeLabeler.NodeLabels(node))
 	}
 
+	c.stageSamples(telemetrySource, msg.GetCollectionId(), msg.GetCollectionEndTime() != 0, deviceMetrics)
+
+	return telemetrySource, supported
+}
+
+// RemoveSource will remove the metrics of a Telemetry Node / YANG path from the sinks
+func (c *Collector) RemoveSource(telemetrySource Source) {
+
+	c.Mutex.Lock()
+	c.dropStaging(telemetrySource)
+	delete(c.published, telemetrySource)
+	sinks := c.sinks
+	c.Mutex.Unlock()
+
+	for _, s := range sinks {
+		s.Remove(telemetrySource)
+	}
 }
 
-// CreatePromMetric will create on the fly the Prometheus metric
+// CreatePromMetric will create on the fly the metric sample
 func CreatePromMetric(
 	val interface{},
 	desc *prometheus.Desc,
@@ -106,22 +173,48 @@ func CreatePromMetric(
 	labels ...string) {
 
 	if v, ok := val.(float64); !ok {
+		selfmetrics.SampleDecoded(selfmetrics.SampleInvalidValue)
 		logging.PeppaMonLog("error",
 			"Metric %v value %v not float64. Skipping it.", *desc, v)
 		return
 	}
 
-	metricMutex := &sync.Mutex{}
-	m := DeviceUnaryMetric{Mutex: metricMutex}
-	m.Metric = prometheus.NewMetricWithTimestamp(t, prometheus.MustNewConstMetric(
-		desc,
-		mt,
-		val.(float64),
-		labels...,
-	))
+	info, ok := lookupDescInfo(desc)
+
+	if !ok {
+		selfmetrics.SampleDecoded(selfmetrics.SampleInvalidValue)
+		logging.PeppaMonLog("error",
+			"Metric %v descriptor was not created with newDesc. Skipping it.", desc)
+		return
+	}
+
+	if len(info.labelNames) != len(labels) {
+		selfmetrics.SampleDecoded(selfmetrics.SampleLabelMismatch)
+		logging.PeppaMonLog("error",
+			"Metric %v expects %d label(s), got %d. Skipping it.", info.name, len(info.labelNames), len(labels))
+		return
+	}
+
+	s := Sample{
+		Name:        info.name,
+		Help:        info.help,
+		Labels:      make([]Label, len(labels)),
+		Value:       val.(float64),
+		Timestamp:   t,
+		Type:        mt,
+		desc:        desc,
+		labelValues: labels,
+	}
+
+	for i, l := range labels {
+		s.Labels[i] = Label{Name: info.labelNames[i], Value: l}
+	}
+
 	dm.Mutex.Lock()
-	dm.Metrics = append(dm.Metrics, m)
+	dm.Samples = append(dm.Samples, s)
 	dm.Mutex.Unlock()
+
+	selfmetrics.SampleDecoded(selfmetrics.SampleCreated)
 }
 
 // Support function to extract the value field from K/V Field.
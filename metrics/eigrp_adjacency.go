@@ -8,11 +8,10 @@ import (
 )
 
 var (
-	eigrpAdjStatus = prometheus.NewDesc(
+	eigrpAdjStatus = newDesc(
 		"cisco_iosxe_eigrp_adjacency_status",
 		"The current state of the EIGRP adjacency",
 		[]string{"node", "neighbor_id", "address_family", "vrf", "interface"},
-		nil,
 	)
 )
 
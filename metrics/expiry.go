@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+)
+
+// Bounds of the interval between two checks for stale metrics when derived from the TTL settings
+const (
+	minExpiryCheckInterval = time.Second
+	maxExpiryCheckInterval = 30 * time.Second
+)
+
+// ExpirySettings represents the stale metrics eviction settings of the Prometheus metrics cache
+type ExpirySettings struct {
+	// Number of observed sample intervals after which metrics of a source are evicted
+	IntervalMultiplier float64
+
+	// TTL of a source when its sample interval is unknown (single message received)
+	DefaultTTL time.Duration
+
+	// Per YANG encoding path TTL overriding the observed sample interval
+	PathTTL map[string]time.Duration
+
+	// Interval between two checks for stale metrics
+	CheckInterval time.Duration
+}
+
+// ExpirySettingsFromConfig builds the stale metrics eviction settings from the metrics.ttl configuration section
+func ExpirySettingsFromConfig(c config.TTL) ExpirySettings {
+
+	s := ExpirySettings{
+		IntervalMultiplier: c.Multiplier,
+		DefaultTTL:         c.Default,
+		PathTTL:            make(map[string]time.Duration),
+		CheckInterval:      c.CheckInterval,
+	}
+
+	if s.CheckInterval == 0 {
+		s.CheckInterval = ttlFraction(c, minExpiryCheckInterval, maxExpiryCheckInterval)
+	}
+
+	for _, pathTTL := range c.Paths {
+
+		path, d, err := config.ParsePathTTL(pathTTL)
+
+		if err != nil {
+			logging.PeppaMonLog("warning", "Invalid metrics TTL path override %v. Ignoring it", err)
+			continue
+		}
+
+		s.PathTTL[path] = d
+	}
+
+	return s
+}
+
+// ttlFraction returns a tenth of the shortest TTL of the settings bounded by min and max, so the tasks on the
+// metrics cache run several times within a TTL
+func ttlFraction(c config.TTL, min time.Duration, max time.Duration) time.Duration {
+
+	d := c.Shortest() / 10
+
+	if d < min {
+		return min
+	}
+
+	if d > max {
+		return max
+	}
+
+	return d
+}
+
+// ttl returns the duration after which metrics of a source are considered stale
+func (s ExpirySettings) ttl(src Source, sm *SourceMetrics) time.Duration {
+
+	if d, ok := s.PathTTL[src.Path]; ok {
+		return d
+	}
+
+	if sm.SampleInterval <= 0 {
+		return s.DefaultTTL
+	}
+
+	return time.Duration(float64(sm.SampleInterval) * s.IntervalMultiplier)
+}
+
+// StartExpiry periodically evicts the metrics of sources the devices stopped streaming until the context is cancelled
+func (p *PrometheusSink) StartExpiry(ctx context.Context, s ExpirySettings) {
+
+	ticker := time.NewTicker(s.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.evictStale(s, time.Now())
+		}
+	}
+}
+
+func (p *PrometheusSink) evictStale(s ExpirySettings, now time.Time) {
+
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	for src, sm := range p.Metrics {
+
+		ttl := s.ttl(src, sm)
+
+		if now.Sub(sm.LastUpdate) <= ttl {
+			continue
+		}
+
+		delete(p.Metrics, src)
+		p.evictions.WithLabelValues(src.Path).Inc()
+
+		logging.PeppaMonLog("warning",
+			"Evicted stale metrics of Node %v for YANG path %v. No Telemetry message received for %v",
+			src.NodeID, src.Path, now.Sub(sm.LastUpdate).Round(time.Second))
+	}
+}
+
+// smoothSampleInterval returns the moving average of the interval between two collection rounds of a source
+func smoothSampleInterval(previous time.Duration, observed time.Duration) time.Duration {
+
+	if previous <= 0 {
+		return observed
+	}
+
+	return (3*previous + observed) / 4
+}
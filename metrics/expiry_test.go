@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+)
+
+func TestExpirySettingsCheckInterval(t *testing.T) {
+
+	tests := []struct {
+		name string
+		ttl  config.TTL
+		want time.Duration
+	}{
+		{
+			name: "configured",
+			ttl:  config.TTL{Default: 10 * time.Minute, CheckInterval: 5 * time.Second},
+			want: 5 * time.Second,
+		},
+		{
+			name: "derived from the default TTL up to the maximum",
+			ttl:  config.TTL{Default: 10 * time.Minute},
+			want: 30 * time.Second,
+		},
+		{
+			name: "derived from the default TTL",
+			ttl:  config.TTL{Default: 2 * time.Minute},
+			want: 12 * time.Second,
+		},
+		{
+			name: "derived from the shortest path TTL",
+			ttl:  config.TTL{Default: 10 * time.Minute, Paths: []string{"a:b=1m", "c:d=20m", "e:f"}},
+			want: 6 * time.Second,
+		},
+		{
+			name: "derived from a short TTL down to the minimum",
+			ttl:  config.TTL{Default: 10 * time.Minute, Paths: []string{"a:b=5s"}},
+			want: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpirySettingsFromConfig(tt.ttl).CheckInterval; got != tt.want {
+				t.Errorf("ExpirySettingsFromConfig() check interval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
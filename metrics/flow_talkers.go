@@ -11,7 +11,7 @@ import (
 )
 
 var (
-	flowTalkerStatsBytes = prometheus.NewDesc(
+	flowTalkerStatsBytes = newDesc(
 		"cisco_iosxe_flexible_netflow_record_bytes",
 		"The number of bytes passed through the netflow record",
 		[]string{
@@ -27,10 +27,9 @@ var (
 			"ip_protocol",
 			"interface_output",
 		},
-		nil,
 	)
 
-	flowTalkerStatsPackets = prometheus.NewDesc(
+	flowTalkerStatsPackets = newDesc(
 		"cisco_iosxe_flexible_netflow_record_packets",
 		"The number of packets passed through the netflow record",
 		[]string{
@@ -46,7 +45,6 @@ var (
 			"ip_protocol",
 			"interface_output",
 		},
-		nil,
 	)
 )
 
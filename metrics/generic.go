@@ -0,0 +1,367 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix of the metrics decoded by the generic decoder
+	genericMetricPrefix = "cisco_generic"
+
+	// Default maximum number of series decoded from a single Telemetry message
+	defaultGenericMaxSeries = 10000
+)
+
+var invalidMetricNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// GenericSettings represents the settings of the generic decoder flattening the kvGPB rows of unsupported
+// YANG encoding paths into gauges
+type GenericSettings struct {
+	// Patterns of the leaves to decode. All leaves when empty
+	Allow []string
+
+	// Patterns of the leaves never decoded. Deny wins over Allow
+	Deny []string
+
+	// Maximum number of series decoded from a single Telemetry message
+	MaxSeries int
+}
+
+// GenericSettingsFromConfig builds the generic decoder settings from the metrics.generic configuration section.
+// Patterns match the YANG encoding path followed by the leaf path within the row content,
+// i.e. Cisco-IOS-XE-foo-oper:foo/bar/statistics/in-octets, and * matches any sequence of characters.
+func GenericSettingsFromConfig(c config.Generic) GenericSettings {
+
+	return GenericSettings{
+		Allow:     c.Allow,
+		Deny:      c.Deny,
+		MaxSeries: c.MaxSeries,
+	}
+}
+
+// GenericDecoder flattens the kvGPB rows of any YANG encoding path into gauges.
+// Leaves of the keys subtree become labels and numeric leaves of the content subtree become gauges named
+// after the sanitised encoding path and leaf path. String leaves of nested containers label the numeric leaves
+// of their container, as kvGPB does not tell the entries of a YANG list nested in the row from a container.
+type GenericDecoder struct {
+	allow     []*regexp.Regexp
+	deny      []*regexp.Regexp
+	maxSeries int
+
+	// Literal part of the allow patterns before their first wildcard
+	allowPrefixes []string
+
+	// Descriptors by metric name and label names
+	mu    *sync.Mutex
+	descs map[string]*prometheus.Desc
+}
+
+// genericLabel represents a label of the flattened leaves
+type genericLabel struct {
+	name  string
+	value string
+}
+
+// NewGenericDecoder returns a generic decoder applying the allow and deny lists of the settings
+func NewGenericDecoder(s GenericSettings) (*GenericDecoder, error) {
+
+	g := &GenericDecoder{
+		maxSeries: s.MaxSeries,
+		mu:        &sync.Mutex{},
+		descs:     make(map[string]*prometheus.Desc),
+	}
+
+	if g.maxSeries <= 0 {
+		g.maxSeries = defaultGenericMaxSeries
+	}
+
+	var err error
+
+	if g.allow, err = compilePatterns(s.Allow); err != nil {
+		return nil, err
+	}
+
+	for _, p := range s.Allow {
+		g.allowPrefixes = append(g.allowPrefixes, strings.SplitN(p, "*", 2)[0])
+	}
+
+	if g.deny, err = compilePatterns(s.Deny); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// compilePatterns converts the patterns where * matches any sequence of characters into regular expressions
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+
+	res := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, p := range patterns {
+
+		re, err := regexp.Compile("^" + strings.Replace(regexp.QuoteMeta(p), `\*`, ".*", -1) + "$")
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid generic decoder pattern %v: %v", p, err)
+		}
+		res = append(res, re)
+	}
+
+	return res, nil
+}
+
+// allowed returns whether the leaf path is decoded
+func (g *GenericDecoder) allowed(leafPath string) bool {
+
+	for _, re := range g.deny {
+		if re.MatchString(leafPath) {
+			return false
+		}
+	}
+
+	if len(g.allow) == 0 {
+		return true
+	}
+
+	for _, re := range g.allow {
+		if re.MatchString(leafPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decodes returns whether some leaves of the YANG encoding path may be decoded,
+// that is the path is not denied as a whole and an allow pattern may match its leaves
+func (g *GenericDecoder) Decodes(encodingPath string) bool {
+
+	for _, re := range g.deny {
+		if re.MatchString(encodingPath) || re.MatchString(encodingPath+"/") {
+			return false
+		}
+	}
+
+	if len(g.allowPrefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range g.allowPrefixes {
+		if strings.HasPrefix(encodingPath, prefix) || strings.HasPrefix(prefix, encodingPath+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RecordMetricFunc is the parser of the YANG encoding paths handed over to the generic decoder
+func (g *GenericDecoder) RecordMetricFunc(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics, t time.Time, node string) {
+
+	encodingPath := msg.GetEncodingPath()
+	metricPrefix := genericMetricPrefix + "_" + sanitizeMetricName(encodingPath)
+
+	series := 0
+	truncated := false
+
+	emit := func(name string, labels []genericLabel, v float64) bool {
+
+		if series >= g.maxSeries {
+			truncated = true
+			return false
+		}
+		series++
+
+		g.createSample(name, encodingPath, labels, v, dm, t)
+
+		return true
+	}
+
+	for _, row := range msg.DataGpbkv {
+
+		// kvGPB rows are made of the keys and content containers
+		if len(row.Fields) < 2 {
+			continue
+		}
+
+		labels := []genericLabel{{name: "node", value: node}}
+		labels = appendKeyLabels(labels, "", row.Fields[0].Fields)
+
+		g.walk(row.Fields[1].Fields, encodingPath, "", metricPrefix, labels, emit)
+	}
+
+	if truncated {
+		logging.PeppaMonLog(
+			"warning",
+			"Generic decoder reached the limit of %v series for node %v YANG Node Path %v. Skipping remaining leaves",
+			g.maxSeries, node, encodingPath)
+	}
+}
+
+// walk flattens the content fields and calls emit for every allowed numeric leaf until emit returns false
+func (g *GenericDecoder) walk(
+	fields []*telemetry.TelemetryField,
+	leafPrefix string,
+	namePrefix string,
+	metricPrefix string,
+	labels []genericLabel,
+	emit func(name string, labels []genericLabel, v float64) bool) bool {
+
+	for _, f := range fields {
+
+		leafPath := leafPrefix + "/" + f.GetName()
+		name := joinName(namePrefix, f.GetName())
+
+		if len(f.Fields) > 0 {
+
+			// Every container may be a YANG list entry. Labelling them all keeps the label set of a list
+			// stable whatever its number of entries
+			entryLabels := appendListLabels(labels, name, f.Fields)
+
+			if !g.walk(f.Fields, leafPath, name, metricPrefix, entryLabels, emit) {
+				return false
+			}
+			continue
+		}
+
+		v, ok := extractGPBKVNativeTypeFromOneof(f, true).(float64)
+
+		if !ok || !g.allowed(leafPath) {
+			continue
+		}
+
+		if !emit(metricPrefix+"_"+sanitizeMetricName(name), labels, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appendKeyLabels adds the leaves of the keys subtree to the labels
+func appendKeyLabels(labels []genericLabel, prefix string, fields []*telemetry.TelemetryField) []genericLabel {
+
+	for _, f := range fields {
+
+		name := joinName(prefix, f.GetName())
+
+		if len(f.Fields) > 0 {
+			labels = appendKeyLabels(labels, name, f.Fields)
+			continue
+		}
+		labels = append(labels, genericLabel{name: sanitizeMetricName(name), value: genericLabelValue(f)})
+	}
+
+	return labels
+}
+
+// appendListLabels adds the string leaves of a YANG list entry to a copy of the labels
+func appendListLabels(labels []genericLabel, prefix string, fields []*telemetry.TelemetryField) []genericLabel {
+
+	entryLabels := make([]genericLabel, len(labels), len(labels)+len(fields))
+	copy(entryLabels, labels)
+
+	for _, f := range fields {
+
+		s, ok := f.ValueByType.(*telemetry.TelemetryField_StringValue)
+
+		if !ok || len(f.Fields) > 0 {
+			continue
+		}
+
+		entryLabels = append(entryLabels, genericLabel{
+			name:  sanitizeMetricName(joinName(prefix, f.GetName())),
+			value: s.StringValue,
+		})
+	}
+
+	return entryLabels
+}
+
+func genericLabelValue(f *telemetry.TelemetryField) string {
+
+	switch v := extractGPBKVNativeTypeFromOneof(f, false).(type) {
+	case string:
+		if v != "" {
+			return v
+		}
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return labelDefaultValue
+}
+
+// createSample appends the gauge sample. Metrics get a descriptor per label set as the string leaves
+// labelling the list entries may differ between entries
+func (g *GenericDecoder) createSample(
+	name string,
+	encodingPath string,
+	labels []genericLabel,
+	v float64,
+	dm *DeviceGroupedMetrics,
+	t time.Time) {
+
+	labelNames := make([]string, 0, len(labels))
+	values := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+
+	// Keys and list entries labels never override the labels of their parents
+	for _, l := range labels {
+		if !seen[l.name] {
+			seen[l.name] = true
+			labelNames = append(labelNames, l.name)
+			values = append(values, l.value)
+		}
+	}
+
+	k := name + "\xff" + strings.Join(labelNames, "\xff")
+
+	g.mu.Lock()
+
+	desc, ok := g.descs[k]
+
+	if !ok {
+		desc = newDesc(name, fmt.Sprintf("Generic decoding of YANG path %v", encodingPath), labelNames)
+		g.descs[k] = desc
+	}
+
+	g.mu.Unlock()
+
+	CreatePromMetric(v, desc, prometheus.GaugeValue, dm, t, values...)
+}
+
+func joinName(prefix string, name string) string {
+
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "_" + name
+}
+
+// sanitizeMetricName converts a YANG path into a valid Prometheus metric or label name.
+// Leading underscores are trimmed as names starting with __ are reserved for Prometheus internal use.
+func sanitizeMetricName(s string) string {
+
+	s = strings.Trim(invalidMetricNameChars.ReplaceAllString(strings.ToLower(s), "_"), "_")
+
+	// Names cannot start with a digit
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		s = "_" + s
+	}
+
+	return s
+}
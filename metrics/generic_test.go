@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+func TestSanitizeMetricName(t *testing.T) {
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "in-octets", want: "in_octets"},
+		{in: "Cisco-IOS-XE-foo-oper:foo/bar", want: "cisco_ios_xe_foo_oper_foo_bar"},
+		{in: "__name__", want: "name"},
+		{in: "_/__reserved", want: "reserved"},
+		{in: "5-min", want: "_5_min"},
+		{in: "-5-min", want: "_5_min"},
+		{in: "", want: "_"},
+		{in: "::", want: "_"},
+	}
+
+	for _, tt := range tests {
+
+		got := sanitizeMetricName(tt.in)
+
+		if got != tt.want {
+			t.Errorf("sanitizeMetricName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+
+		if strings.HasPrefix(got, "__") {
+			t.Errorf("sanitizeMetricName(%q) = %q starts with the reserved __ prefix", tt.in, got)
+		}
+	}
+}
+
+func stringField(name string, v string) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_StringValue{StringValue: v}}
+}
+
+func uintField(name string, v uint64) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_Uint64Value{Uint64Value: v}}
+}
+
+func containerField(name string, fields ...*telemetry.TelemetryField) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, Fields: fields}
+}
+
+func TestGenericDecoderWalkLabels(t *testing.T) {
+
+	const prefix = "cisco_generic_test"
+
+	tests := []struct {
+		name   string
+		fields []*telemetry.TelemetryField
+		want   []string
+	}{
+		{
+			name:   "leaf",
+			fields: []*telemetry.TelemetryField{uintField("in-octets", 1)},
+			want:   []string{`cisco_generic_test_in_octets{node="r1"}`},
+		},
+		{
+			name: "single list entry",
+			fields: []*telemetry.TelemetryField{
+				containerField("queue", stringField("name", "q0"), uintField("drops", 1)),
+			},
+			want: []string{`cisco_generic_test_queue_drops{node="r1",queue_name="q0"}`},
+		},
+		{
+			name: "list entries",
+			fields: []*telemetry.TelemetryField{
+				containerField("queue", stringField("name", "q0"), uintField("drops", 1)),
+				containerField("queue", stringField("name", "q1"), uintField("drops", 2)),
+			},
+			want: []string{
+				`cisco_generic_test_queue_drops{node="r1",queue_name="q0"}`,
+				`cisco_generic_test_queue_drops{node="r1",queue_name="q1"}`,
+			},
+		},
+		{
+			name: "numeric strings are labels",
+			fields: []*telemetry.TelemetryField{
+				containerField("queue", stringField("name", "q0"), stringField("depth", "12"), uintField("drops", 1)),
+			},
+			want: []string{
+				`cisco_generic_test_queue_drops{node="r1",queue_name="q0",queue_depth="12"}`,
+			},
+		},
+		{
+			name: "nested list entries",
+			fields: []*telemetry.TelemetryField{
+				containerField("policy",
+					stringField("name", "p0"),
+					containerField("class", stringField("name", "c0"), uintField("matches", 1)),
+					containerField("class", stringField("name", "c1"), uintField("matches", 2)),
+				),
+			},
+			want: []string{
+				`cisco_generic_test_policy_class_matches{node="r1",policy_name="p0",policy_class_name="c0"}`,
+				`cisco_generic_test_policy_class_matches{node="r1",policy_name="p0",policy_class_name="c1"}`,
+			},
+		},
+		{
+			name: "labels of an entry only",
+			fields: []*telemetry.TelemetryField{
+				containerField("queue", stringField("name", "q0"), uintField("drops", 1)),
+				containerField("queue", uintField("drops", 2)),
+			},
+			want: []string{
+				`cisco_generic_test_queue_drops{node="r1",queue_name="q0"}`,
+				`cisco_generic_test_queue_drops{node="r1"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			g, err := NewGenericDecoder(GenericSettings{})
+
+			if err != nil {
+				t.Fatalf("NewGenericDecoder() error = %v", err)
+			}
+
+			dm := &DeviceGroupedMetrics{Mutex: &sync.Mutex{}}
+
+			emit := func(name string, labels []genericLabel, v float64) bool {
+				g.createSample(name, "Cisco-IOS-XE-test:data", labels, v, dm, time.Unix(0, 0))
+				return true
+			}
+
+			g.walk(tt.fields, "", "", prefix, []genericLabel{{name: "node", value: "r1"}}, emit)
+
+			var got []string
+
+			for _, s := range dm.Samples {
+
+				labels := make([]string, len(s.Labels))
+
+				for i, l := range s.Labels {
+					labels[i] = l.Name + `="` + l.Value + `"`
+				}
+
+				got = append(got, s.Name+"{"+strings.Join(labels, ",")+"}")
+			}
+
+			sort.Strings(got)
+			sort.Strings(tt.want)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("walk() series = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -111,24 +111,27 @@ func parseDeviceHardwareMsg(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics,
 
 	}
 
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
+
+	if db == nil {
+		return
+	}
+
 	if len(hwObjSlice) > 0 {
-		go func() {
-			err := metadb.DBInstance.PersistsDeviceHWInventory(hwObjSlice, node)
-			if err != nil {
-				logging.PeppaMonLog("error",
-					"Failed to insert Device Hardware inventory data into DB: %v for Node %v", err, node)
-			}
-		}()
+		err := db.PersistsDeviceHWInventory(hwObjSlice, node)
+		if err != nil {
+			logging.PeppaMonLog("error",
+				"Failed to insert Device Hardware inventory data into DB: %v for Node %v", err, node)
+		}
 	}
 
 	if len(sysObjSlice) > 0 {
-		go func() {
-			err := metadb.DBInstance.PersistsDeviceSYSData(sysObjSlice, node)
-			if err != nil {
-				logging.PeppaMonLog("error",
-					"Failed to insert Device System data into DB: %v for Node %v", err, node)
-			}
-		}()
+		err := db.PersistsDeviceSYSData(sysObjSlice, node)
+		if err != nil {
+			logging.PeppaMonLog("error",
+				"Failed to insert Device System data into DB: %v for Node %v", err, node)
+		}
 	}
 
 }
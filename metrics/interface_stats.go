@@ -11,102 +11,88 @@ import (
 )
 
 var (
-	ifStatsInOctets = prometheus.NewDesc(
+	ifStatsInOctets = newDesc(
 		"cisco_iosxe_if_stats_in_octets",
 		"The number of inbound octets processed by the interface",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsOutOctets = prometheus.NewDesc(
+	ifStatsOutOctets = newDesc(
 		"cisco_iosxe_if_stats_out_octets",
 		"The number of outbound octets processed by the interface",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsNumFlaps = prometheus.NewDesc(
+	ifStatsNumFlaps = newDesc(
 		"cisco_iosxe_if_stats_num_flaps",
 		"The number of times the interface state transitioned between up and down",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsCRCErrorsIn = prometheus.NewDesc(
+	ifStatsCRCErrorsIn = newDesc(
 		"cisco_iosxe_if_stats_num_crc_errors",
 		"Number of receive error events due to FCS/CRC check failure",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsOutDiscardPkts = prometheus.NewDesc(
+	ifStatsOutDiscardPkts = newDesc(
 		"cisco_iosxe_if_stats_out_discard_packets",
 		"Number of outbound packets discarded",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsInDiscardPkts = prometheus.NewDesc(
+	ifStatsInDiscardPkts = newDesc(
 		"cisco_iosxe_if_stats_in_discard_packets",
 		"Number of inbound packets discarded",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsOutErrorPkts = prometheus.NewDesc(
+	ifStatsOutErrorPkts = newDesc(
 		"cisco_iosxe_if_stats_out_error_packets",
 		"Number of outbound packets that container errors",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsInErrorPkts = prometheus.NewDesc(
+	ifStatsInErrorPkts = newDesc(
 		"cisco_iosxe_if_stats_in_error_packets",
 		"Number of inbound packets that container errors",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsOutBroadcastPkts = prometheus.NewDesc(
+	ifStatsOutBroadcastPkts = newDesc(
 		"cisco_iosxe_if_stats_out_broadcast_packets",
 		"Number of outbound broadcast packets processed",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsInBroadcastPkts = prometheus.NewDesc(
+	ifStatsInBroadcastPkts = newDesc(
 		"cisco_iosxe_if_stats_in_broadcast_packets",
 		"Number of inbound broadcast packets processed",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsOutUnicastPkts = prometheus.NewDesc(
+	ifStatsOutUnicastPkts = newDesc(
 		"cisco_iosxe_if_stats_out_unicast_packets",
 		"Number of outbound unicast packets processed",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsInUnicastPkts = prometheus.NewDesc(
+	ifStatsInUnicastPkts = newDesc(
 		"cisco_iosxe_if_stats_in_unicast_packets",
 		"Number of inbound unicast packets processed",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsOutMulticastPkts = prometheus.NewDesc(
+	ifStatsOutMulticastPkts = newDesc(
 		"cisco_iosxe_if_stats_out_multicast_packets",
 		"Number of outbound multicast packets processed",
 		[]string{"node", "interface"},
-		nil,
 	)
 
-	ifStatsInMulticastPkts = prometheus.NewDesc(
+	ifStatsInMulticastPkts = newDesc(
 		"cisco_iosxe_if_stats_in_multicast_packets",
 		"Number of inbound multicast packets processed",
 		[]string{"node", "interface"},
-		nil,
 	)
 )
 
@@ -544,7 +530,7 @@ func ParsePBMsgInterfaceStats(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics
 			}
 			if m.GetName() == "diffserv-info" {
 
-				go instrumentQoSStats(m.Fields, interfaceName, nodeName, dm, t)
+				instrumentQoSStats(m.Fields, interfaceName, nodeName, dm, t)
 
 			}
 
@@ -552,17 +538,21 @@ func ParsePBMsgInterfaceStats(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics
 		ifMetaSlice = append(ifMetaSlice, ifMeta)
 	}
 
-	// Persist Interface Metadata in Meta DB within a separate goroutine
-	go func() {
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
 
-		err := metadb.DBInstance.PersistsInterfaceMetadata(ifMetaSlice, node)
+	if db == nil {
+		return
+	}
 
-		if err != nil {
-			logging.PeppaMonLog(
-				"error",
-				"failed to insert interface metadata in DB for node %v, error: %v", node, err)
-		}
-	}()
+	// Queue Interface Metadata persistence in Meta DB
+	err := db.PersistsInterfaceMetadata(ifMetaSlice, node)
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"error",
+			"failed to insert interface metadata in DB for node %v, error: %v", node, err)
+	}
 }
 
 func recordInterfaceMeta(fields []*telemetry.TelemetryField, ifName string, node string, t time.Time) map[string]interface{} {
@@ -8,25 +8,22 @@ import (
 )
 
 var (
-	cpu5Sec = prometheus.NewDesc(
+	cpu5Sec = newDesc(
 		"cisco_iosxe_iosd_cpu_busy_5_sec_percentage",
 		"The IOSd daemon CPU busy percentage over the last 5 seconds",
 		[]string{"node"},
-		nil, // constant labels
 	)
 
-	cpu1Min = prometheus.NewDesc(
+	cpu1Min = newDesc(
 		"cisco_iosxe_iosd_cpu_busy_1_min_percentage",
 		"The IOSd daemon CPU busy percentage over the last minute",
 		[]string{"node"},
-		nil,
 	)
 
-	cpu5Min = prometheus.NewDesc(
+	cpu5Min = newDesc(
 		"cisco_iosxe_iosd_cpu_busy_5_min_percentage",
 		"The IOSd daemon CPU busy percentage over the last 5 minutes",
 		[]string{"node"},
-		nil,
 	)
 )
 
@@ -112,6 +109,6 @@ func ParsePBMsgCPUBusyPercent(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics
 		}
 
 	}
-	// Insert CPU Processes Usage Metadata in Batch SQL query
-	go recordCPUProcMeta(ProcCPUSlice, node)
+	// Queue CPU Processes Usage Metadata persistence
+	recordCPUProcMeta(ProcCPUSlice, node)
 }
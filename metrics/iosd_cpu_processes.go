@@ -68,7 +68,14 @@ func parseCPUProcMeta(fields []*telemetry.TelemetryField, node string, t time.Ti
 
 func recordCPUProcMeta(p []map[string]interface{}, node string) {
 
-	err := metadb.DBInstance.PersistsCPUProcMetadata(p)
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
+
+	if db == nil {
+		return
+	}
+
+	err := db.PersistsCPUProcMetadata(p)
 
 	if err != nil {
 		logging.PeppaMonLog(
@@ -77,7 +77,15 @@ func parseMemoryProcMeta(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics, t t
 		}
 		ProcMemObjSlice = append(ProcMemObjSlice, ProcMemObj)
 	}
-	err := metadb.DBInstance.PersistsMemProcMetadata(ProcMemObjSlice)
+
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
+
+	if db == nil {
+		return
+	}
+
+	err := db.PersistsMemProcMetadata(ProcMemObjSlice)
 
 	if err != nil {
 		logging.PeppaMonLog(
@@ -8,25 +8,22 @@ import (
 )
 
 var (
-	iosdTotalMemory = prometheus.NewDesc(
+	iosdTotalMemory = newDesc(
 		"cisco_iosxe_iosd_total_memory_bytes",
 		"The IOSd daemon total memory",
 		[]string{"node"},
-		nil,
 	)
 
-	iosdUsedMemory = prometheus.NewDesc(
+	iosdUsedMemory = newDesc(
 		"cisco_iosxe_iosd_used_memory_bytes",
 		"The IOSd daemon used memory",
 		[]string{"node"},
-		nil,
 	)
 
-	iosdFreeMemory = prometheus.NewDesc(
+	iosdFreeMemory = newDesc(
 		"cisco_iosxe_iosd_free_memory_bytes",
 		"The IOSd daemon free memory",
 		[]string{"node"},
-		nil,
 	)
 )
 
@@ -185,16 +185,21 @@ func parseIPSlaConfigPB(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics, t ti
 		}
 		IPSLAConfigSlice = append(IPSLAConfigSlice, IPSLAConfig)
 	}
-	go func() {
-		if len(IPSLAConfigSlice) > 0 {
-			err := metadb.DBInstance.PersistsIPSlaConfigMetadata(IPSLAConfigSlice, node)
 
-			if err != nil {
-				logging.PeppaMonLog("error",
-					"Failed to insert IP SLA Config metadata into DB: %v for Node %v", err, node)
-			}
-		}
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
+
+	if db == nil {
+		return
+	}
 
-	}()
+	if len(IPSLAConfigSlice) > 0 {
+		err := db.PersistsIPSlaConfigMetadata(IPSLAConfigSlice, node)
+
+		if err != nil {
+			logging.PeppaMonLog("error",
+				"Failed to insert IP SLA Config metadata into DB: %v for Node %v", err, node)
+		}
+	}
 
 }
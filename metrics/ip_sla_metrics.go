@@ -125,203 +125,174 @@ const (
 )
 
 var (
-	ipSLAProbeRTT = prometheus.NewDesc(
+	ipSLAProbeRTT = newDesc(
 		"cisco_iosxe_ip_sla_probe_rtt_msec",
 		"The IP SLA probe reported Round Trip Time in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeFailureCount = prometheus.NewDesc(
+	ipSLAProbeFailureCount = newDesc(
 		"cisco_iosxe_ip_sla_probe_failure_count",
 		"The IP SLA probe failure count",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeSuccessCount = prometheus.NewDesc(
+	ipSLAProbeSuccessCount = newDesc(
 		"cisco_iosxe_ip_sla_probe_success_count",
 		"The IP SLA probe success count",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeLatestReturnCode = prometheus.NewDesc(
+	ipSLAProbeLatestReturnCode = newDesc(
 		"cisco_iosxe_ip_sla_probe_latest_return_code",
 		"The IP SLA Latest Return Code",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeLatestOperTime = prometheus.NewDesc(
+	ipSLAProbeLatestOperTime = newDesc(
 		"cisco_iosxe_ip_sla_probe_latest_operation_time_epoch",
 		"The IP SLA Latest Operation start in epoch time",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbePacketLossSD = prometheus.NewDesc(
+	ipSLAProbePacketLossSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_packet_loss_sd",
 		"The IP SLA probe packet loss source to destination",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbePacketLossDS = prometheus.NewDesc(
+	ipSLAProbePacketLossDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_packet_loss_ds",
 		"The IP SLA probe packet loss count destination to source",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayLatencyMinSD = prometheus.NewDesc(
+	ipSLAProbeOneWayLatencyMinSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_min_latency_sd_msec",
 		"The IP SLA probe minimum one way latency source to destination in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayLatencyMinDS = prometheus.NewDesc(
+	ipSLAProbeOneWayLatencyMinDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_min_latency_ds_msec",
 		"The IP SLA probe minimum one way latency destination to source in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayLatencyAvgSD = prometheus.NewDesc(
+	ipSLAProbeOneWayLatencyAvgSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_avg_latency_sd_msec",
 		"The IP SLA probe average one way latency source to destination in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayLatencyAvgDS = prometheus.NewDesc(
+	ipSLAProbeOneWayLatencyAvgDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_avg_latency_ds_msec",
 		"The IP SLA probe average one way latency destination to source in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayLatencyMaxSD = prometheus.NewDesc(
+	ipSLAProbeOneWayLatencyMaxSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_max_latency_sd_msec",
 		"The IP SLA probe maximum one way latency source to destination in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayLatencyMaxDS = prometheus.NewDesc(
+	ipSLAProbeOneWayLatencyMaxDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_max_latency_ds_msec",
 		"The IP SLA probe maximum one way latency destination to source in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayJitterMinSD = prometheus.NewDesc(
+	ipSLAProbeOneWayJitterMinSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_min_jitter_sd_msec",
 		"The IP SLA probe minimum jitter source to destination in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayJitterMinDS = prometheus.NewDesc(
+	ipSLAProbeOneWayJitterMinDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_min_jitter_ds_msec",
 		"The IP SLA probe minimum jitter destination to source in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayJitterMaxDS = prometheus.NewDesc(
+	ipSLAProbeOneWayJitterMaxDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_max_jitter_ds_msec",
 		"The IP SLA probe maximum jitter destination to source in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
-	ipSLAProbeOneWayJitterMaxSD = prometheus.NewDesc(
+	ipSLAProbeOneWayJitterMaxSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_max_jitter_sd_msec",
 		"The IP SLA probe maximum jitter source to destination in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayJitterAvgSD = prometheus.NewDesc(
+	ipSLAProbeOneWayJitterAvgSD = newDesc(
 		"cisco_iosxe_ip_sla_probe_avg_jitter_sd_msec",
 		"The IP SLA probe average jitter source to destination in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeOneWayJitterAvgDS = prometheus.NewDesc(
+	ipSLAProbeOneWayJitterAvgDS = newDesc(
 		"cisco_iosxe_ip_sla_probe_avg_jitter_ds_msec",
 		"The IP SLA probe average jitter destination to source in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
-	ipSLAProbeHTTPStatusCode = prometheus.NewDesc(
+	ipSLAProbeHTTPStatusCode = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_status_code",
 		"The HTTP IP SLA probe Status Code",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
-	ipSLAProbeHTTPTransactionRTT = prometheus.NewDesc(
+	ipSLAProbeHTTPTransactionRTT = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_transaction_rtt_msec",
 		"The HTTP IP SLA probe Transaction Round Trip Time in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
-	ipSLAProbeHTTPDNSRTT = prometheus.NewDesc(
+	ipSLAProbeHTTPDNSRTT = newDesc(
 		"cisco_iosxe_ip_sla_probe_dns_rtt_msec",
 		"The HTTP IP SLA probe DNS lookup Round Trip Time in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPTCPRTT = prometheus.NewDesc(
+	ipSLAProbeHTTPTCPRTT = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_tcp_rtt_msec",
 		"The HTTP IP SLA probe TCP Connection Round Trip Time in milliseconds",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPTransactionError = prometheus.NewDesc(
+	ipSLAProbeHTTPTransactionError = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_transaction_error",
 		"The HTTP IP SLA probe number of HTTP transaction errors occurred",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPTCPError = prometheus.NewDesc(
+	ipSLAProbeHTTPTCPError = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_tcp_error",
 		"The HTTP IP SLA probe number of TCP errors occurred",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPDNSError = prometheus.NewDesc(
+	ipSLAProbeHTTPDNSError = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_dns_error",
 		"The HTTP IP SLA probe number of DNS errors occurred",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPTransactionTimeout = prometheus.NewDesc(
+	ipSLAProbeHTTPTransactionTimeout = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_transaction_timeout",
 		"The HTTP IP SLA probe number of HTTP transaction timeout occurred",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPTCPTimeout = prometheus.NewDesc(
+	ipSLAProbeHTTPTCPTimeout = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_tcp_timeout",
 		"The HTTP IP SLA probe number of TCP timeout occurred",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 
-	ipSLAProbeHTTPDNSTimeout = prometheus.NewDesc(
+	ipSLAProbeHTTPDNSTimeout = newDesc(
 		"cisco_iosxe_ip_sla_probe_http_dns_timeout",
 		"The HTTP IP SLA probe number of DNS timeout occurred",
 		[]string{"node", "sla_entry_id", "sla_type"},
-		nil,
 	)
 )
 
@@ -62,12 +62,18 @@ func parseDeviceLicenseInfo(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics,
 			licObj[yangDeviceLicenseBootLevel] = licBootLevel
 		}
 	}
-	go func() {
-		err := metadb.DBInstance.PersistsDeviceLicenseData(licObj, node)
-		if err != nil {
-			logging.PeppaMonLog("error",
-				"Failed to insert Device License data into DB: %v for Node %v", err, node)
-		}
-	}()
+
+	// Skip metadata persistence while the metadata DB is disabled or not connected
+	db := metadb.Instance()
+
+	if db == nil {
+		return
+	}
+
+	err := db.PersistsDeviceLicenseData(licObj, node)
+	if err != nil {
+		logging.PeppaMonLog("error",
+			"Failed to insert Device License data into DB: %v for Node %v", err, node)
+	}
 
 }
@@ -0,0 +1,302 @@
+package metrics
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+)
+
+// MetricMappingFile represents a declarative YANG path to Prometheus metrics mapping file.
+// Both YAML and JSON formats are accepted. Example:
+//
+//	mappings:
+//	  - encoding_path: Cisco-IOS-XE-ospf-oper:ospf-oper-data/ospfv2-instance/ospfv2-area/ospfv2-interface/ospfv2-neighbor
+//	    labels:
+//	      - name: neighbor_id
+//	        field: keys/nbr-id
+//	        transform: ipv4
+//	      - name: interface
+//	        field: keys/name
+//	    metrics:
+//	      - name: cisco_iosxe_ospf_adjacency_state
+//	        help: The current state of the OSPF adjacency
+//	        type: gauge
+//	        field: content/state
+//	        value_map:
+//	          ospf-nbr-down: 1
+//	          ospf-nbr-full: 8
+type MetricMappingFile struct {
+	Mappings []MetricMapping `yaml:"mappings" json:"mappings"`
+}
+
+// MetricMapping declares how the rows of a YANG encoding path are converted to metrics
+type MetricMapping struct {
+	EncodingPath string               `yaml:"encoding_path" json:"encoding_path"`
+	Labels       []MetricMappingLabel `yaml:"labels" json:"labels"`
+	Metrics      []MetricMappingLeaf  `yaml:"metrics" json:"metrics"`
+}
+
+// MetricMappingLabel declares a field of the Telemetry row used as metric label.
+// Field is the path of the field from the row. i.e. keys/name or content/vrf
+type MetricMappingLabel struct {
+	Name      string `yaml:"name" json:"name"`
+	Field     string `yaml:"field" json:"field"`
+	Default   string `yaml:"default" json:"default"`
+	Transform string `yaml:"transform" json:"transform"`
+}
+
+// MetricMappingLeaf declares a leaf of the Telemetry row exported as gauge or counter.
+// Leaves with string values (i.e. enumerations) must declare a value map.
+type MetricMappingLeaf struct {
+	Name     string             `yaml:"name" json:"name"`
+	Help     string             `yaml:"help" json:"help"`
+	Type     string             `yaml:"type" json:"type"`
+	Field    string             `yaml:"field" json:"field"`
+	ValueMap map[string]float64 `yaml:"value_map" json:"value_map"`
+
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+const (
+	// Label transform converting a base 10 integer into an IPv4 address
+	labelTransformIPv4 = "ipv4"
+
+	// Label value when field is missing from Telemetry message and no default is set
+	labelDefaultValue = "N/A"
+)
+
+// LoadMetricMappings reads a mapping file and registers a parser for each declared encoding path
+func LoadMetricMappings(file string) error {
+
+	b, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return err
+	}
+
+	var mf MetricMappingFile
+
+	if err := yaml.Unmarshal(b, &mf); err != nil {
+		return fmt.Errorf("unable to decode metric mapping file %v: %v", file, err)
+	}
+
+	for i := range mf.Mappings {
+
+		m := &mf.Mappings[i]
+
+		if err := m.validate(); err != nil {
+			return fmt.Errorf("invalid mapping #%d in file %v: %v", i+1, file, err)
+		}
+
+		for _, r := range CiscoMetricRegistrar {
+			if r.EncodingPath == m.EncodingPath {
+				logging.PeppaMonLog("warning",
+					"Metric mapping for YANG path %v is registered alongside a built-in parser", m.EncodingPath)
+				break
+			}
+		}
+
+		CiscoMetricRegistrar = append(CiscoMetricRegistrar, CiscoTelemetryMetric{
+			EncodingPath:     m.EncodingPath,
+			RecordMetricFunc: m.parseMsg,
+		})
+
+		logging.PeppaMonLog("info",
+			"Registered %d metric(s) from mapping file for YANG path %v", len(m.Metrics), m.EncodingPath)
+	}
+
+	return nil
+}
+
+func (m *MetricMapping) validate() error {
+
+	if m.EncodingPath == "" {
+		return fmt.Errorf("encoding_path is missing")
+	}
+
+	if len(m.Metrics) == 0 {
+		return fmt.Errorf("no metric declared for %v", m.EncodingPath)
+	}
+
+	// The node label is added to every metric
+	labelNames := []string{"node"}
+
+	for _, l := range m.Labels {
+
+		if l.Name == "" || l.Field == "" {
+			return fmt.Errorf("label name and field are mandatory for %v", m.EncodingPath)
+		}
+
+		if !config.ValidLabelName(l.Name) {
+			return fmt.Errorf("label %q is not a valid Prometheus label name", l.Name)
+		}
+
+		for _, name := range labelNames {
+			if name == l.Name {
+				return fmt.Errorf("label %v is reserved or declared more than once for %v", l.Name, m.EncodingPath)
+			}
+		}
+
+		if l.Transform != "" && l.Transform != labelTransformIPv4 {
+			return fmt.Errorf("unsupported label transform %v", l.Transform)
+		}
+		labelNames = append(labelNames, l.Name)
+	}
+
+	for i := range m.Metrics {
+
+		leaf := &m.Metrics[i]
+
+		if leaf.Name == "" || leaf.Field == "" {
+			return fmt.Errorf("metric name and field are mandatory for %v", m.EncodingPath)
+		}
+
+		if !config.ValidMetricName(leaf.Name) {
+			return fmt.Errorf("metric %q is not a valid Prometheus metric name", leaf.Name)
+		}
+
+		for _, other := range m.Metrics[:i] {
+			if other.Name == leaf.Name {
+				return fmt.Errorf("metric %v is declared more than once for %v", leaf.Name, m.EncodingPath)
+			}
+		}
+
+		switch strings.ToLower(leaf.Type) {
+		case "", "gauge":
+			leaf.valueType = prometheus.GaugeValue
+		case "counter":
+			leaf.valueType = prometheus.CounterValue
+		default:
+			return fmt.Errorf("unsupported metric type %v for metric %v", leaf.Type, leaf.Name)
+		}
+
+		if leaf.Help == "" {
+			leaf.Help = fmt.Sprintf("Value of YANG leaf %v/%v", m.EncodingPath, leaf.Field)
+		}
+
+		leaf.desc = newDesc(leaf.Name, leaf.Help, labelNames)
+	}
+
+	return nil
+}
+
+// parseMsg is the RecordMetricFunc of a declarative mapping
+func (m *MetricMapping) parseMsg(msg *telemetry.Telemetry, dm *DeviceGroupedMetrics, t time.Time, node string) {
+
+	for _, row := range msg.DataGpbkv {
+
+		labels := make([]string, 0, len(m.Labels)+1)
+		labels = append(labels, node)
+
+		for _, l := range m.Labels {
+			labels = append(labels, l.value(row))
+		}
+
+		for _, leaf := range m.Metrics {
+
+			f := lookupField(row, leaf.Field)
+
+			if f == nil {
+				continue
+			}
+
+			val, ok := leaf.value(f)
+
+			if !ok {
+				continue
+			}
+
+			CreatePromMetric(val, leaf.desc, leaf.valueType, dm, t, labels...)
+		}
+	}
+}
+
+func (l MetricMappingLabel) value(row *telemetry.TelemetryField) string {
+
+	def := l.Default
+
+	if def == "" {
+		def = labelDefaultValue
+	}
+
+	f := lookupField(row, l.Field)
+
+	if f == nil {
+		return def
+	}
+
+	switch v := extractGPBKVNativeTypeFromOneof(f, false).(type) {
+	case string:
+		if v == "" {
+			return def
+		}
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		if l.Transform == labelTransformIPv4 {
+			return intToIP4(int64(v))
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return def
+}
+
+func (leaf MetricMappingLeaf) value(f *telemetry.TelemetryField) (float64, bool) {
+
+	switch v := extractGPBKVNativeTypeFromOneof(f, false).(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		if mapped, ok := leaf.ValueMap[v]; ok {
+			return mapped, true
+		}
+
+		// JSON encodings carry 64 bits integers as strings
+		if num, err := strconv.ParseFloat(v, 64); err == nil {
+			return num, true
+		}
+	}
+
+	return 0, false
+}
+
+// lookupField walks the Telemetry field tree following a slash separated path of field names
+func lookupField(f *telemetry.TelemetryField, path string) *telemetry.TelemetryField {
+
+	current := f
+
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+
+		var next *telemetry.TelemetryField
+
+		for _, child := range current.Fields {
+			if child.GetName() == name {
+				next = child
+				break
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+		current = next
+	}
+
+	return current
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMetricMappingValidate(t *testing.T) {
+
+	validLeaf := MetricMappingLeaf{Name: "cisco_iosxe_test_value", Field: "content/value"}
+
+	tests := []struct {
+		name    string
+		mapping MetricMapping
+		wantErr string
+	}{
+		{
+			name: "valid",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Labels:       []MetricMappingLabel{{Name: "interface", Field: "keys/name"}},
+				Metrics:      []MetricMappingLeaf{validLeaf},
+			},
+		},
+		{
+			name:    "missing encoding path",
+			mapping: MetricMapping{Metrics: []MetricMappingLeaf{validLeaf}},
+			wantErr: "encoding_path is missing",
+		},
+		{
+			name:    "no metric",
+			mapping: MetricMapping{EncodingPath: "Cisco-IOS-XE-test:data"},
+			wantErr: "no metric declared",
+		},
+		{
+			name: "invalid label name",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Labels:       []MetricMappingLabel{{Name: "if-name", Field: "keys/name"}},
+				Metrics:      []MetricMappingLeaf{validLeaf},
+			},
+			wantErr: "not a valid Prometheus label name",
+		},
+		{
+			name: "reserved label name",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Labels:       []MetricMappingLabel{{Name: "__name__", Field: "keys/name"}},
+				Metrics:      []MetricMappingLeaf{validLeaf},
+			},
+			wantErr: "not a valid Prometheus label name",
+		},
+		{
+			name: "node label",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Labels:       []MetricMappingLabel{{Name: "node", Field: "keys/name"}},
+				Metrics:      []MetricMappingLeaf{validLeaf},
+			},
+			wantErr: "reserved or declared more than once",
+		},
+		{
+			name: "duplicate label",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Labels: []MetricMappingLabel{
+					{Name: "interface", Field: "keys/name"},
+					{Name: "interface", Field: "content/name"},
+				},
+				Metrics: []MetricMappingLeaf{validLeaf},
+			},
+			wantErr: "reserved or declared more than once",
+		},
+		{
+			name: "unsupported transform",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Labels:       []MetricMappingLabel{{Name: "peer", Field: "keys/id", Transform: "ipv6"}},
+				Metrics:      []MetricMappingLeaf{validLeaf},
+			},
+			wantErr: "unsupported label transform",
+		},
+		{
+			name: "invalid metric name",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Metrics:      []MetricMappingLeaf{{Name: "1st_value", Field: "content/value"}},
+			},
+			wantErr: "not a valid Prometheus metric name",
+		},
+		{
+			name: "duplicate metric",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Metrics:      []MetricMappingLeaf{validLeaf, validLeaf},
+			},
+			wantErr: "declared more than once",
+		},
+		{
+			name: "unsupported metric type",
+			mapping: MetricMapping{
+				EncodingPath: "Cisco-IOS-XE-test:data",
+				Metrics:      []MetricMappingLeaf{{Name: "cisco_iosxe_test_value", Field: "content/value", Type: "histogram"}},
+			},
+			wantErr: "unsupported metric type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := tt.mapping.validate()
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate() unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
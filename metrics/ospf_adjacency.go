@@ -9,11 +9,10 @@ import (
 )
 
 var (
-	ospfAdjStatus = prometheus.NewDesc(
+	ospfAdjStatus = newDesc(
 		"cisco_iosxe_ospf_adjacency_status",
 		"The current state of the OSPF adjacency",
 		[]string{"node", "neighbor_id", "neighbor_ip", "ospf_instance_id", "interface", "area_id"},
-		nil,
 	)
 )
 
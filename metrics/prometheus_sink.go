@@ -0,0 +1,222 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusSink represents the metrics cache exposed on the Prometheus scrape endpoint.
+// It holds the last collection round of every Telemetry source.
+type PrometheusSink struct {
+	Mutex   *sync.Mutex
+	Metrics map[Source]*SourceMetrics
+
+	// Number of metrics cache entries evicted because the device stopped streaming the YANG path
+	evictions *prometheus.CounterVec
+}
+
+// SourceMetrics represents the cached metrics of a Telemetry source
+type SourceMetrics struct {
+	Metrics []prometheus.Metric
+
+	// Telemetry collection round the metrics belong to
+	CollectionID uint64
+
+	// Time the metrics were received and observed interval between two collection rounds of the same source
+	LastUpdate     time.Time
+	SampleInterval time.Duration
+
+	// Position of the series in Metrics to merge late messages of the collection round
+	index map[string]int
+}
+
+// NewPrometheusSink will create a new instance of the Prometheus metrics cache
+func NewPrometheusSink() *PrometheusSink {
+
+	mu := &sync.Mutex{}
+
+	return &PrometheusSink{
+		Mutex:   mu,
+		Metrics: make(map[Source]*SourceMetrics),
+		evictions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "peppamon_collector_stale_evictions_total",
+				Help: "The number of metrics cache entries evicted because the device stopped streaming the YANG path",
+			},
+			[]string{"path"},
+		),
+	}
+}
+
+// Publish replaces the metrics cache entry of the source with the collection round metrics
+func (p *PrometheusSink) Publish(r *Round) {
+
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	live, ok := p.Metrics[r.Source]
+
+	// Complete the collection round already cached
+	if r.Late && ok && live.CollectionID == r.CollectionID {
+		for _, s := range r.Samples {
+			live.add(s)
+		}
+		return
+	}
+
+	sm := &SourceMetrics{
+		Metrics:      make([]prometheus.Metric, 0, len(r.Samples)),
+		CollectionID: r.CollectionID,
+		LastUpdate:   time.Now(),
+		index:        make(map[string]int, len(r.Samples)),
+	}
+
+	for _, s := range r.Samples {
+		sm.add(s)
+	}
+
+	// If Metric cache key already exists, invalidate and remove it
+	// Otherwise dashboard may show arbitrary / constant values
+	// See https://stackoverflow.com/questions/57304563/prometheus-exporter-direct-instrumentation-vs-custom-collector
+	if ok {
+
+		// Keep track of the device sample interval to determine when the metrics become stale
+		sm.SampleInterval = smoothSampleInterval(live.SampleInterval, sm.LastUpdate.Sub(live.LastUpdate))
+	}
+
+	p.Metrics[r.Source] = sm
+}
+
+// add converts a sample to a Prometheus metric and adds it to the source metrics, replacing the same series
+func (sm *SourceMetrics) add(s Sample) {
+
+	desc, labelValues := s.desc, s.labelValues
+
+	// Samples not created by CreatePromMetric carry no descriptor
+	if desc == nil {
+
+		labelNames := make([]string, len(s.Labels))
+		labelValues = make([]string, len(s.Labels))
+
+		for i, l := range s.Labels {
+			labelNames[i] = l.Name
+			labelValues[i] = l.Value
+		}
+
+		desc = sampleDesc(s, labelNames)
+	}
+
+	m, err := prometheus.NewConstMetric(desc, s.Type, s.Value, labelValues...)
+
+	if err != nil {
+		logging.PeppaMonLog("error",
+			"Failed to create Prometheus metric %v: %v", s.Name, err)
+		return
+	}
+
+	m = prometheus.NewMetricWithTimestamp(s.Timestamp, m)
+
+	k := s.key()
+
+	if i, ok := sm.index[k]; ok {
+		sm.Metrics[i] = m
+		return
+	}
+
+	sm.index[k] = len(sm.Metrics)
+	sm.Metrics = append(sm.Metrics, m)
+}
+
+// Cache of the descriptors built from the samples labels, keyed by metric name, help and label names
+var sampleDescs sync.Map
+
+// sampleDesc returns the Prometheus descriptor of a sample not created by CreatePromMetric
+func sampleDesc(s Sample, labelNames []string) *prometheus.Desc {
+
+	help := s.Help
+
+	if help == "" {
+		help = s.Name
+	}
+
+	k := s.Name + "\xff" + help + "\xff" + strings.Join(labelNames, "\xff")
+
+	if desc, ok := sampleDescs.Load(k); ok {
+		return desc.(*prometheus.Desc)
+	}
+
+	desc := prometheus.NewDesc(s.Name, help, labelNames, nil)
+
+	sampleDescs.Store(k, desc)
+
+	return desc
+}
+
+// Remove will remove the metrics cache entry of a Telemetry Node / YANG path
+func (p *PrometheusSink) Remove(src Source) {
+
+	p.Mutex.Lock()
+	delete(p.Metrics, src)
+	p.Mutex.Unlock()
+}
+
+// Size returns the number of series held in the metrics cache
+func (p *PrometheusSink) Size() int {
+
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	n := 0
+
+	for _, sourceMetrics := range p.Metrics {
+		n += len(sourceMetrics.Metrics)
+	}
+
+	return n
+}
+
+// Describe method will write metrics descriptors within Prometheus Desc channel
+// and implements prometheus.Collector interface
+func (p *PrometheusSink) Describe(ch chan<- *prometheus.Desc) {
+
+	// Copy current descriptors in case consumer channel is slow
+	var metricDescriptors []*prometheus.Desc
+
+	p.Mutex.Lock()
+	for _, source := range p.Metrics {
+		for _, metric := range source.Metrics {
+			metricDescriptors = append(metricDescriptors, metric.Desc())
+		}
+	}
+	p.Mutex.Unlock()
+
+	p.evictions.Describe(ch)
+
+	for _, desc := range metricDescriptors {
+		ch <- desc
+	}
+}
+
+// Collect method implements prometheus.Collector interface and is executed upon each scrape
+// Telemetry Metric cache is sent to Prometheus channel consumer.
+func (p *PrometheusSink) Collect(ch chan<- prometheus.Metric) {
+
+	// Copy current metrics so we don't lock for very long if channel consumer is slow.
+	var metrics []prometheus.Metric
+
+	p.Mutex.Lock()
+	for _, sourceMetrics := range p.Metrics {
+		metrics = append(metrics, sourceMetrics.Metrics...)
+	}
+	p.Mutex.Unlock()
+
+	p.evictions.Collect(ch)
+
+	for _, metric := range metrics {
+		ch <- metric
+	}
+}
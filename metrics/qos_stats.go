@@ -10,32 +10,28 @@ import (
 
 // Declare Metrics Descriptors
 var (
-	ifStatsQoSClassMapClassifiedBytes = prometheus.NewDesc(
+	ifStatsQoSClassMapClassifiedBytes = newDesc(
 		"cisco_iosxe_qos_class_map_classfied_bytes",
 		"The number of total bytes which filtered to the classifier-entry",
 		[]string{"node", "interface", "direction", "policy_map", "class_map", "parent_path"},
-		nil,
 	)
 
-	ifStatsQoSClassMapQueueOutputBytes = prometheus.NewDesc(
+	ifStatsQoSClassMapQueueOutputBytes = newDesc(
 		"cisco_iosxe_qos_class_map_queued_bytes",
 		"The number of bytes transmitted from queue",
 		[]string{"node", "interface", "direction", "policy_map", "class_map", "parent_path"},
-		nil,
 	)
 
-	ifStatsQoSClassMapQueueSizeBytes = prometheus.NewDesc(
+	ifStatsQoSClassMapQueueSizeBytes = newDesc(
 		"cisco_iosxe_qos_class_map_queue_size_bytes",
 		"The number of bytes currently buffered",
 		[]string{"node", "interface", "direction", "policy_map", "class_map", "parent_path"},
-		nil,
 	)
 
-	ifStatsQoSClassMapQueueDropBytes = prometheus.NewDesc(
+	ifStatsQoSClassMapQueueDropBytes = newDesc(
 		"cisco_iosxe_qos_class_map_queue_drops_bytes",
 		"The total number of bytes dropped",
 		[]string{"node", "interface", "direction", "policy_map", "class_map", "parent_path"},
-		nil,
 	)
 
 	//ifStatsQoSClassMapMarkedPkts = promauto.NewGaugeVec(
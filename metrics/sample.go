@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Sample represents a normalised metric sample decoded from a Telemetry message
+type Sample struct {
+	Name      string
+	Help      string
+	Labels    []Label
+	Value     float64
+	Timestamp time.Time
+	Type      prometheus.ValueType
+
+	// Prometheus descriptor and label values the sample was created with
+	desc        *prometheus.Desc
+	labelValues []string
+}
+
+// Label represents a metric label name and value pair
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Round represents the samples of a Telemetry collection round published to the sinks
+type Round struct {
+	Source       Source
+	CollectionID uint64
+	Samples      []Sample
+
+	// Set when the samples complete a collection round already published
+	Late bool
+}
+
+// Sink represents an output receiving the samples of each published collection round.
+// Publish must not block as it is called while the Collector is locked.
+type Sink interface {
+	Publish(r *Round)
+	Remove(src Source)
+}
+
+// NodeLabeler represents a source of labels added to every sample of a node, such as the device registry
+// attributes. The same label names must be returned for every node so the metric families stay consistent.
+type NodeLabeler interface {
+	NodeLabels(node string) []Label
+}
+
+// MessageSink represents an output receiving every decoded Telemetry message, whether its YANG path is supported
+// or not. PublishMessage must not block the Telemetry stream.
+type MessageSink interface {
+	PublishMessage(msg *telemetry.Telemetry)
+}
+
+// AddMessageSink registers an output receiving every decoded Telemetry message
+func (c *Collector) AddMessageSink(s MessageSink) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.messageSinks = append(c.messageSinks, s)
+}
+
+// SetUnsupportedPaths sets the policy and counters applied to the messages of YANG encoding paths without parser
+func (c *Collector) SetUnsupportedPaths(u *UnsupportedPaths) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.unsupported = u
+}
+
+// SetGenericDecoder sets the decoder of the YANG encoding paths without parser applied with the passthrough policy
+func (c *Collector) SetGenericDecoder(g *GenericDecoder) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.generic = g
+}
+
+// SetNodeLabeler sets the source of the labels added to the samples of each node
+func (c *Collector) SetNodeLabeler(l NodeLabeler) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.nodeLabeler = l
+}
+
+// AddSink registers an output receiving the samples of every published collection round
+func (c *Collector) AddSink(s Sink) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.sinks = append(c.sinks, s)
+}
+
+// publish forwards a collection round to the sinks.
+// Collector Mutex must be held by the caller.
+func (c *Collector) publish(r *Round) {
+
+	for _, s := range c.sinks {
+		s.Publish(r)
+	}
+}
+
+// descInfo represents the metric name, help and label names of a Prometheus descriptor
+type descInfo struct {
+	name       string
+	help       string
+	labelNames []string
+}
+
+// Details of the descriptors created with newDesc as prometheus.Desc does not expose its name and labels
+var descInfos sync.Map
+
+// newDesc creates the Prometheus descriptor of a metric and records its name, help and label names
+// so the samples created with it can be rendered by every sink
+func newDesc(name string, help string, labelNames []string) *prometheus.Desc {
+
+	desc := prometheus.NewDesc(name, help, labelNames, nil)
+
+	descInfos.Store(desc, descInfo{name: name, help: help, labelNames: labelNames})
+
+	return desc
+}
+
+// lookupDescInfo returns the metric name, help and label names of a descriptor created with newDesc
+func lookupDescInfo(desc *prometheus.Desc) (descInfo, bool) {
+
+	info, ok := descInfos.Load(desc)
+
+	if !ok {
+		return descInfo{}, false
+	}
+
+	return info.(descInfo), true
+}
+
+// addNodeLabels appends the node labels to the samples, skipping the labels a sample already has
+func addNodeLabels(samples []Sample, nodeLabels []Label) {
+
+	if len(nodeLabels) == 0 {
+		return
+	}
+
+	for i := range samples {
+
+		s := &samples[i]
+
+		labels := make([]Label, len(s.Labels), len(s.Labels)+len(nodeLabels))
+		copy(labels, s.Labels)
+
+		for _, nl := range nodeLabels {
+			if !hasLabel(s.Labels, nl.Name) {
+				labels = append(labels, nl)
+			}
+		}
+
+		s.Labels = labels
+
+		// The descriptor no longer matches the labels, sinks build it from the sample
+		s.desc = nil
+		s.labelValues = nil
+	}
+}
+
+// hasLabel returns whether the label name is part of the labels
+func hasLabel(labels []Label, name string) bool {
+
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// key returns the identity of the series the sample belongs to
+func (s Sample) key() string {
+
+	var b strings.Builder
+
+	b.WriteString(s.Name)
+
+	for _, l := range s.Labels {
+		b.WriteByte(0xff)
+		b.WriteString(l.Name)
+		b.WriteByte('=')
+		b.WriteString(l.Value)
+	}
+
+	return b.String()
+}
+
+// dedupSamples removes the samples recorded more than once for the same series within a collection round,
+// keeping the most recent one. Prometheus rejects duplicate series within a scrape.
+func dedupSamples(samples []Sample) []Sample {
+
+	index := make(map[string]int, len(samples))
+	deduped := make([]Sample, 0, len(samples))
+
+	for _, s := range samples {
+
+		k := s.key()
+
+		if i, ok := index[k]; ok {
+			deduped[i] = s
+			continue
+		}
+
+		index[k] = len(deduped)
+		deduped = append(deduped, s)
+	}
+
+	return deduped
+}
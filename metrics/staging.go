@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+)
+
+// Bounds of the time after which a collection round whose last Telemetry message was not received is published
+// anyway when derived from the TTL settings
+const (
+	minCollectionRoundTimeout = time.Second
+	maxCollectionRoundTimeout = 5 * time.Second
+)
+
+// collectionRound represents the samples of a collection round being assembled from one or more Telemetry messages
+// sharing the same collection_id. It is published to the sinks once complete.
+type collectionRound struct {
+	id      uint64
+	samples []Sample
+	timer   *time.Timer
+}
+
+// CollectionRoundTimeoutFromConfig returns the collection round timeout of the metrics configuration section
+func CollectionRoundTimeoutFromConfig(c config.Metrics) time.Duration {
+
+	if c.CollectionRoundTimeout > 0 {
+		return c.CollectionRoundTimeout
+	}
+
+	return ttlFraction(c.TTL, minCollectionRoundTimeout, maxCollectionRoundTimeout)
+}
+
+// SetCollectionRoundTimeout sets the time after which a collection round missing its last message is published.
+// It applies to the collection rounds staged afterwards.
+func (c *Collector) SetCollectionRoundTimeout(d time.Duration) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.roundTimeout = d
+}
+
+// stageSamples adds the samples decoded from a Telemetry message to the collection round of the source.
+// The collection round is published when the message is the last of the round, which is flagged
+// by the collection_end_time, or when a message of a new collection round is received.
+// Messages without collection_id are considered as a complete collection round.
+func (c *Collector) stageSamples(src Source, collectionID uint64, last bool, dm *DeviceGroupedMetrics) {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	round := c.staging[src]
+
+	if round != nil && round.id != collectionID {
+
+		// Device moved on to the next collection round before flagging the last message of the previous one
+		c.publishRound(src, round)
+		round = nil
+	}
+
+	if round == nil {
+
+		// Late message of a collection round already published
+		if id, ok := c.published[src]; ok && collectionID != 0 && id == collectionID {
+			c.publish(&Round{Source: src, CollectionID: collectionID, Samples: dedupSamples(dm.Samples), Late: true})
+			return
+		}
+
+		round = &collectionRound{id: collectionID}
+		c.staging[src] = round
+	}
+
+	round.samples = append(round.samples, dm.Samples...)
+
+	if collectionID == 0 || last {
+		c.publishRound(src, round)
+		return
+	}
+
+	if round.timer == nil {
+		round.timer = time.AfterFunc(c.roundTimeout, func() {
+			c.Mutex.Lock()
+			defer c.Mutex.Unlock()
+
+			if c.staging[src] == round {
+				c.publishRound(src, round)
+			}
+		})
+	}
+}
+
+// publishRound publishes the collection round samples to the sinks at once.
+// Collector Mutex must be held by the caller.
+func (c *Collector) publishRound(src Source, round *collectionRound) {
+
+	if round.timer != nil {
+		round.timer.Stop()
+	}
+
+	delete(c.staging, src)
+	c.published[src] = round.id
+
+	c.publish(&Round{Source: src, CollectionID: round.id, Samples: dedupSamples(round.samples)})
+}
+
+// dropStaging discards the collection round being assembled for the source.
+// Collector Mutex must be held by the caller.
+func (c *Collector) dropStaging(src Source) {
+
+	if round, ok := c.staging[src]; ok {
+		if round.timer != nil {
+			round.timer.Stop()
+		}
+		delete(c.staging, src)
+	}
+}
+
+// StagedSamples returns the number of samples of the collection rounds being assembled
+func (c *Collector) StagedSamples() int {
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	n := 0
+
+	for _, round := range c.staging {
+		n += len(round.samples)
+	}
+
+	return n
+}
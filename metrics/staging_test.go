@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+)
+
+// roundRecorder forwards the published collection rounds to the tests
+type roundRecorder chan *Round
+
+func (r roundRecorder) Publish(round *Round) { r <- round }
+
+func (r roundRecorder) Remove(src Source) {}
+
+func TestCollectionRoundTimeoutFromConfig(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		metrics config.Metrics
+		want    time.Duration
+	}{
+		{
+			name:    "configured",
+			metrics: config.Metrics{TTL: config.TTL{Default: 10 * time.Minute}, CollectionRoundTimeout: 20 * time.Second},
+			want:    20 * time.Second,
+		},
+		{
+			name:    "derived from the default TTL up to the maximum",
+			metrics: config.Metrics{TTL: config.TTL{Default: 10 * time.Minute}},
+			want:    5 * time.Second,
+		},
+		{
+			name:    "derived from the shortest path TTL",
+			metrics: config.Metrics{TTL: config.TTL{Default: 10 * time.Minute, Paths: []string{"a:b=30s"}}},
+			want:    3 * time.Second,
+		},
+		{
+			name:    "derived from a short TTL down to the minimum",
+			metrics: config.Metrics{TTL: config.TTL{Default: 10 * time.Minute, Paths: []string{"a:b=5s"}}},
+			want:    time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CollectionRoundTimeoutFromConfig(tt.metrics); got != tt.want {
+				t.Errorf("CollectionRoundTimeoutFromConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStagingTimeout(t *testing.T) {
+
+	rounds := make(roundRecorder, 1)
+
+	c := NewCollector()
+	c.AddSink(rounds)
+	c.SetCollectionRoundTimeout(20 * time.Millisecond)
+
+	src := Source{NodeID: "r1", Path: "Cisco-IOS-XE-interfaces-oper:interfaces/interface"}
+
+	c.stageSamples(src, 7, false, &DeviceGroupedMetrics{Samples: []Sample{{Name: "m", Value: 1}}})
+
+	select {
+	case r := <-rounds:
+		if r.CollectionID != 7 || len(r.Samples) != 1 {
+			t.Errorf("published round %v, want collection 7 with 1 sample", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("collection round missing its last message not published after the timeout")
+	}
+
+	if n := c.StagedSamples(); n != 0 {
+		t.Errorf("%d sample(s) staged after the timeout, want none", n)
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Policies applied to the Telemetry messages of YANG encoding paths without registered parser.
+// The policies only apply to the metrics generation, the message sinks receive the messages whatever the policy.
+const (
+	// Close the Telemetry stream of the device with codes.InvalidArgument
+	UnsupportedPathReject = "reject"
+
+	// Skip the metrics generation of the message and keep the stream open
+	UnsupportedPathDrop = "drop"
+
+	// Keep the stream open and hand the message over to the generic decoder
+	UnsupportedPathPassThrough = "passthrough"
+)
+
+// UnsupportedPathsSettings represents the handling settings of unsupported YANG encoding paths
+type UnsupportedPathsSettings struct {
+	Policy string
+}
+
+// UnsupportedPaths applies the unsupported YANG encoding paths policy and counts the messages received
+// per Telemetry node and YANG encoding path
+type UnsupportedPaths struct {
+	policy string
+
+	mu    *sync.Mutex
+	stats map[Source]*UnsupportedPathStats
+
+	messages *prometheus.CounterVec
+}
+
+// UnsupportedPathStats represents the messages received from a Telemetry node for an unsupported YANG encoding path
+type UnsupportedPathStats struct {
+	Node      string    `json:"node"`
+	Path      string    `json:"path"`
+	Messages  uint64    `json:"messages"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// NewUnsupportedPaths will create a new instance of the unsupported YANG encoding paths counters
+func NewUnsupportedPaths(s UnsupportedPathsSettings) *UnsupportedPaths {
+
+	return &UnsupportedPaths{
+		policy: s.Policy,
+		mu:     &sync.Mutex{},
+		stats:  make(map[Source]*UnsupportedPathStats),
+		messages: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "peppamon_unsupported_path_messages_total",
+				Help: "The number of Telemetry messages received for YANG encoding paths without parser by policy",
+			},
+			[]string{"node", "path", "policy"},
+		),
+	}
+}
+
+// Policy returns the policy applied to the messages of unsupported YANG encoding paths
+func (u *UnsupportedPaths) Policy() string {
+
+	if u == nil {
+		return UnsupportedPathDrop
+	}
+
+	return u.policy
+}
+
+// Record counts a message received for an unsupported YANG encoding path
+func (u *UnsupportedPaths) Record(src Source) {
+
+	if u == nil {
+		return
+	}
+
+	now := time.Now()
+
+	u.mu.Lock()
+
+	s, ok := u.stats[src]
+
+	if !ok {
+		s = &UnsupportedPathStats{Node: src.NodeID, Path: src.Path, FirstSeen: now}
+		u.stats[src] = s
+	}
+	s.Messages++
+	s.LastSeen = now
+
+	u.mu.Unlock()
+
+	u.messages.WithLabelValues(src.NodeID, src.Path, u.policy).Inc()
+}
+
+// Stats returns the counters of the unsupported YANG encoding paths sorted by node and path
+func (u *UnsupportedPaths) Stats() []UnsupportedPathStats {
+
+	u.mu.Lock()
+
+	stats := make([]UnsupportedPathStats, 0, len(u.stats))
+
+	for _, s := range u.stats {
+		stats = append(stats, *s)
+	}
+
+	u.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Node != stats[j].Node {
+			return stats[i].Node < stats[j].Node
+		}
+		return stats[i].Path < stats[j].Path
+	})
+
+	return stats
+}
+
+// Describe implements prometheus.Collector interface
+func (u *UnsupportedPaths) Describe(ch chan<- *prometheus.Desc) {
+	u.messages.Describe(ch)
+}
+
+// Collect implements prometheus.Collector interface
+func (u *UnsupportedPaths) Collect(ch chan<- prometheus.Metric) {
+	u.messages.Collect(ch)
+}
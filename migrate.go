@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metadb"
+)
+
+const migrateUsage = `Usage: peppamon migrate <up|down|status> [options]
+
+Manage the Telemetry metadata Postgres database schema. The SQLite backend schema is migrated
+automatically when the collector opens the database.
+
+  up      Apply the pending migrations
+  down    Revert the most recent applied migrations
+  status  List the migrations and whether they are applied
+
+The database is set by the configuration file and the PEPPAMON_METADB_* environment variables.
+
+Options:
+`
+
+// Timeout of the migrate command
+const migrateTimeout = 10 * time.Minute
+
+// runMigrate implements the migrate command
+func runMigrate(args []string) {
+
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+
+	configFile := fs.String("config", os.Getenv("PEPPAMON_CONFIG_FILE"), "Path of the YAML configuration file")
+	steps := fs.Int("steps", 1, "Number of migrations reverted by down")
+
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	action := args[0]
+
+	// ExitOnError flag set exits on parsing errors
+	_ = fs.Parse(args[1:])
+
+	if fs.NArg() > 0 || *steps < 1 || (action != "up" && action != "down" && action != "status") {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load("migrate", []string{"-config", *configFile})
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"fatal",
+			"Failed to load Peppamon configuration %v", err)
+	}
+
+	if !cfg.MetaDB.Enabled() {
+		logging.PeppaMonLog(
+			"fatal",
+			"Telemetry metadata database disabled. Set metadb.host to run migrations")
+	}
+
+	if cfg.MetaDB.Backend != metadb.BackendPostgres {
+		logging.PeppaMonLog(
+			"fatal",
+			"Migrations apply to the postgres backend. The %v schema is migrated when the collector opens it",
+			cfg.MetaDB.Backend)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	migrator, err := metadb.NewMigrator(ctx, cfg.MetaDB)
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"fatal",
+			"Unable to connect to the metadata database: %v", err)
+	}
+	defer migrator.Close()
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("Applied", applied)
+
+		if err != nil {
+			logging.PeppaMonLog("fatal", "%v", err)
+		}
+
+	case "down":
+		reverted, err := migrator.Down(ctx, *steps)
+		printMigrations("Reverted", reverted)
+
+		if err != nil {
+			logging.PeppaMonLog("fatal", "%v", err)
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to retrieve the migrations status %v", err)
+		}
+		printMigrationStatus(statuses)
+	}
+}
+
+func printMigrations(verb string, statuses []metadb.MigrationStatus) {
+
+	if len(statuses) == 0 {
+		fmt.Printf("No migration %v\n", strings.ToLower(verb))
+		return
+	}
+
+	for _, s := range statuses {
+		fmt.Printf("%v migration %v %v\n", verb, s.Version, s.Name)
+	}
+}
+
+func printMigrationStatus(statuses []metadb.MigrationStatus) {
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+
+	for _, s := range statuses {
+
+		status, appliedAt := "pending", "-"
+
+		if s.Applied() {
+			status, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+		}
+
+		if s.Unknown {
+			status = "unknown"
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", s.Version, s.Name, status, appliedAt)
+	}
+
+	_ = w.Flush()
+}
@@ -0,0 +1,184 @@
+package otlp
+
+import (
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/pbwire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Label moved from the data points to the resource attributes
+	nodeLabel = "node"
+
+	// OTLP AggregationTemporality enum value for cumulative sums
+	aggregationTemporalityCumulative = 2
+
+	// Instrumentation scope of the exported metrics
+	scopeName = "github.com/lucabrasi83/peppamon_cisco"
+)
+
+// resourceMetrics represents the metrics of a Telemetry node in export order
+type resourceMetrics struct {
+	node    string
+	metrics []*metricPoints
+	byName  map[string]*metricPoints
+}
+
+// metricPoints represents the data points of a metric
+type metricPoints struct {
+	name    string
+	help    string
+	counter bool
+	samples []metrics.Sample
+}
+
+// groupByNode groups samples per Telemetry node and metric name keeping their order of appearance
+func groupByNode(samples []metrics.Sample) []*resourceMetrics {
+
+	var resources []*resourceMetrics
+	byNode := make(map[string]*resourceMetrics)
+
+	for _, s := range samples {
+
+		var node string
+
+		for _, l := range s.Labels {
+			if l.Name == nodeLabel {
+				node = l.Value
+				break
+			}
+		}
+
+		rm, ok := byNode[node]
+
+		if !ok {
+			rm = &resourceMetrics{node: node, byName: make(map[string]*metricPoints)}
+			byNode[node] = rm
+			resources = append(resources, rm)
+		}
+
+		mp, ok := rm.byName[s.Name]
+
+		if !ok {
+			mp = &metricPoints{name: s.Name, help: s.Help, counter: s.Type == prometheus.CounterValue}
+			rm.byName[s.Name] = mp
+			rm.metrics = append(rm.metrics, mp)
+		}
+
+		mp.samples = append(mp.samples, s)
+	}
+
+	return resources
+}
+
+// encodeExportRequest marshals samples into an OTLP ExportMetricsServiceRequest protocol buffer message.
+// Prometheus counters become monotonic cumulative sums starting at startTime, other samples become gauges.
+// The node label becomes a resource attribute and the other labels data point attributes.
+//
+//	message ExportMetricsServiceRequest { repeated ResourceMetrics resource_metrics = 1; }
+//	message ResourceMetrics { Resource resource = 1; repeated ScopeMetrics scope_metrics = 2; }
+//	message Resource        { repeated KeyValue attributes = 1; }
+//	message ScopeMetrics    { InstrumentationScope scope = 1; repeated Metric metrics = 2; }
+//	message Metric          { string name = 1; string description = 2; Gauge gauge = 5; Sum sum = 7; }
+//	message Gauge           { repeated NumberDataPoint data_points = 1; }
+//	message Sum             { repeated NumberDataPoint data_points = 1; int32 aggregation_temporality = 2;
+//	                          bool is_monotonic = 3; }
+//	message NumberDataPoint { fixed64 start_time_unix_nano = 2; fixed64 time_unix_nano = 3;
+//	                          double as_double = 4; repeated KeyValue attributes = 7; }
+func encodeExportRequest(samples []metrics.Sample, startTime time.Time) []byte {
+
+	var req []byte
+
+	for _, rm := range groupByNode(samples) {
+
+		var resource []byte
+		resource = pbwire.AppendBytesField(resource, 1, encodeKeyValue("service.name", "peppamon"))
+
+		if rm.node != "" {
+			resource = pbwire.AppendBytesField(resource, 1, encodeKeyValue("host.name", rm.node))
+			resource = pbwire.AppendBytesField(resource, 1, encodeKeyValue(nodeLabel, rm.node))
+		}
+
+		var scope []byte
+		scope = pbwire.AppendStringField(scope, 1, scopeName)
+
+		var scopeMetrics []byte
+		scopeMetrics = pbwire.AppendBytesField(scopeMetrics, 1, scope)
+
+		for _, mp := range rm.metrics {
+			scopeMetrics = pbwire.AppendBytesField(scopeMetrics, 2, encodeMetric(mp, startTime))
+		}
+
+		var resourceMetrics []byte
+		resourceMetrics = pbwire.AppendBytesField(resourceMetrics, 1, resource)
+		resourceMetrics = pbwire.AppendBytesField(resourceMetrics, 2, scopeMetrics)
+
+		req = pbwire.AppendBytesField(req, 1, resourceMetrics)
+	}
+
+	return req
+}
+
+func encodeMetric(mp *metricPoints, startTime time.Time) []byte {
+
+	var data []byte
+
+	for _, s := range mp.samples {
+
+		var dp []byte
+
+		// Start of the cumulative sum cannot be later than the sample. i.e. replayed Telemetry
+		if mp.counter {
+
+			start := startTime
+
+			if s.Timestamp.Before(start) {
+				start = s.Timestamp
+			}
+			dp = pbwire.AppendFixed64Field(dp, 2, uint64(start.UnixNano()))
+		}
+		dp = pbwire.AppendFixed64Field(dp, 3, uint64(s.Timestamp.UnixNano()))
+		dp = pbwire.AppendDoubleField(dp, 4, s.Value)
+
+		for _, l := range s.Labels {
+			if l.Name != nodeLabel {
+				dp = pbwire.AppendBytesField(dp, 7, encodeKeyValue(l.Name, l.Value))
+			}
+		}
+
+		data = pbwire.AppendBytesField(data, 1, dp)
+	}
+
+	var m []byte
+	m = pbwire.AppendStringField(m, 1, mp.name)
+
+	if mp.help != "" {
+		m = pbwire.AppendStringField(m, 2, mp.help)
+	}
+
+	if mp.counter {
+		data = pbwire.AppendVarintField(data, 2, aggregationTemporalityCumulative)
+		data = pbwire.AppendVarintField(data, 3, 1)
+		return pbwire.AppendBytesField(m, 7, data)
+	}
+
+	return pbwire.AppendBytesField(m, 5, data)
+}
+
+// encodeKeyValue marshals a string attribute:
+//
+//	message KeyValue { string key = 1; AnyValue value = 2; }
+//	message AnyValue { string string_value = 1; }
+func encodeKeyValue(key string, value string) []byte {
+
+	var anyValue []byte
+	anyValue = pbwire.AppendStringField(anyValue, 1, value)
+
+	var kv []byte
+	kv = pbwire.AppendStringField(kv, 1, key)
+	kv = pbwire.AppendBytesField(kv, 2, anyValue)
+
+	return kv
+}
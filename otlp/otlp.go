@@ -0,0 +1,230 @@
+// Package otlp exports the Telemetry metrics samples as OpenTelemetry OTLP metrics over gRPC or HTTP
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// OTLP transport protocols
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+
+	// OTLP/gRPC metrics Export method and OTLP/HTTP metrics path
+	grpcExportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+	httpMetricsPath  = "/v1/metrics"
+)
+
+// Settings represents the OTLP metrics exporter settings
+type Settings struct {
+	// OTLP receiver endpoint. host:port for gRPC (i.e. otel-collector:4317),
+	// base URL for HTTP (i.e. http://otel-collector:4318)
+	Endpoint string
+
+	// Transport protocol: grpc or http
+	Protocol string
+
+	// Disable TLS of the gRPC transport
+	Insecure bool
+
+	// Extra headers sent with every export request. i.e. authentication
+	Headers map[string]string
+
+	// Export request timeout
+	Timeout time.Duration
+
+	// Batching of the samples
+	Batch batch.Settings
+}
+
+// Enabled returns whether the OTLP metrics exporter is configured
+func (s Settings) Enabled() bool {
+	return s.Endpoint != ""
+}
+
+// SettingsFromConfig builds the OTLP metrics exporter settings from the outputs.otlp configuration section
+func SettingsFromConfig(c config.OTLP) Settings {
+
+	s := Settings{
+		Endpoint: c.Endpoint,
+		Protocol: c.Protocol,
+		Insecure: c.Insecure,
+		Headers:  make(map[string]string),
+		Timeout:  c.Timeout,
+		Batch:    batch.SettingsFromConfig(c.Batch),
+	}
+
+	// Headers format is key=value
+	for _, h := range c.Headers {
+
+		kv := strings.SplitN(h, "=", 2)
+
+		if len(kv) == 2 && strings.TrimSpace(kv[0]) != "" {
+			s.Headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	return s
+}
+
+// Exporter batches the published samples and exports them to an OTLP receiver.
+// It implements the metrics.Sink interface.
+type Exporter struct {
+	*batch.Queue
+
+	settings  Settings
+	startTime time.Time
+
+	httpClient *http.Client
+	grpcConn   *grpc.ClientConn
+}
+
+// NewExporter returns an OTLP metrics Exporter. Samples are exported once Run is started
+func NewExporter(s Settings) (*Exporter, error) {
+
+	e := &Exporter{
+		settings:  s,
+		startTime: time.Now(),
+	}
+
+	switch s.Protocol {
+	case ProtocolHTTP:
+		e.httpClient = &http.Client{Timeout: s.Timeout}
+		e.Queue = batch.NewQueue("otlp", s.Batch, e.exportHTTP)
+
+	case ProtocolGRPC:
+		creds := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+
+		if s.Insecure {
+			creds = grpc.WithInsecure()
+		}
+
+		// Connection is established in background and re-established on failure
+		conn, err := grpc.Dial(s.Endpoint, creds)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to create OTLP gRPC connection: %v", err)
+		}
+
+		e.grpcConn = conn
+		e.Queue = batch.NewQueue("otlp", s.Batch, e.exportGRPC)
+
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %v", s.Protocol)
+	}
+
+	return e, nil
+}
+
+// Close releases the gRPC connection
+func (e *Exporter) Close() error {
+
+	if e.grpcConn != nil {
+		return e.grpcConn.Close()
+	}
+
+	return nil
+}
+
+// exportHTTP posts a batch of samples to the OTLP/HTTP receiver
+func (e *Exporter) exportHTTP(ctx context.Context, samples []metrics.Sample) (bool, error) {
+
+	body := encodeExportRequest(samples, e.startTime)
+
+	url := strings.TrimSuffix(e.settings.Endpoint, "/") + httpMetricsPath
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return false, err
+	}
+
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "peppamon")
+
+	for k, v := range e.settings.Headers {
+		req.Header.Set(k, v)
+	}
+
+	return batch.Post(e.httpClient, req)
+}
+
+// exportGRPC calls the Export method of the OTLP/gRPC receiver
+func (e *Exporter) exportGRPC(ctx context.Context, samples []metrics.Sample) (bool, error) {
+
+	ctxExport, cancel := context.WithTimeout(ctx, e.settings.Timeout)
+	defer cancel()
+
+	for k, v := range e.settings.Headers {
+		ctxExport = metadata.AppendToOutgoingContext(ctxExport, k, v)
+	}
+
+	req := rawMessage(encodeExportRequest(samples, e.startTime))
+	var resp rawMessage
+
+	err := e.grpcConn.Invoke(ctxExport, grpcExportMethod, &req, &resp, grpc.ForceCodec(rawCodec{}))
+
+	if err == nil {
+		return true, nil
+	}
+
+	// Retryable status codes as per OTLP specification
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+		codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true, err
+	}
+
+	return false, err
+}
+
+// rawMessage represents an already marshaled protocol buffer message
+type rawMessage []byte
+
+// rawCodec passes the protocol buffer messages marshaled by the exporter as is to the gRPC transport
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+
+	m, ok := v.(*rawMessage)
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+
+	return *m, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+
+	m, ok := v.(*rawMessage)
+
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+
+	*m = append((*m)[:0], data...)
+
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
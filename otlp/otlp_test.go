@@ -0,0 +1,399 @@
+package otlp
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The OTLP metrics messages, declared with the field numbers of the opentelemetry-proto definitions so the
+// export requests are decoded by the gogo protobuf library rather than by pbwire.
+type otlpExportRequest struct {
+	ResourceMetrics []*otlpResourceMetrics `protobuf:"bytes,1,rep,name=resource_metrics,proto3"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     *otlpResource       `protobuf:"bytes,1,opt,name=resource,proto3"`
+	ScopeMetrics []*otlpScopeMetrics `protobuf:"bytes,2,rep,name=scope_metrics,proto3"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `protobuf:"bytes,1,rep,name=attributes,proto3"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   *otlpScope    `protobuf:"bytes,1,opt,name=scope,proto3"`
+	Metrics []*otlpMetric `protobuf:"bytes,2,rep,name=metrics,proto3"`
+}
+
+type otlpScope struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3"`
+}
+
+type otlpMetric struct {
+	Name        string     `protobuf:"bytes,1,opt,name=name,proto3"`
+	Description string     `protobuf:"bytes,2,opt,name=description,proto3"`
+	Gauge       *otlpGauge `protobuf:"bytes,5,opt,name=gauge,proto3"`
+	Sum         *otlpSum   `protobuf:"bytes,7,opt,name=sum,proto3"`
+}
+
+type otlpGauge struct {
+	DataPoints []*otlpDataPoint `protobuf:"bytes,1,rep,name=data_points,proto3"`
+}
+
+type otlpSum struct {
+	DataPoints             []*otlpDataPoint `protobuf:"bytes,1,rep,name=data_points,proto3"`
+	AggregationTemporality int32            `protobuf:"varint,2,opt,name=aggregation_temporality,proto3"`
+	IsMonotonic            bool             `protobuf:"varint,3,opt,name=is_monotonic,proto3"`
+}
+
+type otlpDataPoint struct {
+	StartTimeUnixNano uint64          `protobuf:"fixed64,2,opt,name=start_time_unix_nano,proto3"`
+	TimeUnixNano      uint64          `protobuf:"fixed64,3,opt,name=time_unix_nano,proto3"`
+	AsDouble          float64         `protobuf:"fixed64,4,opt,name=as_double,proto3"`
+	Attributes        []*otlpKeyValue `protobuf:"bytes,7,rep,name=attributes,proto3"`
+}
+
+type otlpKeyValue struct {
+	Key   string        `protobuf:"bytes,1,opt,name=key,proto3"`
+	Value *otlpAnyValue `protobuf:"bytes,2,opt,name=value,proto3"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,proto3"`
+}
+
+func (m *otlpExportRequest) Reset()         { *m = otlpExportRequest{} }
+func (m *otlpExportRequest) String() string { return proto.CompactTextString(m) }
+func (*otlpExportRequest) ProtoMessage()    {}
+
+func attr(key string, value string) *otlpKeyValue {
+	return &otlpKeyValue{Key: key, Value: &otlpAnyValue{StringValue: value}}
+}
+
+var testTime = time.Unix(1600000000, 0)
+
+// testSamples returns two counter samples of node r1 and a gauge sample of node r2
+func testSamples() []metrics.Sample {
+
+	return []metrics.Sample{
+		{
+			Name:      "cisco_iosxe_if_in_octets",
+			Help:      "Interface input octets",
+			Labels:    []metrics.Label{{Name: "node", Value: "r1"}, {Name: "interface", Value: "Gi1"}},
+			Value:     1234,
+			Timestamp: testTime,
+			Type:      prometheus.CounterValue,
+		},
+		{
+			Name:      "cisco_iosxe_cpu_busy_five_seconds",
+			Labels:    []metrics.Label{{Name: "node", Value: "r2"}},
+			Value:     2.5,
+			Timestamp: testTime,
+			Type:      prometheus.GaugeValue,
+		},
+		{
+			Name:      "cisco_iosxe_if_in_octets",
+			Help:      "Interface input octets",
+			Labels:    []metrics.Label{{Name: "node", Value: "r1"}, {Name: "interface", Value: "Gi2"}},
+			Value:     10,
+			Timestamp: testTime.Add(time.Second),
+			Type:      prometheus.CounterValue,
+		},
+	}
+}
+
+// wantExportRequest returns the export request of testSamples. Counters start at the exporter start time.
+func wantExportRequest() *otlpExportRequest {
+
+	start := uint64(testTime.Add(-time.Minute).UnixNano())
+	scope := &otlpScope{Name: scopeName}
+
+	return &otlpExportRequest{ResourceMetrics: []*otlpResourceMetrics{
+		{
+			Resource: &otlpResource{Attributes: []*otlpKeyValue{
+				attr("service.name", "peppamon"), attr("host.name", "r1"), attr("node", "r1"),
+			}},
+			ScopeMetrics: []*otlpScopeMetrics{{Scope: scope, Metrics: []*otlpMetric{{
+				Name:        "cisco_iosxe_if_in_octets",
+				Description: "Interface input octets",
+				Sum: &otlpSum{
+					DataPoints: []*otlpDataPoint{
+						{
+							StartTimeUnixNano: start,
+							TimeUnixNano:      uint64(testTime.UnixNano()),
+							AsDouble:          1234,
+							Attributes:        []*otlpKeyValue{attr("interface", "Gi1")},
+						},
+						{
+							StartTimeUnixNano: start,
+							TimeUnixNano:      uint64(testTime.Add(time.Second).UnixNano()),
+							AsDouble:          10,
+							Attributes:        []*otlpKeyValue{attr("interface", "Gi2")},
+						},
+					},
+					AggregationTemporality: aggregationTemporalityCumulative,
+					IsMonotonic:            true,
+				},
+			}}}},
+		},
+		{
+			Resource: &otlpResource{Attributes: []*otlpKeyValue{
+				attr("service.name", "peppamon"), attr("host.name", "r2"), attr("node", "r2"),
+			}},
+			ScopeMetrics: []*otlpScopeMetrics{{Scope: scope, Metrics: []*otlpMetric{{
+				Name:  "cisco_iosxe_cpu_busy_five_seconds",
+				Gauge: &otlpGauge{DataPoints: []*otlpDataPoint{{TimeUnixNano: uint64(testTime.UnixNano()), AsDouble: 2.5}}},
+			}}}},
+		},
+	}}
+}
+
+func checkExportRequest(t *testing.T, body []byte) {
+
+	t.Helper()
+
+	var got otlpExportRequest
+
+	if err := proto.Unmarshal(body, &got); err != nil {
+		t.Fatalf("export request decoding error = %v", err)
+	}
+
+	if want := wantExportRequest(); !reflect.DeepEqual(&got, want) {
+		t.Errorf("export request =\n%v\nwant\n%v", &got, want)
+	}
+}
+
+// serverCodec passes the export requests as is to the MetricsService stand-in
+type serverCodec struct {
+	rawCodec
+}
+
+func (serverCodec) String() string {
+	return "proto"
+}
+
+// metricsService represents an OTLP/gRPC receiver answering the Export calls with the given status code
+type metricsService struct {
+	mu       sync.Mutex
+	code     codes.Code
+	requests [][]byte
+	headers  []metadata.MD
+}
+
+// startMetricsService serves the OTLP MetricsService on a loopback address and returns an exporter calling it
+func startMetricsService(t *testing.T, code codes.Code) (*metricsService, *Exporter) {
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &metricsService{code: code}
+
+	s := grpc.NewServer(grpc.CustomCodec(serverCodec{}))
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "opentelemetry.proto.collector.metrics.v1.MetricsService",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Export",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+				interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+				var req rawMessage
+
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+
+				return srv.(*metricsService).export(ctx, req)
+			},
+		}},
+	}, svc)
+
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	e, err := NewExporter(Settings{
+		Endpoint: lis.Addr().String(),
+		Protocol: ProtocolGRPC,
+		Insecure: true,
+		Headers:  map[string]string{"authorization": "Bearer token"},
+		Timeout:  5 * time.Second,
+		Batch:    batch.Settings{BatchSize: 100, FlushInterval: time.Second, QueueSize: 100},
+	})
+
+	if err != nil {
+		t.Fatalf("NewExporter() error = %v", err)
+	}
+	t.Cleanup(func() { _ = e.Close() })
+
+	e.startTime = testTime.Add(-time.Minute)
+
+	return svc, e
+}
+
+func (m *metricsService) export(ctx context.Context, req rawMessage) (*rawMessage, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	m.requests = append(m.requests, req)
+	m.headers = append(m.headers, md)
+
+	if m.code != codes.OK {
+		return nil, status.Error(m.code, "export failed")
+	}
+
+	// Empty ExportMetricsServiceResponse
+	return &rawMessage{}, nil
+}
+
+func TestExportGRPC(t *testing.T) {
+
+	svc, e := startMetricsService(t, codes.OK)
+
+	if _, err := e.exportGRPC(context.Background(), testSamples()); err != nil {
+		t.Fatalf("exportGRPC() error = %v", err)
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if len(svc.requests) != 1 {
+		t.Fatalf("%d export(s) received, want 1", len(svc.requests))
+	}
+
+	if got := svc.headers[0].Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("authorization metadata = %v, want Bearer token", got)
+	}
+
+	checkExportRequest(t, svc.requests[0])
+}
+
+func TestExportGRPCStatus(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		code            codes.Code
+		wantRecoverable bool
+	}{
+		{name: "unavailable", code: codes.Unavailable, wantRecoverable: true},
+		{name: "resource exhausted", code: codes.ResourceExhausted, wantRecoverable: true},
+		{name: "invalid argument", code: codes.InvalidArgument, wantRecoverable: false},
+		{name: "unauthenticated", code: codes.Unauthenticated, wantRecoverable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			_, e := startMetricsService(t, tt.code)
+
+			recoverable, err := e.exportGRPC(context.Background(), testSamples())
+
+			if status.Code(err) != tt.code {
+				t.Fatalf("exportGRPC() error = %v, want %v", err, tt.code)
+			}
+
+			if recoverable != tt.wantRecoverable {
+				t.Errorf("exportGRPC() recoverable = %v, want %v", recoverable, tt.wantRecoverable)
+			}
+		})
+	}
+}
+
+func TestExportHTTP(t *testing.T) {
+
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+		bodies   [][]byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer srv.Close()
+
+	e, err := NewExporter(Settings{
+		Endpoint: srv.URL + "/",
+		Protocol: ProtocolHTTP,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Timeout:  5 * time.Second,
+		Batch:    batch.Settings{BatchSize: 100, FlushInterval: time.Second, QueueSize: 100},
+	})
+
+	if err != nil {
+		t.Fatalf("NewExporter() error = %v", err)
+	}
+
+	e.startTime = testTime.Add(-time.Minute)
+
+	if _, err := e.exportHTTP(context.Background(), testSamples()); err != nil {
+		t.Fatalf("exportHTTP() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(requests) != 1 {
+		t.Fatalf("%d export(s) received, want 1", len(requests))
+	}
+
+	r := requests[0]
+
+	if r.URL.Path != "/v1/metrics" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("export request path %v content type %v, want /v1/metrics application/x-protobuf",
+			r.URL.Path, r.Header.Get("Content-Type"))
+	}
+
+	if got := r.Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization header = %q, want Bearer token", got)
+	}
+
+	checkExportRequest(t, bodies[0])
+}
+
+func TestCounterStartTime(t *testing.T) {
+
+	// Counters replayed from before the exporter start begin at their own timestamp
+	s := testSamples()[:1]
+
+	var got otlpExportRequest
+
+	if err := proto.Unmarshal(encodeExportRequest(s, testTime.Add(time.Hour)), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	dp := got.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].Sum.DataPoints[0]
+
+	if dp.StartTimeUnixNano != dp.TimeUnixNano {
+		t.Errorf("start time = %v, want the sample time %v", dp.StartTimeUnixNano, dp.TimeUnixNano)
+	}
+}
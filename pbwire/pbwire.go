@@ -0,0 +1,65 @@
+// Package pbwire appends protocol buffer encoded fields to a buffer. It is used to marshal the few messages of
+// the output sinks protocols without depending on their generated code
+package pbwire
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Protocol buffer wire types
+const (
+	WireVarint  = 0
+	WireFixed64 = 1
+	WireBytes   = 2
+)
+
+// AppendTag appends a field tag
+func AppendTag(b []byte, field int, wireType int) []byte {
+	return AppendUvarint(b, uint64(field<<3|wireType))
+}
+
+// AppendUvarint appends a base 128 varint
+func AppendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// AppendFixed64 appends a little endian 64 bits value
+func AppendFixed64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// AppendVarintField appends an integer or boolean field
+func AppendVarintField(b []byte, field int, v uint64) []byte {
+	b = AppendTag(b, field, WireVarint)
+	return AppendUvarint(b, v)
+}
+
+// AppendFixed64Field appends a fixed64 field
+func AppendFixed64Field(b []byte, field int, v uint64) []byte {
+	b = AppendTag(b, field, WireFixed64)
+	return AppendFixed64(b, v)
+}
+
+// AppendDoubleField appends a double field
+func AppendDoubleField(b []byte, field int, v float64) []byte {
+	return AppendFixed64Field(b, field, math.Float64bits(v))
+}
+
+// AppendBytesField appends a length delimited field such as an embedded message
+func AppendBytesField(b []byte, field int, v []byte) []byte {
+	b = AppendTag(b, field, WireBytes)
+	b = AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// AppendStringField appends a string field
+func AppendStringField(b []byte, field int, v string) []byte {
+	b = AppendTag(b, field, WireBytes)
+	b = AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
@@ -0,0 +1,389 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/bgp_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type BgpAddressFamilyKeys struct {
+	AfiSafi              string   `protobuf:"bytes,1,opt,name=afi_safi,json=afiSafi,proto3" json:"afi_safi,omitempty"`
+	VrfName              string   `protobuf:"bytes,2,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BgpAddressFamilyKeys) Reset()         { *m = BgpAddressFamilyKeys{} }
+func (m *BgpAddressFamilyKeys) String() string { return proto.CompactTextString(m) }
+func (*BgpAddressFamilyKeys) ProtoMessage()    {}
+func (*BgpAddressFamilyKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf0fa38d1f74a784, []int{0}
+}
+func (m *BgpAddressFamilyKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BgpAddressFamilyKeys.Unmarshal(m, b)
+}
+func (m *BgpAddressFamilyKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BgpAddressFamilyKeys.Marshal(b, m, deterministic)
+}
+func (m *BgpAddressFamilyKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BgpAddressFamilyKeys.Merge(m, src)
+}
+func (m *BgpAddressFamilyKeys) XXX_Size() int {
+	return xxx_messageInfo_BgpAddressFamilyKeys.Size(m)
+}
+func (m *BgpAddressFamilyKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_BgpAddressFamilyKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BgpAddressFamilyKeys proto.InternalMessageInfo
+
+func (m *BgpAddressFamilyKeys) GetAfiSafi() string {
+	if m != nil {
+		return m.AfiSafi
+	}
+	return ""
+}
+
+func (m *BgpAddressFamilyKeys) GetVrfName() string {
+	if m != nil {
+		return m.VrfName
+	}
+	return ""
+}
+
+type BgpAddressFamily struct {
+	AfiSafi              string                                 `protobuf:"bytes,1,opt,name=afi_safi,json=afiSafi,proto3" json:"afi_safi,omitempty"`
+	VrfName              string                                 `protobuf:"bytes,2,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	RouterId             string                                 `protobuf:"bytes,3,opt,name=router_id,json=routerId,proto3" json:"router_id,omitempty"`
+	BgpTableVersion      uint64                                 `protobuf:"varint,4,opt,name=bgp_table_version,json=bgpTableVersion,proto3" json:"bgp_table_version,omitempty"`
+	RoutingTableVersion  uint64                                 `protobuf:"varint,5,opt,name=routing_table_version,json=routingTableVersion,proto3" json:"routing_table_version,omitempty"`
+	Prefixes             *BgpAddressFamily_EntryStats           `protobuf:"bytes,6,opt,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Path                 *BgpAddressFamily_EntryStats           `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	LocalAs              uint32                                 `protobuf:"varint,8,opt,name=local_as,json=localAs,proto3" json:"local_as,omitempty"`
+	BgpNeighborSummary   []*BgpAddressFamily_BgpNeighborSummary `protobuf:"bytes,9,rep,name=bgp_neighbor_summary,json=bgpNeighborSummary,proto3" json:"bgp_neighbor_summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
+	XXX_unrecognized     []byte                                 `json:"-"`
+	XXX_sizecache        int32                                  `json:"-"`
+}
+
+func (m *BgpAddressFamily) Reset()         { *m = BgpAddressFamily{} }
+func (m *BgpAddressFamily) String() string { return proto.CompactTextString(m) }
+func (*BgpAddressFamily) ProtoMessage()    {}
+func (*BgpAddressFamily) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf0fa38d1f74a784, []int{1}
+}
+func (m *BgpAddressFamily) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BgpAddressFamily.Unmarshal(m, b)
+}
+func (m *BgpAddressFamily) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BgpAddressFamily.Marshal(b, m, deterministic)
+}
+func (m *BgpAddressFamily) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BgpAddressFamily.Merge(m, src)
+}
+func (m *BgpAddressFamily) XXX_Size() int {
+	return xxx_messageInfo_BgpAddressFamily.Size(m)
+}
+func (m *BgpAddressFamily) XXX_DiscardUnknown() {
+	xxx_messageInfo_BgpAddressFamily.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BgpAddressFamily proto.InternalMessageInfo
+
+func (m *BgpAddressFamily) GetAfiSafi() string {
+	if m != nil {
+		return m.AfiSafi
+	}
+	return ""
+}
+
+func (m *BgpAddressFamily) GetVrfName() string {
+	if m != nil {
+		return m.VrfName
+	}
+	return ""
+}
+
+func (m *BgpAddressFamily) GetRouterId() string {
+	if m != nil {
+		return m.RouterId
+	}
+	return ""
+}
+
+func (m *BgpAddressFamily) GetBgpTableVersion() uint64 {
+	if m != nil {
+		return m.BgpTableVersion
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily) GetRoutingTableVersion() uint64 {
+	if m != nil {
+		return m.RoutingTableVersion
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily) GetPrefixes() *BgpAddressFamily_EntryStats {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *BgpAddressFamily) GetPath() *BgpAddressFamily_EntryStats {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *BgpAddressFamily) GetLocalAs() uint32 {
+	if m != nil {
+		return m.LocalAs
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily) GetBgpNeighborSummary() []*BgpAddressFamily_BgpNeighborSummary {
+	if m != nil {
+		return m.BgpNeighborSummary
+	}
+	return nil
+}
+
+type BgpAddressFamily_EntryStats struct {
+	TotalEntries         uint64   `protobuf:"varint,1,opt,name=total_entries,json=totalEntries,proto3" json:"total_entries,omitempty"`
+	MemoryUsage          uint64   `protobuf:"varint,2,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BgpAddressFamily_EntryStats) Reset()         { *m = BgpAddressFamily_EntryStats{} }
+func (m *BgpAddressFamily_EntryStats) String() string { return proto.CompactTextString(m) }
+func (*BgpAddressFamily_EntryStats) ProtoMessage()    {}
+func (*BgpAddressFamily_EntryStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf0fa38d1f74a784, []int{1, 0}
+}
+func (m *BgpAddressFamily_EntryStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BgpAddressFamily_EntryStats.Unmarshal(m, b)
+}
+func (m *BgpAddressFamily_EntryStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BgpAddressFamily_EntryStats.Marshal(b, m, deterministic)
+}
+func (m *BgpAddressFamily_EntryStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BgpAddressFamily_EntryStats.Merge(m, src)
+}
+func (m *BgpAddressFamily_EntryStats) XXX_Size() int {
+	return xxx_messageInfo_BgpAddressFamily_EntryStats.Size(m)
+}
+func (m *BgpAddressFamily_EntryStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_BgpAddressFamily_EntryStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BgpAddressFamily_EntryStats proto.InternalMessageInfo
+
+func (m *BgpAddressFamily_EntryStats) GetTotalEntries() uint64 {
+	if m != nil {
+		return m.TotalEntries
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_EntryStats) GetMemoryUsage() uint64 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
+type BgpAddressFamily_BgpNeighborSummary struct {
+	Id                    string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BgpVersion            uint32   `protobuf:"varint,2,opt,name=bgp_version,json=bgpVersion,proto3" json:"bgp_version,omitempty"`
+	MessagesReceived      uint64   `protobuf:"varint,3,opt,name=messages_received,json=messagesReceived,proto3" json:"messages_received,omitempty"`
+	MessagesSent          uint64   `protobuf:"varint,4,opt,name=messages_sent,json=messagesSent,proto3" json:"messages_sent,omitempty"`
+	TableVersion          uint64   `protobuf:"varint,5,opt,name=table_version,json=tableVersion,proto3" json:"table_version,omitempty"`
+	InputQueue            uint64   `protobuf:"varint,6,opt,name=input_queue,json=inputQueue,proto3" json:"input_queue,omitempty"`
+	OutputQueue           uint64   `protobuf:"varint,7,opt,name=output_queue,json=outputQueue,proto3" json:"output_queue,omitempty"`
+	UpTime                string   `protobuf:"bytes,8,opt,name=up_time,json=upTime,proto3" json:"up_time,omitempty"`
+	State                 string   `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`
+	PrefixesReceived      uint64   `protobuf:"varint,10,opt,name=prefixes_received,json=prefixesReceived,proto3" json:"prefixes_received,omitempty"`
+	DynamicallyConfigured bool     `protobuf:"varint,11,opt,name=dynamically_configured,json=dynamicallyConfigured,proto3" json:"dynamically_configured,omitempty"`
+	As                    uint32   `protobuf:"varint,12,opt,name=as,proto3" json:"as,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) Reset()         { *m = BgpAddressFamily_BgpNeighborSummary{} }
+func (m *BgpAddressFamily_BgpNeighborSummary) String() string { return proto.CompactTextString(m) }
+func (*BgpAddressFamily_BgpNeighborSummary) ProtoMessage()    {}
+func (*BgpAddressFamily_BgpNeighborSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf0fa38d1f74a784, []int{1, 1}
+}
+func (m *BgpAddressFamily_BgpNeighborSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BgpAddressFamily_BgpNeighborSummary.Unmarshal(m, b)
+}
+func (m *BgpAddressFamily_BgpNeighborSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BgpAddressFamily_BgpNeighborSummary.Marshal(b, m, deterministic)
+}
+func (m *BgpAddressFamily_BgpNeighborSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BgpAddressFamily_BgpNeighborSummary.Merge(m, src)
+}
+func (m *BgpAddressFamily_BgpNeighborSummary) XXX_Size() int {
+	return xxx_messageInfo_BgpAddressFamily_BgpNeighborSummary.Size(m)
+}
+func (m *BgpAddressFamily_BgpNeighborSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_BgpAddressFamily_BgpNeighborSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BgpAddressFamily_BgpNeighborSummary proto.InternalMessageInfo
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetBgpVersion() uint32 {
+	if m != nil {
+		return m.BgpVersion
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetMessagesReceived() uint64 {
+	if m != nil {
+		return m.MessagesReceived
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetMessagesSent() uint64 {
+	if m != nil {
+		return m.MessagesSent
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetTableVersion() uint64 {
+	if m != nil {
+		return m.TableVersion
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetInputQueue() uint64 {
+	if m != nil {
+		return m.InputQueue
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetOutputQueue() uint64 {
+	if m != nil {
+		return m.OutputQueue
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetUpTime() string {
+	if m != nil {
+		return m.UpTime
+	}
+	return ""
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetPrefixesReceived() uint64 {
+	if m != nil {
+		return m.PrefixesReceived
+	}
+	return 0
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetDynamicallyConfigured() bool {
+	if m != nil {
+		return m.DynamicallyConfigured
+	}
+	return false
+}
+
+func (m *BgpAddressFamily_BgpNeighborSummary) GetAs() uint32 {
+	if m != nil {
+		return m.As
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*BgpAddressFamilyKeys)(nil), "gpbmodels.BgpAddressFamilyKeys")
+	proto.RegisterType((*BgpAddressFamily)(nil), "gpbmodels.BgpAddressFamily")
+	proto.RegisterType((*BgpAddressFamily_EntryStats)(nil), "gpbmodels.BgpAddressFamily.EntryStats")
+	proto.RegisterType((*BgpAddressFamily_BgpNeighborSummary)(nil), "gpbmodels.BgpAddressFamily.BgpNeighborSummary")
+}
+
+func init() { proto.RegisterFile("proto/gpbmodels/bgp_oper.proto", fileDescriptor_bf0fa38d1f74a784) }
+
+var fileDescriptor_bf0fa38d1f74a784 = []byte{
+	// 560 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xd1, 0x6e, 0xd3, 0x3e,
+	0x14, 0xc6, 0xd5, 0x2e, 0x5b, 0xdb, 0xd3, 0xee, 0xff, 0xdf, 0xcc, 0x06, 0x61, 0x48, 0xac, 0x0c,
+	0x09, 0x55, 0x20, 0x75, 0xd2, 0x10, 0x37, 0xdc, 0x6d, 0x68, 0x48, 0x08, 0x34, 0x09, 0x77, 0x70,
+	0x6b, 0x9c, 0xe6, 0x24, 0xb3, 0x14, 0xc7, 0xc6, 0x76, 0x2a, 0xf2, 0x3c, 0xbc, 0x07, 0xcf, 0x86,
+	0x6c, 0xb7, 0xdd, 0xd8, 0x10, 0x12, 0x5c, 0xfa, 0xf7, 0x9d, 0xe3, 0xf8, 0x3b, 0xe7, 0x53, 0xe0,
+	0xb1, 0x36, 0xca, 0xa9, 0xe3, 0x52, 0x67, 0x52, 0xe5, 0x58, 0xd9, 0xe3, 0xac, 0xd4, 0x4c, 0x69,
+	0x34, 0xd3, 0x20, 0x90, 0xc1, 0x5a, 0x39, 0xfa, 0x00, 0x7b, 0x67, 0xa5, 0x3e, 0xcd, 0x73, 0x83,
+	0xd6, 0xbe, 0xe5, 0x52, 0x54, 0xed, 0x7b, 0x6c, 0x2d, 0x79, 0x08, 0x7d, 0x5e, 0x08, 0x66, 0x79,
+	0x21, 0xd2, 0xce, 0xb8, 0x33, 0x19, 0xd0, 0x1e, 0x2f, 0xc4, 0x8c, 0x17, 0xc2, 0x4b, 0x0b, 0x53,
+	0xb0, 0x9a, 0x4b, 0x4c, 0xbb, 0x51, 0x5a, 0x98, 0xe2, 0x82, 0x4b, 0x3c, 0xfa, 0xd1, 0x83, 0x9d,
+	0xdb, 0xd7, 0xfd, 0xdb, 0x55, 0xe4, 0x11, 0x0c, 0x8c, 0x6a, 0x1c, 0x1a, 0x26, 0xf2, 0x74, 0x23,
+	0x68, 0xfd, 0x08, 0xde, 0xe5, 0xe4, 0x39, 0xec, 0x7a, 0x4b, 0x8e, 0x67, 0x15, 0xb2, 0x05, 0x1a,
+	0x2b, 0x54, 0x9d, 0x26, 0xe3, 0xce, 0x24, 0xa1, 0xff, 0x67, 0xa5, 0xbe, 0xf4, 0xfc, 0x73, 0xc4,
+	0xe4, 0x04, 0xf6, 0x7d, 0x9f, 0xa8, 0xcb, 0x5b, 0xf5, 0x9b, 0xa1, 0xfe, 0xde, 0x52, 0xfc, 0xa5,
+	0xe7, 0x0c, 0xfa, 0xda, 0x60, 0x21, 0xbe, 0xa1, 0x4d, 0xb7, 0xc6, 0x9d, 0xc9, 0xf0, 0xe4, 0xd9,
+	0x74, 0x3d, 0xb3, 0xe9, 0x6d, 0x87, 0xd3, 0xf3, 0xda, 0x99, 0x76, 0xe6, 0xb8, 0xb3, 0x74, 0xdd,
+	0x47, 0x5e, 0x43, 0xa2, 0xb9, 0xbb, 0x4a, 0x7b, 0x7f, 0xd5, 0x1f, 0x7a, 0xfc, 0x5c, 0x2a, 0x35,
+	0xe7, 0x15, 0xe3, 0x36, 0xed, 0x8f, 0x3b, 0x93, 0x6d, 0xda, 0x0b, 0xe7, 0x53, 0x4b, 0xbe, 0xc0,
+	0x9e, 0xb7, 0x5e, 0xa3, 0x28, 0xaf, 0x32, 0x65, 0x98, 0x6d, 0xa4, 0xe4, 0xa6, 0x4d, 0x07, 0xe3,
+	0x8d, 0xc9, 0xf0, 0x64, 0xfa, 0xa7, 0xcf, 0x9c, 0x95, 0xfa, 0x62, 0xd9, 0x36, 0x8b, 0x5d, 0x94,
+	0x64, 0x77, 0xd8, 0xc1, 0x25, 0xc0, 0xf5, 0x83, 0xc8, 0x53, 0xd8, 0x76, 0xca, 0xf1, 0x8a, 0x61,
+	0xed, 0x8c, 0x40, 0x1b, 0x56, 0x98, 0xd0, 0x51, 0x80, 0xe7, 0x91, 0x91, 0x27, 0x30, 0x92, 0x28,
+	0x95, 0x69, 0x59, 0x63, 0x79, 0x19, 0x77, 0x99, 0xd0, 0x61, 0x64, 0x9f, 0x3c, 0x3a, 0xf8, 0xbe,
+	0x01, 0xe4, 0xee, 0x03, 0xc8, 0x7f, 0xd0, 0x15, 0xf9, 0x32, 0x16, 0x5d, 0x91, 0x93, 0x43, 0x18,
+	0x7a, 0x7b, 0xab, 0x1d, 0x75, 0x83, 0x79, 0xc8, 0x4a, 0xbd, 0x5a, 0xcd, 0x0b, 0xd8, 0x95, 0x68,
+	0xfd, 0x95, 0x96, 0x19, 0x9c, 0xa3, 0x58, 0x60, 0xcc, 0x47, 0x42, 0x77, 0x56, 0x02, 0x5d, 0x72,
+	0xff, 0xf8, 0x75, 0xb1, 0xc5, 0xda, 0x2d, 0x33, 0x32, 0x5a, 0xc1, 0x19, 0xd6, 0x2e, 0x38, 0xfc,
+	0x4d, 0x30, 0x46, 0xee, 0x66, 0x22, 0x0e, 0x61, 0x28, 0x6a, 0xdd, 0x38, 0xf6, 0xb5, 0xc1, 0x06,
+	0x43, 0x28, 0x12, 0x0a, 0x01, 0x7d, 0xf4, 0xc4, 0x8f, 0x40, 0x35, 0xee, 0xba, 0xa2, 0x17, 0x47,
+	0x10, 0x59, 0x2c, 0x79, 0x00, 0xbd, 0x46, 0x33, 0x27, 0x24, 0x86, 0xa5, 0x0e, 0xe8, 0x56, 0xa3,
+	0x2f, 0x85, 0x44, 0xb2, 0x07, 0x9b, 0xd6, 0x71, 0x87, 0xe9, 0x20, 0xe0, 0x78, 0xf0, 0x4e, 0x57,
+	0x61, 0xba, 0x76, 0x0a, 0xd1, 0xe9, 0x4a, 0x58, 0x3b, 0x7d, 0x05, 0xf7, 0xf3, 0xb6, 0xe6, 0x52,
+	0xcc, 0x79, 0x55, 0xb5, 0x6c, 0xae, 0xea, 0x42, 0x94, 0x8d, 0xc1, 0x3c, 0x1d, 0x8e, 0x3b, 0x93,
+	0x3e, 0xdd, 0xbf, 0xa1, 0xbe, 0x59, 0x8b, 0x7e, 0xfc, 0xdc, 0xa6, 0xa3, 0x30, 0xe5, 0x2e, 0xb7,
+	0xd9, 0x56, 0xf8, 0x41, 0xbc, 0xfc, 0x39, 0x00, 0x73, 0xed, 0x92, 0xb9, 0x42, 0x04, 0x00, 0x00,
+}
@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/device_hardware_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type DeviceHardwareData struct {
+	DeviceHardware       *DeviceHardwareData_DeviceHardware `protobuf:"bytes,1,opt,name=device_hardware,json=deviceHardware,proto3" json:"device_hardware,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *DeviceHardwareData) Reset()         { *m = DeviceHardwareData{} }
+func (m *DeviceHardwareData) String() string { return proto.CompactTextString(m) }
+func (*DeviceHardwareData) ProtoMessage()    {}
+func (*DeviceHardwareData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df64b8a33d405e94, []int{0}
+}
+func (m *DeviceHardwareData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceHardwareData.Unmarshal(m, b)
+}
+func (m *DeviceHardwareData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceHardwareData.Marshal(b, m, deterministic)
+}
+func (m *DeviceHardwareData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceHardwareData.Merge(m, src)
+}
+func (m *DeviceHardwareData) XXX_Size() int {
+	return xxx_messageInfo_DeviceHardwareData.Size(m)
+}
+func (m *DeviceHardwareData) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceHardwareData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceHardwareData proto.InternalMessageInfo
+
+func (m *DeviceHardwareData) GetDeviceHardware() *DeviceHardwareData_DeviceHardware {
+	if m != nil {
+		return m.DeviceHardware
+	}
+	return nil
+}
+
+type DeviceHardwareData_DeviceHardware struct {
+	DeviceInventory      []*DeviceHardwareData_DeviceInventory `protobuf:"bytes,1,rep,name=device_inventory,json=deviceInventory,proto3" json:"device_inventory,omitempty"`
+	DeviceSystemData     *DeviceHardwareData_DeviceSystemData  `protobuf:"bytes,2,opt,name=device_system_data,json=deviceSystemData,proto3" json:"device_system_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *DeviceHardwareData_DeviceHardware) Reset()         { *m = DeviceHardwareData_DeviceHardware{} }
+func (m *DeviceHardwareData_DeviceHardware) String() string { return proto.CompactTextString(m) }
+func (*DeviceHardwareData_DeviceHardware) ProtoMessage()    {}
+func (*DeviceHardwareData_DeviceHardware) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df64b8a33d405e94, []int{0, 0}
+}
+func (m *DeviceHardwareData_DeviceHardware) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceHardwareData_DeviceHardware.Unmarshal(m, b)
+}
+func (m *DeviceHardwareData_DeviceHardware) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceHardwareData_DeviceHardware.Marshal(b, m, deterministic)
+}
+func (m *DeviceHardwareData_DeviceHardware) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceHardwareData_DeviceHardware.Merge(m, src)
+}
+func (m *DeviceHardwareData_DeviceHardware) XXX_Size() int {
+	return xxx_messageInfo_DeviceHardwareData_DeviceHardware.Size(m)
+}
+func (m *DeviceHardwareData_DeviceHardware) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceHardwareData_DeviceHardware.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceHardwareData_DeviceHardware proto.InternalMessageInfo
+
+func (m *DeviceHardwareData_DeviceHardware) GetDeviceInventory() []*DeviceHardwareData_DeviceInventory {
+	if m != nil {
+		return m.DeviceInventory
+	}
+	return nil
+}
+
+func (m *DeviceHardwareData_DeviceHardware) GetDeviceSystemData() *DeviceHardwareData_DeviceSystemData {
+	if m != nil {
+		return m.DeviceSystemData
+	}
+	return nil
+}
+
+type DeviceHardwareData_DeviceInventory struct {
+	HwType               string   `protobuf:"bytes,1,opt,name=hw_type,json=hwType,proto3" json:"hw_type,omitempty"`
+	HwDevIndex           uint32   `protobuf:"varint,2,opt,name=hw_dev_index,json=hwDevIndex,proto3" json:"hw_dev_index,omitempty"`
+	Version              string   `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	PartNumber           string   `protobuf:"bytes,4,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	SerialNumber         string   `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	HwDescription        string   `protobuf:"bytes,6,opt,name=hw_description,json=hwDescription,proto3" json:"hw_description,omitempty"`
+	DevName              string   `protobuf:"bytes,7,opt,name=dev_name,json=devName,proto3" json:"dev_name,omitempty"`
+	FieldReplaceable     bool     `protobuf:"varint,8,opt,name=field_replaceable,json=fieldReplaceable,proto3" json:"field_replaceable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceHardwareData_DeviceInventory) Reset()         { *m = DeviceHardwareData_DeviceInventory{} }
+func (m *DeviceHardwareData_DeviceInventory) String() string { return proto.CompactTextString(m) }
+func (*DeviceHardwareData_DeviceInventory) ProtoMessage()    {}
+func (*DeviceHardwareData_DeviceInventory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df64b8a33d405e94, []int{0, 1}
+}
+func (m *DeviceHardwareData_DeviceInventory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceHardwareData_DeviceInventory.Unmarshal(m, b)
+}
+func (m *DeviceHardwareData_DeviceInventory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceHardwareData_DeviceInventory.Marshal(b, m, deterministic)
+}
+func (m *DeviceHardwareData_DeviceInventory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceHardwareData_DeviceInventory.Merge(m, src)
+}
+func (m *DeviceHardwareData_DeviceInventory) XXX_Size() int {
+	return xxx_messageInfo_DeviceHardwareData_DeviceInventory.Size(m)
+}
+func (m *DeviceHardwareData_DeviceInventory) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceHardwareData_DeviceInventory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceHardwareData_DeviceInventory proto.InternalMessageInfo
+
+func (m *DeviceHardwareData_DeviceInventory) GetHwType() string {
+	if m != nil {
+		return m.HwType
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetHwDevIndex() uint32 {
+	if m != nil {
+		return m.HwDevIndex
+	}
+	return 0
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetPartNumber() string {
+	if m != nil {
+		return m.PartNumber
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetHwDescription() string {
+	if m != nil {
+		return m.HwDescription
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetDevName() string {
+	if m != nil {
+		return m.DevName
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceInventory) GetFieldReplaceable() bool {
+	if m != nil {
+		return m.FieldReplaceable
+	}
+	return false
+}
+
+type DeviceHardwareData_DeviceSystemData struct {
+	CurrentTime          string   `protobuf:"bytes,1,opt,name=current_time,json=currentTime,proto3" json:"current_time,omitempty"`
+	BootTime             string   `protobuf:"bytes,2,opt,name=boot_time,json=bootTime,proto3" json:"boot_time,omitempty"`
+	SoftwareVersion      string   `protobuf:"bytes,3,opt,name=software_version,json=softwareVersion,proto3" json:"software_version,omitempty"`
+	RommonVersion        string   `protobuf:"bytes,4,opt,name=rommon_version,json=rommonVersion,proto3" json:"rommon_version,omitempty"`
+	LastRebootReason     uint64   `protobuf:"varint,5,opt,name=last_reboot_reason,json=lastRebootReason,proto3" json:"last_reboot_reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceHardwareData_DeviceSystemData) Reset()         { *m = DeviceHardwareData_DeviceSystemData{} }
+func (m *DeviceHardwareData_DeviceSystemData) String() string { return proto.CompactTextString(m) }
+func (*DeviceHardwareData_DeviceSystemData) ProtoMessage()    {}
+func (*DeviceHardwareData_DeviceSystemData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df64b8a33d405e94, []int{0, 2}
+}
+func (m *DeviceHardwareData_DeviceSystemData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceHardwareData_DeviceSystemData.Unmarshal(m, b)
+}
+func (m *DeviceHardwareData_DeviceSystemData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceHardwareData_DeviceSystemData.Marshal(b, m, deterministic)
+}
+func (m *DeviceHardwareData_DeviceSystemData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceHardwareData_DeviceSystemData.Merge(m, src)
+}
+func (m *DeviceHardwareData_DeviceSystemData) XXX_Size() int {
+	return xxx_messageInfo_DeviceHardwareData_DeviceSystemData.Size(m)
+}
+func (m *DeviceHardwareData_DeviceSystemData) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceHardwareData_DeviceSystemData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceHardwareData_DeviceSystemData proto.InternalMessageInfo
+
+func (m *DeviceHardwareData_DeviceSystemData) GetCurrentTime() string {
+	if m != nil {
+		return m.CurrentTime
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceSystemData) GetBootTime() string {
+	if m != nil {
+		return m.BootTime
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceSystemData) GetSoftwareVersion() string {
+	if m != nil {
+		return m.SoftwareVersion
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceSystemData) GetRommonVersion() string {
+	if m != nil {
+		return m.RommonVersion
+	}
+	return ""
+}
+
+func (m *DeviceHardwareData_DeviceSystemData) GetLastRebootReason() uint64 {
+	if m != nil {
+		return m.LastRebootReason
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*DeviceHardwareData)(nil), "gpbmodels.DeviceHardwareData")
+	proto.RegisterType((*DeviceHardwareData_DeviceHardware)(nil), "gpbmodels.DeviceHardwareData.DeviceHardware")
+	proto.RegisterType((*DeviceHardwareData_DeviceInventory)(nil), "gpbmodels.DeviceHardwareData.DeviceInventory")
+	proto.RegisterType((*DeviceHardwareData_DeviceSystemData)(nil), "gpbmodels.DeviceHardwareData.DeviceSystemData")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/device_hardware_oper.proto", fileDescriptor_df64b8a33d405e94)
+}
+
+var fileDescriptor_df64b8a33d405e94 = []byte{
+	// 453 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xcd, 0x6e, 0xd4, 0x3e,
+	0x14, 0xc5, 0x95, 0x69, 0xff, 0xf3, 0x71, 0xe7, 0x2b, 0x7f, 0x6f, 0x08, 0xc3, 0x82, 0x01, 0x54,
+	0x69, 0x80, 0x32, 0x95, 0xca, 0x2b, 0xcc, 0x82, 0x6e, 0xba, 0x30, 0x05, 0xb1, 0x40, 0xb2, 0x9c,
+	0xf1, 0x2d, 0x89, 0x94, 0xd8, 0x91, 0xed, 0x26, 0xcc, 0xb3, 0xf0, 0x04, 0xbc, 0x09, 0x6b, 0x9e,
+	0x08, 0xe5, 0x26, 0x19, 0x34, 0x61, 0xd3, 0xa5, 0x7f, 0xe7, 0x5c, 0xdf, 0x93, 0xa3, 0x18, 0xde,
+	0x14, 0xd6, 0x78, 0x73, 0xf5, 0xad, 0x88, 0x73, 0xa3, 0x30, 0x73, 0x57, 0x0a, 0xcb, 0x74, 0x8f,
+	0x22, 0x91, 0x56, 0x55, 0xd2, 0xa2, 0x30, 0x05, 0xda, 0x2d, 0x99, 0xd8, 0xe4, 0xe8, 0x7a, 0xf9,
+	0x73, 0x08, 0x6c, 0x47, 0xce, 0x0f, 0xad, 0x71, 0x27, 0xbd, 0x64, 0x9f, 0x60, 0xd9, 0x9b, 0x8f,
+	0x82, 0x75, 0xb0, 0x99, 0x5e, 0x5f, 0x6e, 0x8f, 0xb3, 0xdb, 0x7f, 0xe7, 0x7a, 0x88, 0x2f, 0xd4,
+	0xc9, 0x79, 0xf5, 0x2b, 0x80, 0xc5, 0xa9, 0x85, 0x7d, 0x81, 0xb0, 0xdd, 0x94, 0xea, 0x12, 0xb5,
+	0x37, 0xf6, 0x10, 0x05, 0xeb, 0xb3, 0xcd, 0xf4, 0xfa, 0xdd, 0x63, 0x56, 0xdd, 0x74, 0x43, 0x7c,
+	0xa9, 0x4e, 0x01, 0xfb, 0x0a, 0xac, 0xbd, 0xd9, 0x1d, 0x9c, 0xc7, 0x5c, 0x28, 0xe9, 0x65, 0x34,
+	0xa0, 0xcf, 0xd8, 0x3e, 0xe6, 0xee, 0x8f, 0x34, 0x56, 0x03, 0x1e, 0xaa, 0x1e, 0x59, 0xfd, 0x18,
+	0xc0, 0xb2, 0x17, 0x81, 0x3d, 0x81, 0x51, 0x52, 0x09, 0x7f, 0x28, 0x9a, 0xb6, 0x26, 0x7c, 0x98,
+	0x54, 0x77, 0x87, 0x02, 0xd9, 0x1a, 0x66, 0x49, 0x25, 0x14, 0x96, 0x22, 0xd5, 0x0a, 0xbf, 0x53,
+	0x88, 0x39, 0x87, 0xa4, 0xda, 0x61, 0x79, 0x53, 0x13, 0x16, 0xc1, 0xa8, 0x44, 0xeb, 0x52, 0xa3,
+	0xa3, 0x33, 0x1a, 0xed, 0x8e, 0xec, 0x39, 0x4c, 0x0b, 0x69, 0xbd, 0xd0, 0x0f, 0x79, 0x8c, 0x36,
+	0x3a, 0x27, 0x15, 0x6a, 0x74, 0x4b, 0x84, 0xbd, 0x82, 0xb9, 0x43, 0x9b, 0xca, 0xac, 0xb3, 0xfc,
+	0x47, 0x96, 0x59, 0x03, 0x5b, 0xd3, 0x05, 0x2c, 0x28, 0x81, 0xdb, 0xdb, 0xb4, 0xf0, 0xf5, 0x9a,
+	0x21, 0xb9, 0xe6, 0x75, 0x86, 0x23, 0x64, 0x4f, 0x61, 0x5c, 0xa7, 0xd4, 0x32, 0xc7, 0x68, 0xd4,
+	0xe4, 0x50, 0x58, 0xde, 0xca, 0x1c, 0xd9, 0x5b, 0xf8, 0xff, 0x3e, 0xc5, 0x4c, 0x09, 0x8b, 0x45,
+	0x26, 0xf7, 0x28, 0xe3, 0x0c, 0xa3, 0xf1, 0x3a, 0xd8, 0x8c, 0x79, 0x48, 0x02, 0xff, 0xcb, 0x57,
+	0xbf, 0x03, 0x08, 0xfb, 0x25, 0xb2, 0x17, 0x30, 0xdb, 0x3f, 0x58, 0x8b, 0xda, 0x0b, 0x9f, 0xe6,
+	0x5d, 0x47, 0xd3, 0x96, 0xdd, 0xa5, 0x39, 0xb2, 0x67, 0x30, 0x89, 0x8d, 0x69, 0xf5, 0x01, 0xe9,
+	0xe3, 0x1a, 0x90, 0xf8, 0x1a, 0x42, 0x67, 0xee, 0x3d, 0xfd, 0xcd, 0xa7, 0x65, 0x2d, 0x3b, 0xfe,
+	0xb9, 0x2d, 0xed, 0x02, 0x16, 0xd6, 0xe4, 0xb9, 0xd1, 0x47, 0x63, 0xd3, 0xdb, 0xbc, 0xa1, 0x9d,
+	0xed, 0x12, 0x58, 0x26, 0x9d, 0x17, 0x16, 0x69, 0xab, 0x45, 0xe9, 0x8c, 0xa6, 0xfe, 0xce, 0x79,
+	0x58, 0x2b, 0x9c, 0x04, 0x4e, 0x3c, 0x1e, 0xd2, 0xeb, 0x79, 0xff, 0x67, 0x00, 0xa4, 0x0a, 0xcd,
+	0x66, 0x6b, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/eigrp_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type EigrpNbrKeys struct {
+	Afi                  string   `protobuf:"bytes,1,opt,name=afi,proto3" json:"afi,omitempty"`
+	VrfName              string   `protobuf:"bytes,2,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	AsNum                uint32   `protobuf:"varint,3,opt,name=as_num,json=asNum,proto3" json:"as_num,omitempty"`
+	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	NbrAddress           string   `protobuf:"bytes,5,opt,name=nbr_address,json=nbrAddress,proto3" json:"nbr_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EigrpNbrKeys) Reset()         { *m = EigrpNbrKeys{} }
+func (m *EigrpNbrKeys) String() string { return proto.CompactTextString(m) }
+func (*EigrpNbrKeys) ProtoMessage()    {}
+func (*EigrpNbrKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b1baa9c67e7146f, []int{0}
+}
+func (m *EigrpNbrKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EigrpNbrKeys.Unmarshal(m, b)
+}
+func (m *EigrpNbrKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EigrpNbrKeys.Marshal(b, m, deterministic)
+}
+func (m *EigrpNbrKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EigrpNbrKeys.Merge(m, src)
+}
+func (m *EigrpNbrKeys) XXX_Size() int {
+	return xxx_messageInfo_EigrpNbrKeys.Size(m)
+}
+func (m *EigrpNbrKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_EigrpNbrKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EigrpNbrKeys proto.InternalMessageInfo
+
+func (m *EigrpNbrKeys) GetAfi() string {
+	if m != nil {
+		return m.Afi
+	}
+	return ""
+}
+
+func (m *EigrpNbrKeys) GetVrfName() string {
+	if m != nil {
+		return m.VrfName
+	}
+	return ""
+}
+
+func (m *EigrpNbrKeys) GetAsNum() uint32 {
+	if m != nil {
+		return m.AsNum
+	}
+	return 0
+}
+
+func (m *EigrpNbrKeys) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EigrpNbrKeys) GetNbrAddress() string {
+	if m != nil {
+		return m.NbrAddress
+	}
+	return ""
+}
+
+type EigrpNbr struct {
+	NbrAddress           string   `protobuf:"bytes,1,opt,name=nbr_address,json=nbrAddress,proto3" json:"nbr_address,omitempty"`
+	InterfaceName        string   `protobuf:"bytes,2,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
+	HoldTime             uint32   `protobuf:"varint,3,opt,name=hold_time,json=holdTime,proto3" json:"hold_time,omitempty"`
+	Uptime               uint64   `protobuf:"varint,4,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Srtt                 uint32   `protobuf:"varint,5,opt,name=srtt,proto3" json:"srtt,omitempty"`
+	Rto                  uint32   `protobuf:"varint,6,opt,name=rto,proto3" json:"rto,omitempty"`
+	Qcount               uint32   `protobuf:"varint,7,opt,name=qcount,proto3" json:"qcount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EigrpNbr) Reset()         { *m = EigrpNbr{} }
+func (m *EigrpNbr) String() string { return proto.CompactTextString(m) }
+func (*EigrpNbr) ProtoMessage()    {}
+func (*EigrpNbr) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b1baa9c67e7146f, []int{1}
+}
+func (m *EigrpNbr) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EigrpNbr.Unmarshal(m, b)
+}
+func (m *EigrpNbr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EigrpNbr.Marshal(b, m, deterministic)
+}
+func (m *EigrpNbr) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EigrpNbr.Merge(m, src)
+}
+func (m *EigrpNbr) XXX_Size() int {
+	return xxx_messageInfo_EigrpNbr.Size(m)
+}
+func (m *EigrpNbr) XXX_DiscardUnknown() {
+	xxx_messageInfo_EigrpNbr.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EigrpNbr proto.InternalMessageInfo
+
+func (m *EigrpNbr) GetNbrAddress() string {
+	if m != nil {
+		return m.NbrAddress
+	}
+	return ""
+}
+
+func (m *EigrpNbr) GetInterfaceName() string {
+	if m != nil {
+		return m.InterfaceName
+	}
+	return ""
+}
+
+func (m *EigrpNbr) GetHoldTime() uint32 {
+	if m != nil {
+		return m.HoldTime
+	}
+	return 0
+}
+
+func (m *EigrpNbr) GetUptime() uint64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *EigrpNbr) GetSrtt() uint32 {
+	if m != nil {
+		return m.Srtt
+	}
+	return 0
+}
+
+func (m *EigrpNbr) GetRto() uint32 {
+	if m != nil {
+		return m.Rto
+	}
+	return 0
+}
+
+func (m *EigrpNbr) GetQcount() uint32 {
+	if m != nil {
+		return m.Qcount
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*EigrpNbrKeys)(nil), "gpbmodels.EigrpNbrKeys")
+	proto.RegisterType((*EigrpNbr)(nil), "gpbmodels.EigrpNbr")
+}
+
+func init() { proto.RegisterFile("proto/gpbmodels/eigrp_oper.proto", fileDescriptor_9b1baa9c67e7146f) }
+
+var fileDescriptor_9b1baa9c67e7146f = []byte{
+	// 264 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0xc1, 0x4a, 0x03, 0x31,
+	0x10, 0x86, 0x89, 0x6d, 0xb7, 0xed, 0xe8, 0x8a, 0x04, 0x94, 0x88, 0x07, 0x97, 0x82, 0xd0, 0x93,
+	0x3d, 0xf8, 0x04, 0x1e, 0x3c, 0x09, 0x7b, 0x58, 0xbc, 0x2f, 0xd9, 0xee, 0x6c, 0x0d, 0x34, 0xc9,
+	0x3a, 0xc9, 0x16, 0x7c, 0x02, 0x9f, 0xcc, 0xf7, 0x92, 0x8c, 0x6b, 0x91, 0xde, 0xfe, 0x7c, 0xff,
+	0x1f, 0xf8, 0x18, 0x28, 0x7a, 0xf2, 0xd1, 0x6f, 0x76, 0x7d, 0x63, 0x7d, 0x8b, 0xfb, 0xb0, 0x41,
+	0xb3, 0xa3, 0xbe, 0xf6, 0x3d, 0xd2, 0x23, 0x57, 0x72, 0x79, 0xec, 0x56, 0x5f, 0x02, 0x2e, 0x5e,
+	0x52, 0x5f, 0x36, 0xf4, 0x8a, 0x9f, 0x41, 0x5e, 0xc1, 0x44, 0x77, 0x46, 0x89, 0x42, 0xac, 0x97,
+	0x55, 0x8a, 0xf2, 0x16, 0x16, 0x07, 0xea, 0x6a, 0xa7, 0x2d, 0xaa, 0x33, 0xc6, 0xf3, 0x03, 0x75,
+	0xa5, 0xb6, 0x28, 0xaf, 0x21, 0xd3, 0xa1, 0x76, 0x83, 0x55, 0x93, 0x42, 0xac, 0xf3, 0x6a, 0xa6,
+	0x43, 0x39, 0x58, 0x29, 0x61, 0xca, 0xeb, 0x29, 0xaf, 0x39, 0xcb, 0x7b, 0x38, 0x77, 0x0d, 0xd5,
+	0xba, 0x6d, 0x09, 0x43, 0x50, 0x33, 0xae, 0xc0, 0x35, 0xf4, 0xfc, 0x4b, 0x56, 0xdf, 0x02, 0x16,
+	0x7f, 0x26, 0xa7, 0x6b, 0x71, 0xba, 0x96, 0x0f, 0x70, 0x69, 0x5c, 0x44, 0xea, 0xf4, 0x16, 0xff,
+	0xab, 0xe5, 0x47, 0xca, 0x82, 0x77, 0xb0, 0x7c, 0xf7, 0xfb, 0xb6, 0x8e, 0xc6, 0xe2, 0xe8, 0xb8,
+	0x48, 0xe0, 0xcd, 0x58, 0x94, 0x37, 0x90, 0x0d, 0x7d, 0x34, 0xa3, 0xe8, 0xb4, 0x1a, 0x5f, 0x49,
+	0x3f, 0x50, 0x8c, 0xec, 0x98, 0x57, 0x9c, 0xd3, 0x59, 0x28, 0x7a, 0x95, 0x31, 0x4a, 0x31, 0xfd,
+	0xfe, 0xd8, 0xfa, 0xc1, 0x45, 0x35, 0x67, 0x38, 0xbe, 0x9a, 0x8c, 0x6f, 0xfc, 0xf4, 0x33, 0x00,
+	0xe0, 0x28, 0x4c, 0x79, 0x87, 0x01, 0x00, 0x00,
+}
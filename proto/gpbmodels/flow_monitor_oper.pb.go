@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/flow_monitor_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type FlowMonitorKeys struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlowMonitorKeys) Reset()         { *m = FlowMonitorKeys{} }
+func (m *FlowMonitorKeys) String() string { return proto.CompactTextString(m) }
+func (*FlowMonitorKeys) ProtoMessage()    {}
+func (*FlowMonitorKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0e7fe41ff89f8f7a, []int{0}
+}
+func (m *FlowMonitorKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowMonitorKeys.Unmarshal(m, b)
+}
+func (m *FlowMonitorKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowMonitorKeys.Marshal(b, m, deterministic)
+}
+func (m *FlowMonitorKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowMonitorKeys.Merge(m, src)
+}
+func (m *FlowMonitorKeys) XXX_Size() int {
+	return xxx_messageInfo_FlowMonitorKeys.Size(m)
+}
+func (m *FlowMonitorKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowMonitorKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowMonitorKeys proto.InternalMessageInfo
+
+func (m *FlowMonitorKeys) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type FlowMonitor struct {
+	Name                 string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TimeCollected        string              `protobuf:"bytes,2,opt,name=time_collected,json=timeCollected,proto3" json:"time_collected,omitempty"`
+	Flow                 []*FlowMonitor_Flow `protobuf:"bytes,3,rep,name=flow,proto3" json:"flow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *FlowMonitor) Reset()         { *m = FlowMonitor{} }
+func (m *FlowMonitor) String() string { return proto.CompactTextString(m) }
+func (*FlowMonitor) ProtoMessage()    {}
+func (*FlowMonitor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0e7fe41ff89f8f7a, []int{1}
+}
+func (m *FlowMonitor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowMonitor.Unmarshal(m, b)
+}
+func (m *FlowMonitor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowMonitor.Marshal(b, m, deterministic)
+}
+func (m *FlowMonitor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowMonitor.Merge(m, src)
+}
+func (m *FlowMonitor) XXX_Size() int {
+	return xxx_messageInfo_FlowMonitor.Size(m)
+}
+func (m *FlowMonitor) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowMonitor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowMonitor proto.InternalMessageInfo
+
+func (m *FlowMonitor) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FlowMonitor) GetTimeCollected() string {
+	if m != nil {
+		return m.TimeCollected
+	}
+	return ""
+}
+
+func (m *FlowMonitor) GetFlow() []*FlowMonitor_Flow {
+	if m != nil {
+		return m.Flow
+	}
+	return nil
+}
+
+type FlowMonitor_Flow struct {
+	SourceAddress        string   `protobuf:"bytes,1,opt,name=source_address,json=sourceAddress,proto3" json:"source_address,omitempty"`
+	DestinationAddress   string   `protobuf:"bytes,2,opt,name=destination_address,json=destinationAddress,proto3" json:"destination_address,omitempty"`
+	InterfaceInput       string   `protobuf:"bytes,3,opt,name=interface_input,json=interfaceInput,proto3" json:"interface_input,omitempty"`
+	IsMulticast          string   `protobuf:"bytes,4,opt,name=is_multicast,json=isMulticast,proto3" json:"is_multicast,omitempty"`
+	VrfIdInput           uint32   `protobuf:"varint,5,opt,name=vrf_id_input,json=vrfIdInput,proto3" json:"vrf_id_input,omitempty"`
+	SourcePort           uint32   `protobuf:"varint,6,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	DestinationPort      uint32   `protobuf:"varint,7,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	IpTos                string   `protobuf:"bytes,8,opt,name=ip_tos,json=ipTos,proto3" json:"ip_tos,omitempty"`
+	IpProtocol           uint32   `protobuf:"varint,9,opt,name=ip_protocol,json=ipProtocol,proto3" json:"ip_protocol,omitempty"`
+	InterfaceOutput      string   `protobuf:"bytes,10,opt,name=interface_output,json=interfaceOutput,proto3" json:"interface_output,omitempty"`
+	Bytes                uint64   `protobuf:"varint,11,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Packets              uint64   `protobuf:"varint,12,opt,name=packets,proto3" json:"packets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlowMonitor_Flow) Reset()         { *m = FlowMonitor_Flow{} }
+func (m *FlowMonitor_Flow) String() string { return proto.CompactTextString(m) }
+func (*FlowMonitor_Flow) ProtoMessage()    {}
+func (*FlowMonitor_Flow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0e7fe41ff89f8f7a, []int{1, 0}
+}
+func (m *FlowMonitor_Flow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowMonitor_Flow.Unmarshal(m, b)
+}
+func (m *FlowMonitor_Flow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowMonitor_Flow.Marshal(b, m, deterministic)
+}
+func (m *FlowMonitor_Flow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowMonitor_Flow.Merge(m, src)
+}
+func (m *FlowMonitor_Flow) XXX_Size() int {
+	return xxx_messageInfo_FlowMonitor_Flow.Size(m)
+}
+func (m *FlowMonitor_Flow) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowMonitor_Flow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowMonitor_Flow proto.InternalMessageInfo
+
+func (m *FlowMonitor_Flow) GetSourceAddress() string {
+	if m != nil {
+		return m.SourceAddress
+	}
+	return ""
+}
+
+func (m *FlowMonitor_Flow) GetDestinationAddress() string {
+	if m != nil {
+		return m.DestinationAddress
+	}
+	return ""
+}
+
+func (m *FlowMonitor_Flow) GetInterfaceInput() string {
+	if m != nil {
+		return m.InterfaceInput
+	}
+	return ""
+}
+
+func (m *FlowMonitor_Flow) GetIsMulticast() string {
+	if m != nil {
+		return m.IsMulticast
+	}
+	return ""
+}
+
+func (m *FlowMonitor_Flow) GetVrfIdInput() uint32 {
+	if m != nil {
+		return m.VrfIdInput
+	}
+	return 0
+}
+
+func (m *FlowMonitor_Flow) GetSourcePort() uint32 {
+	if m != nil {
+		return m.SourcePort
+	}
+	return 0
+}
+
+func (m *FlowMonitor_Flow) GetDestinationPort() uint32 {
+	if m != nil {
+		return m.DestinationPort
+	}
+	return 0
+}
+
+func (m *FlowMonitor_Flow) GetIpTos() string {
+	if m != nil {
+		return m.IpTos
+	}
+	return ""
+}
+
+func (m *FlowMonitor_Flow) GetIpProtocol() uint32 {
+	if m != nil {
+		return m.IpProtocol
+	}
+	return 0
+}
+
+func (m *FlowMonitor_Flow) GetInterfaceOutput() string {
+	if m != nil {
+		return m.InterfaceOutput
+	}
+	return ""
+}
+
+func (m *FlowMonitor_Flow) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *FlowMonitor_Flow) GetPackets() uint64 {
+	if m != nil {
+		return m.Packets
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*FlowMonitorKeys)(nil), "gpbmodels.FlowMonitorKeys")
+	proto.RegisterType((*FlowMonitor)(nil), "gpbmodels.FlowMonitor")
+	proto.RegisterType((*FlowMonitor_Flow)(nil), "gpbmodels.FlowMonitor.Flow")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/flow_monitor_oper.proto", fileDescriptor_0e7fe41ff89f8f7a)
+}
+
+var fileDescriptor_0e7fe41ff89f8f7a = []byte{
+	// 382 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xd1, 0x6a, 0xdb, 0x30,
+	0x14, 0x86, 0xc9, 0xe2, 0xa4, 0xcb, 0x71, 0xd2, 0x0c, 0x6d, 0x03, 0xb1, 0x5d, 0xcc, 0x2b, 0x94,
+	0x66, 0x37, 0x09, 0x6c, 0x4f, 0x30, 0x06, 0x83, 0x32, 0xca, 0x4a, 0xd8, 0xbd, 0x70, 0x6c, 0x79,
+	0x88, 0xd9, 0x3e, 0x42, 0x3a, 0x6e, 0xe9, 0x13, 0xed, 0x62, 0x2f, 0x39, 0x74, 0x64, 0x3b, 0xbe,
+	0xe8, 0x9d, 0xce, 0xa7, 0x2f, 0xe7, 0xff, 0x15, 0xc3, 0x8d, 0x75, 0x48, 0x78, 0xf8, 0x6d, 0x4f,
+	0x0d, 0x96, 0xba, 0xf6, 0x87, 0xaa, 0xc6, 0x47, 0xd5, 0x60, 0x6b, 0x08, 0x9d, 0x42, 0xab, 0xdd,
+	0x9e, 0x0d, 0xb1, 0x1a, 0x95, 0xab, 0x6b, 0xd8, 0x7e, 0xaf, 0xf1, 0xf1, 0x2e, 0x4a, 0x3f, 0xf4,
+	0x93, 0x17, 0x02, 0x92, 0x36, 0x6f, 0xb4, 0x9c, 0x65, 0xb3, 0xdd, 0xea, 0xc8, 0xe7, 0xab, 0xbf,
+	0x09, 0xa4, 0x13, 0xef, 0x39, 0x47, 0x5c, 0xc3, 0x25, 0x99, 0x46, 0xab, 0x02, 0xeb, 0x5a, 0x17,
+	0xa4, 0x4b, 0xf9, 0x82, 0x6f, 0x37, 0x81, 0x7e, 0x1b, 0xa0, 0x38, 0x40, 0x12, 0x7a, 0xc9, 0x79,
+	0x36, 0xdf, 0xa5, 0x9f, 0xdf, 0xef, 0xc7, 0x2e, 0xfb, 0x49, 0x00, 0x9f, 0x8f, 0x2c, 0xbe, 0xfb,
+	0x37, 0x87, 0x24, 0x8c, 0x21, 0xc0, 0x63, 0xe7, 0x0a, 0xad, 0xf2, 0xb2, 0x74, 0xda, 0xfb, 0x3e,
+	0x7e, 0x13, 0xe9, 0xd7, 0x08, 0xc5, 0x01, 0x5e, 0x97, 0xda, 0x93, 0x69, 0x73, 0x32, 0xd8, 0x8e,
+	0x6e, 0x2c, 0x23, 0x26, 0x57, 0xc3, 0x0f, 0x6e, 0x60, 0x6b, 0x5a, 0xd2, 0xae, 0xca, 0x0b, 0xad,
+	0x4c, 0x6b, 0x3b, 0x92, 0x73, 0x96, 0x2f, 0x47, 0x7c, 0x1b, 0xa8, 0xf8, 0x08, 0x6b, 0xe3, 0x55,
+	0xd3, 0xd5, 0x64, 0x8a, 0xdc, 0x93, 0x4c, 0xd8, 0x4a, 0x8d, 0xbf, 0x1b, 0x90, 0xc8, 0x60, 0xfd,
+	0xe0, 0x2a, 0x65, 0xca, 0x7e, 0xd1, 0x22, 0x9b, 0xed, 0x36, 0x47, 0x78, 0x70, 0xd5, 0x6d, 0x19,
+	0x97, 0x7c, 0x80, 0xb4, 0x7f, 0x85, 0x45, 0x47, 0x72, 0x19, 0x85, 0x88, 0xee, 0xd1, 0x91, 0xf8,
+	0x04, 0xaf, 0xa6, 0xfd, 0xd9, 0xba, 0x60, 0x6b, 0x3b, 0xe1, 0xac, 0xbe, 0x85, 0xa5, 0xb1, 0x8a,
+	0xd0, 0xcb, 0x97, 0x5c, 0x65, 0x61, 0xec, 0x2f, 0xf4, 0x21, 0xc2, 0x58, 0xc5, 0xdf, 0xba, 0xc0,
+	0x5a, 0xae, 0x62, 0x84, 0xb1, 0xf7, 0x3d, 0x09, 0x11, 0xe7, 0x17, 0x63, 0x47, 0xa1, 0x29, 0xf0,
+	0x86, 0xf3, 0x3f, 0xf1, 0x93, 0xb1, 0x78, 0x03, 0x8b, 0xd3, 0x13, 0x69, 0x2f, 0xd3, 0x6c, 0xb6,
+	0x4b, 0x8e, 0x71, 0x10, 0x12, 0x2e, 0x6c, 0x5e, 0xfc, 0xd1, 0xe4, 0xe5, 0x9a, 0xf9, 0x30, 0x9e,
+	0x96, 0x1c, 0xfb, 0xe5, 0xff, 0x00, 0x27, 0xc0, 0x43, 0xd3, 0x8d, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,5 @@
+package gpbmodels
+
+// The Go models are generated from the protos with protoc and the protoc-gen-gogo plugin. Paths are relative to the
+// repository root so the sources recorded in the generated files match the other Telemetry protos.
+//go:generate sh -c "protoc --proto_path=../.. --gogo_out=paths=source_relative:../.. ../../proto/gpbmodels/*.proto"
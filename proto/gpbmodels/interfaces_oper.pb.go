@@ -0,0 +1,874 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/interfaces_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type InterfaceKeys struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InterfaceKeys) Reset()         { *m = InterfaceKeys{} }
+func (m *InterfaceKeys) String() string { return proto.CompactTextString(m) }
+func (*InterfaceKeys) ProtoMessage()    {}
+func (*InterfaceKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{0}
+}
+func (m *InterfaceKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InterfaceKeys.Unmarshal(m, b)
+}
+func (m *InterfaceKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InterfaceKeys.Marshal(b, m, deterministic)
+}
+func (m *InterfaceKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterfaceKeys.Merge(m, src)
+}
+func (m *InterfaceKeys) XXX_Size() int {
+	return xxx_messageInfo_InterfaceKeys.Size(m)
+}
+func (m *InterfaceKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterfaceKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterfaceKeys proto.InternalMessageInfo
+
+func (m *InterfaceKeys) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type Interface struct {
+	Name                 string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	InterfaceType        string                     `protobuf:"bytes,2,opt,name=interface_type,json=interfaceType,proto3" json:"interface_type,omitempty"`
+	AdminStatus          string                     `protobuf:"bytes,3,opt,name=admin_status,json=adminStatus,proto3" json:"admin_status,omitempty"`
+	OperStatus           string                     `protobuf:"bytes,4,opt,name=oper_status,json=operStatus,proto3" json:"oper_status,omitempty"`
+	LastChange           string                     `protobuf:"bytes,5,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`
+	IfIndex              uint32                     `protobuf:"varint,6,opt,name=if_index,json=ifIndex,proto3" json:"if_index,omitempty"`
+	PhysAddress          string                     `protobuf:"bytes,7,opt,name=phys_address,json=physAddress,proto3" json:"phys_address,omitempty"`
+	Speed                uint64                     `protobuf:"varint,8,opt,name=speed,proto3" json:"speed,omitempty"`
+	Mtu                  uint32                     `protobuf:"varint,9,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Vrf                  string                     `protobuf:"bytes,10,opt,name=vrf,proto3" json:"vrf,omitempty"`
+	Ipv4                 string                     `protobuf:"bytes,11,opt,name=ipv4,proto3" json:"ipv4,omitempty"`
+	Ipv4SubnetMask       string                     `protobuf:"bytes,12,opt,name=ipv4_subnet_mask,json=ipv4SubnetMask,proto3" json:"ipv4_subnet_mask,omitempty"`
+	Description          string                     `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	Statistics           *Interface_Statistics      `protobuf:"bytes,14,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	V4ProtocolStats      *Interface_V4ProtocolStats `protobuf:"bytes,15,opt,name=v4_protocol_stats,json=v4ProtocolStats,proto3" json:"v4_protocol_stats,omitempty"`
+	DiffservInfo         []*Interface_DiffservInfo  `protobuf:"bytes,16,rep,name=diffserv_info,json=diffservInfo,proto3" json:"diffserv_info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *Interface) Reset()         { *m = Interface{} }
+func (m *Interface) String() string { return proto.CompactTextString(m) }
+func (*Interface) ProtoMessage()    {}
+func (*Interface) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1}
+}
+func (m *Interface) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface.Unmarshal(m, b)
+}
+func (m *Interface) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface.Marshal(b, m, deterministic)
+}
+func (m *Interface) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface.Merge(m, src)
+}
+func (m *Interface) XXX_Size() int {
+	return xxx_messageInfo_Interface.Size(m)
+}
+func (m *Interface) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface proto.InternalMessageInfo
+
+func (m *Interface) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Interface) GetInterfaceType() string {
+	if m != nil {
+		return m.InterfaceType
+	}
+	return ""
+}
+
+func (m *Interface) GetAdminStatus() string {
+	if m != nil {
+		return m.AdminStatus
+	}
+	return ""
+}
+
+func (m *Interface) GetOperStatus() string {
+	if m != nil {
+		return m.OperStatus
+	}
+	return ""
+}
+
+func (m *Interface) GetLastChange() string {
+	if m != nil {
+		return m.LastChange
+	}
+	return ""
+}
+
+func (m *Interface) GetIfIndex() uint32 {
+	if m != nil {
+		return m.IfIndex
+	}
+	return 0
+}
+
+func (m *Interface) GetPhysAddress() string {
+	if m != nil {
+		return m.PhysAddress
+	}
+	return ""
+}
+
+func (m *Interface) GetSpeed() uint64 {
+	if m != nil {
+		return m.Speed
+	}
+	return 0
+}
+
+func (m *Interface) GetMtu() uint32 {
+	if m != nil {
+		return m.Mtu
+	}
+	return 0
+}
+
+func (m *Interface) GetVrf() string {
+	if m != nil {
+		return m.Vrf
+	}
+	return ""
+}
+
+func (m *Interface) GetIpv4() string {
+	if m != nil {
+		return m.Ipv4
+	}
+	return ""
+}
+
+func (m *Interface) GetIpv4SubnetMask() string {
+	if m != nil {
+		return m.Ipv4SubnetMask
+	}
+	return ""
+}
+
+func (m *Interface) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Interface) GetStatistics() *Interface_Statistics {
+	if m != nil {
+		return m.Statistics
+	}
+	return nil
+}
+
+func (m *Interface) GetV4ProtocolStats() *Interface_V4ProtocolStats {
+	if m != nil {
+		return m.V4ProtocolStats
+	}
+	return nil
+}
+
+func (m *Interface) GetDiffservInfo() []*Interface_DiffservInfo {
+	if m != nil {
+		return m.DiffservInfo
+	}
+	return nil
+}
+
+type Interface_Statistics struct {
+	DiscontinuityTime    string   `protobuf:"bytes,1,opt,name=discontinuity_time,json=discontinuityTime,proto3" json:"discontinuity_time,omitempty"`
+	InOctets             uint64   `protobuf:"varint,2,opt,name=in_octets,json=inOctets,proto3" json:"in_octets,omitempty"`
+	InUnicastPkts        uint64   `protobuf:"varint,3,opt,name=in_unicast_pkts,json=inUnicastPkts,proto3" json:"in_unicast_pkts,omitempty"`
+	InBroadcastPkts      uint64   `protobuf:"varint,4,opt,name=in_broadcast_pkts,json=inBroadcastPkts,proto3" json:"in_broadcast_pkts,omitempty"`
+	InMulticastPkts      uint64   `protobuf:"varint,5,opt,name=in_multicast_pkts,json=inMulticastPkts,proto3" json:"in_multicast_pkts,omitempty"`
+	InDiscards           uint32   `protobuf:"varint,6,opt,name=in_discards,json=inDiscards,proto3" json:"in_discards,omitempty"`
+	InErrors             uint32   `protobuf:"varint,7,opt,name=in_errors,json=inErrors,proto3" json:"in_errors,omitempty"`
+	InUnknownProtos      uint32   `protobuf:"varint,8,opt,name=in_unknown_protos,json=inUnknownProtos,proto3" json:"in_unknown_protos,omitempty"`
+	OutOctets            uint64   `protobuf:"varint,9,opt,name=out_octets,json=outOctets,proto3" json:"out_octets,omitempty"`
+	OutUnicastPkts       uint64   `protobuf:"varint,10,opt,name=out_unicast_pkts,json=outUnicastPkts,proto3" json:"out_unicast_pkts,omitempty"`
+	OutBroadcastPkts     uint64   `protobuf:"varint,11,opt,name=out_broadcast_pkts,json=outBroadcastPkts,proto3" json:"out_broadcast_pkts,omitempty"`
+	OutMulticastPkts     uint64   `protobuf:"varint,12,opt,name=out_multicast_pkts,json=outMulticastPkts,proto3" json:"out_multicast_pkts,omitempty"`
+	OutDiscards          uint64   `protobuf:"varint,13,opt,name=out_discards,json=outDiscards,proto3" json:"out_discards,omitempty"`
+	OutErrors            uint64   `protobuf:"varint,14,opt,name=out_errors,json=outErrors,proto3" json:"out_errors,omitempty"`
+	RxPps                uint64   `protobuf:"varint,15,opt,name=rx_pps,json=rxPps,proto3" json:"rx_pps,omitempty"`
+	RxKbps               uint64   `protobuf:"varint,16,opt,name=rx_kbps,json=rxKbps,proto3" json:"rx_kbps,omitempty"`
+	TxPps                uint64   `protobuf:"varint,17,opt,name=tx_pps,json=txPps,proto3" json:"tx_pps,omitempty"`
+	TxKbps               uint64   `protobuf:"varint,18,opt,name=tx_kbps,json=txKbps,proto3" json:"tx_kbps,omitempty"`
+	NumFlaps             uint64   `protobuf:"varint,19,opt,name=num_flaps,json=numFlaps,proto3" json:"num_flaps,omitempty"`
+	InCrcErrors          uint64   `protobuf:"varint,20,opt,name=in_crc_errors,json=inCrcErrors,proto3" json:"in_crc_errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Interface_Statistics) Reset()         { *m = Interface_Statistics{} }
+func (m *Interface_Statistics) String() string { return proto.CompactTextString(m) }
+func (*Interface_Statistics) ProtoMessage()    {}
+func (*Interface_Statistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1, 0}
+}
+func (m *Interface_Statistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface_Statistics.Unmarshal(m, b)
+}
+func (m *Interface_Statistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface_Statistics.Marshal(b, m, deterministic)
+}
+func (m *Interface_Statistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface_Statistics.Merge(m, src)
+}
+func (m *Interface_Statistics) XXX_Size() int {
+	return xxx_messageInfo_Interface_Statistics.Size(m)
+}
+func (m *Interface_Statistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface_Statistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface_Statistics proto.InternalMessageInfo
+
+func (m *Interface_Statistics) GetDiscontinuityTime() string {
+	if m != nil {
+		return m.DiscontinuityTime
+	}
+	return ""
+}
+
+func (m *Interface_Statistics) GetInOctets() uint64 {
+	if m != nil {
+		return m.InOctets
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInUnicastPkts() uint64 {
+	if m != nil {
+		return m.InUnicastPkts
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInBroadcastPkts() uint64 {
+	if m != nil {
+		return m.InBroadcastPkts
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInMulticastPkts() uint64 {
+	if m != nil {
+		return m.InMulticastPkts
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInDiscards() uint32 {
+	if m != nil {
+		return m.InDiscards
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInErrors() uint32 {
+	if m != nil {
+		return m.InErrors
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInUnknownProtos() uint32 {
+	if m != nil {
+		return m.InUnknownProtos
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetOutOctets() uint64 {
+	if m != nil {
+		return m.OutOctets
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetOutUnicastPkts() uint64 {
+	if m != nil {
+		return m.OutUnicastPkts
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetOutBroadcastPkts() uint64 {
+	if m != nil {
+		return m.OutBroadcastPkts
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetOutMulticastPkts() uint64 {
+	if m != nil {
+		return m.OutMulticastPkts
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetOutDiscards() uint64 {
+	if m != nil {
+		return m.OutDiscards
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetOutErrors() uint64 {
+	if m != nil {
+		return m.OutErrors
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetRxPps() uint64 {
+	if m != nil {
+		return m.RxPps
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetRxKbps() uint64 {
+	if m != nil {
+		return m.RxKbps
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetTxPps() uint64 {
+	if m != nil {
+		return m.TxPps
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetTxKbps() uint64 {
+	if m != nil {
+		return m.TxKbps
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetNumFlaps() uint64 {
+	if m != nil {
+		return m.NumFlaps
+	}
+	return 0
+}
+
+func (m *Interface_Statistics) GetInCrcErrors() uint64 {
+	if m != nil {
+		return m.InCrcErrors
+	}
+	return 0
+}
+
+type Interface_V4ProtocolStats struct {
+	InPkts               uint64   `protobuf:"varint,1,opt,name=in_pkts,json=inPkts,proto3" json:"in_pkts,omitempty"`
+	InOctets             uint64   `protobuf:"varint,2,opt,name=in_octets,json=inOctets,proto3" json:"in_octets,omitempty"`
+	InErrorPkts          uint64   `protobuf:"varint,3,opt,name=in_error_pkts,json=inErrorPkts,proto3" json:"in_error_pkts,omitempty"`
+	InForwardedPkts      uint64   `protobuf:"varint,4,opt,name=in_forwarded_pkts,json=inForwardedPkts,proto3" json:"in_forwarded_pkts,omitempty"`
+	InForwardedOctets    uint64   `protobuf:"varint,5,opt,name=in_forwarded_octets,json=inForwardedOctets,proto3" json:"in_forwarded_octets,omitempty"`
+	InDiscardedPkts      uint64   `protobuf:"varint,6,opt,name=in_discarded_pkts,json=inDiscardedPkts,proto3" json:"in_discarded_pkts,omitempty"`
+	OutPkts              uint64   `protobuf:"varint,7,opt,name=out_pkts,json=outPkts,proto3" json:"out_pkts,omitempty"`
+	OutOctets            uint64   `protobuf:"varint,8,opt,name=out_octets,json=outOctets,proto3" json:"out_octets,omitempty"`
+	OutErrorPkts         uint64   `protobuf:"varint,9,opt,name=out_error_pkts,json=outErrorPkts,proto3" json:"out_error_pkts,omitempty"`
+	OutForwardedPkts     uint64   `protobuf:"varint,10,opt,name=out_forwarded_pkts,json=outForwardedPkts,proto3" json:"out_forwarded_pkts,omitempty"`
+	OutForwardedOctets   uint64   `protobuf:"varint,11,opt,name=out_forwarded_octets,json=outForwardedOctets,proto3" json:"out_forwarded_octets,omitempty"`
+	OutDiscardedPkts     uint64   `protobuf:"varint,12,opt,name=out_discarded_pkts,json=outDiscardedPkts,proto3" json:"out_discarded_pkts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Interface_V4ProtocolStats) Reset()         { *m = Interface_V4ProtocolStats{} }
+func (m *Interface_V4ProtocolStats) String() string { return proto.CompactTextString(m) }
+func (*Interface_V4ProtocolStats) ProtoMessage()    {}
+func (*Interface_V4ProtocolStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1, 1}
+}
+func (m *Interface_V4ProtocolStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface_V4ProtocolStats.Unmarshal(m, b)
+}
+func (m *Interface_V4ProtocolStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface_V4ProtocolStats.Marshal(b, m, deterministic)
+}
+func (m *Interface_V4ProtocolStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface_V4ProtocolStats.Merge(m, src)
+}
+func (m *Interface_V4ProtocolStats) XXX_Size() int {
+	return xxx_messageInfo_Interface_V4ProtocolStats.Size(m)
+}
+func (m *Interface_V4ProtocolStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface_V4ProtocolStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface_V4ProtocolStats proto.InternalMessageInfo
+
+func (m *Interface_V4ProtocolStats) GetInPkts() uint64 {
+	if m != nil {
+		return m.InPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetInOctets() uint64 {
+	if m != nil {
+		return m.InOctets
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetInErrorPkts() uint64 {
+	if m != nil {
+		return m.InErrorPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetInForwardedPkts() uint64 {
+	if m != nil {
+		return m.InForwardedPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetInForwardedOctets() uint64 {
+	if m != nil {
+		return m.InForwardedOctets
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetInDiscardedPkts() uint64 {
+	if m != nil {
+		return m.InDiscardedPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetOutPkts() uint64 {
+	if m != nil {
+		return m.OutPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetOutOctets() uint64 {
+	if m != nil {
+		return m.OutOctets
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetOutErrorPkts() uint64 {
+	if m != nil {
+		return m.OutErrorPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetOutForwardedPkts() uint64 {
+	if m != nil {
+		return m.OutForwardedPkts
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetOutForwardedOctets() uint64 {
+	if m != nil {
+		return m.OutForwardedOctets
+	}
+	return 0
+}
+
+func (m *Interface_V4ProtocolStats) GetOutDiscardedPkts() uint64 {
+	if m != nil {
+		return m.OutDiscardedPkts
+	}
+	return 0
+}
+
+type Interface_DiffservInfo struct {
+	Direction                     string                                     `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	PolicyName                    string                                     `protobuf:"bytes,2,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	DiffservTargetClassifierStats []*Interface_DiffservTargetClassifierStats `protobuf:"bytes,3,rep,name=diffserv_target_classifier_stats,json=diffservTargetClassifierStats,proto3" json:"diffserv_target_classifier_stats,omitempty"`
+	XXX_NoUnkeyedLiteral          struct{}                                   `json:"-"`
+	XXX_unrecognized              []byte                                     `json:"-"`
+	XXX_sizecache                 int32                                      `json:"-"`
+}
+
+func (m *Interface_DiffservInfo) Reset()         { *m = Interface_DiffservInfo{} }
+func (m *Interface_DiffservInfo) String() string { return proto.CompactTextString(m) }
+func (*Interface_DiffservInfo) ProtoMessage()    {}
+func (*Interface_DiffservInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1, 2}
+}
+func (m *Interface_DiffservInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface_DiffservInfo.Unmarshal(m, b)
+}
+func (m *Interface_DiffservInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface_DiffservInfo.Marshal(b, m, deterministic)
+}
+func (m *Interface_DiffservInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface_DiffservInfo.Merge(m, src)
+}
+func (m *Interface_DiffservInfo) XXX_Size() int {
+	return xxx_messageInfo_Interface_DiffservInfo.Size(m)
+}
+func (m *Interface_DiffservInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface_DiffservInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface_DiffservInfo proto.InternalMessageInfo
+
+func (m *Interface_DiffservInfo) GetDirection() string {
+	if m != nil {
+		return m.Direction
+	}
+	return ""
+}
+
+func (m *Interface_DiffservInfo) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+func (m *Interface_DiffservInfo) GetDiffservTargetClassifierStats() []*Interface_DiffservTargetClassifierStats {
+	if m != nil {
+		return m.DiffservTargetClassifierStats
+	}
+	return nil
+}
+
+type Interface_DiffservTargetClassifierStats struct {
+	ClassifierEntryName  string                          `protobuf:"bytes,1,opt,name=classifier_entry_name,json=classifierEntryName,proto3" json:"classifier_entry_name,omitempty"`
+	ParentPath           string                          `protobuf:"bytes,2,opt,name=parent_path,json=parentPath,proto3" json:"parent_path,omitempty"`
+	ClassifierEntryStats *Interface_ClassifierEntryStats `protobuf:"bytes,3,opt,name=classifier_entry_stats,json=classifierEntryStats,proto3" json:"classifier_entry_stats,omitempty"`
+	QueuingStats         *Interface_QueuingStats         `protobuf:"bytes,4,opt,name=queuing_stats,json=queuingStats,proto3" json:"queuing_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *Interface_DiffservTargetClassifierStats) Reset() {
+	*m = Interface_DiffservTargetClassifierStats{}
+}
+func (m *Interface_DiffservTargetClassifierStats) String() string { return proto.CompactTextString(m) }
+func (*Interface_DiffservTargetClassifierStats) ProtoMessage()    {}
+func (*Interface_DiffservTargetClassifierStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1, 3}
+}
+func (m *Interface_DiffservTargetClassifierStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface_DiffservTargetClassifierStats.Unmarshal(m, b)
+}
+func (m *Interface_DiffservTargetClassifierStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface_DiffservTargetClassifierStats.Marshal(b, m, deterministic)
+}
+func (m *Interface_DiffservTargetClassifierStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface_DiffservTargetClassifierStats.Merge(m, src)
+}
+func (m *Interface_DiffservTargetClassifierStats) XXX_Size() int {
+	return xxx_messageInfo_Interface_DiffservTargetClassifierStats.Size(m)
+}
+func (m *Interface_DiffservTargetClassifierStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface_DiffservTargetClassifierStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface_DiffservTargetClassifierStats proto.InternalMessageInfo
+
+func (m *Interface_DiffservTargetClassifierStats) GetClassifierEntryName() string {
+	if m != nil {
+		return m.ClassifierEntryName
+	}
+	return ""
+}
+
+func (m *Interface_DiffservTargetClassifierStats) GetParentPath() string {
+	if m != nil {
+		return m.ParentPath
+	}
+	return ""
+}
+
+func (m *Interface_DiffservTargetClassifierStats) GetClassifierEntryStats() *Interface_ClassifierEntryStats {
+	if m != nil {
+		return m.ClassifierEntryStats
+	}
+	return nil
+}
+
+func (m *Interface_DiffservTargetClassifierStats) GetQueuingStats() *Interface_QueuingStats {
+	if m != nil {
+		return m.QueuingStats
+	}
+	return nil
+}
+
+type Interface_ClassifierEntryStats struct {
+	ClassifiedPkts       uint64   `protobuf:"varint,1,opt,name=classified_pkts,json=classifiedPkts,proto3" json:"classified_pkts,omitempty"`
+	ClassifiedBytes      uint64   `protobuf:"varint,2,opt,name=classified_bytes,json=classifiedBytes,proto3" json:"classified_bytes,omitempty"`
+	ClassifiedRate       uint64   `protobuf:"varint,3,opt,name=classified_rate,json=classifiedRate,proto3" json:"classified_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Interface_ClassifierEntryStats) Reset()         { *m = Interface_ClassifierEntryStats{} }
+func (m *Interface_ClassifierEntryStats) String() string { return proto.CompactTextString(m) }
+func (*Interface_ClassifierEntryStats) ProtoMessage()    {}
+func (*Interface_ClassifierEntryStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1, 4}
+}
+func (m *Interface_ClassifierEntryStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface_ClassifierEntryStats.Unmarshal(m, b)
+}
+func (m *Interface_ClassifierEntryStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface_ClassifierEntryStats.Marshal(b, m, deterministic)
+}
+func (m *Interface_ClassifierEntryStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface_ClassifierEntryStats.Merge(m, src)
+}
+func (m *Interface_ClassifierEntryStats) XXX_Size() int {
+	return xxx_messageInfo_Interface_ClassifierEntryStats.Size(m)
+}
+func (m *Interface_ClassifierEntryStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface_ClassifierEntryStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface_ClassifierEntryStats proto.InternalMessageInfo
+
+func (m *Interface_ClassifierEntryStats) GetClassifiedPkts() uint64 {
+	if m != nil {
+		return m.ClassifiedPkts
+	}
+	return 0
+}
+
+func (m *Interface_ClassifierEntryStats) GetClassifiedBytes() uint64 {
+	if m != nil {
+		return m.ClassifiedBytes
+	}
+	return 0
+}
+
+func (m *Interface_ClassifierEntryStats) GetClassifiedRate() uint64 {
+	if m != nil {
+		return m.ClassifiedRate
+	}
+	return 0
+}
+
+type Interface_QueuingStats struct {
+	OutputPkts           uint64   `protobuf:"varint,1,opt,name=output_pkts,json=outputPkts,proto3" json:"output_pkts,omitempty"`
+	OutputBytes          uint64   `protobuf:"varint,2,opt,name=output_bytes,json=outputBytes,proto3" json:"output_bytes,omitempty"`
+	QueueSizePkts        uint64   `protobuf:"varint,3,opt,name=queue_size_pkts,json=queueSizePkts,proto3" json:"queue_size_pkts,omitempty"`
+	QueueSizeBytes       uint64   `protobuf:"varint,4,opt,name=queue_size_bytes,json=queueSizeBytes,proto3" json:"queue_size_bytes,omitempty"`
+	DropPkts             uint64   `protobuf:"varint,5,opt,name=drop_pkts,json=dropPkts,proto3" json:"drop_pkts,omitempty"`
+	DropBytes            uint64   `protobuf:"varint,6,opt,name=drop_bytes,json=dropBytes,proto3" json:"drop_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Interface_QueuingStats) Reset()         { *m = Interface_QueuingStats{} }
+func (m *Interface_QueuingStats) String() string { return proto.CompactTextString(m) }
+func (*Interface_QueuingStats) ProtoMessage()    {}
+func (*Interface_QueuingStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96d085868e33ae35, []int{1, 5}
+}
+func (m *Interface_QueuingStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Interface_QueuingStats.Unmarshal(m, b)
+}
+func (m *Interface_QueuingStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Interface_QueuingStats.Marshal(b, m, deterministic)
+}
+func (m *Interface_QueuingStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Interface_QueuingStats.Merge(m, src)
+}
+func (m *Interface_QueuingStats) XXX_Size() int {
+	return xxx_messageInfo_Interface_QueuingStats.Size(m)
+}
+func (m *Interface_QueuingStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Interface_QueuingStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Interface_QueuingStats proto.InternalMessageInfo
+
+func (m *Interface_QueuingStats) GetOutputPkts() uint64 {
+	if m != nil {
+		return m.OutputPkts
+	}
+	return 0
+}
+
+func (m *Interface_QueuingStats) GetOutputBytes() uint64 {
+	if m != nil {
+		return m.OutputBytes
+	}
+	return 0
+}
+
+func (m *Interface_QueuingStats) GetQueueSizePkts() uint64 {
+	if m != nil {
+		return m.QueueSizePkts
+	}
+	return 0
+}
+
+func (m *Interface_QueuingStats) GetQueueSizeBytes() uint64 {
+	if m != nil {
+		return m.QueueSizeBytes
+	}
+	return 0
+}
+
+func (m *Interface_QueuingStats) GetDropPkts() uint64 {
+	if m != nil {
+		return m.DropPkts
+	}
+	return 0
+}
+
+func (m *Interface_QueuingStats) GetDropBytes() uint64 {
+	if m != nil {
+		return m.DropBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*InterfaceKeys)(nil), "gpbmodels.InterfaceKeys")
+	proto.RegisterType((*Interface)(nil), "gpbmodels.Interface")
+	proto.RegisterType((*Interface_Statistics)(nil), "gpbmodels.Interface.Statistics")
+	proto.RegisterType((*Interface_V4ProtocolStats)(nil), "gpbmodels.Interface.V4ProtocolStats")
+	proto.RegisterType((*Interface_DiffservInfo)(nil), "gpbmodels.Interface.DiffservInfo")
+	proto.RegisterType((*Interface_DiffservTargetClassifierStats)(nil), "gpbmodels.Interface.DiffservTargetClassifierStats")
+	proto.RegisterType((*Interface_ClassifierEntryStats)(nil), "gpbmodels.Interface.ClassifierEntryStats")
+	proto.RegisterType((*Interface_QueuingStats)(nil), "gpbmodels.Interface.QueuingStats")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/interfaces_oper.proto", fileDescriptor_96d085868e33ae35)
+}
+
+var fileDescriptor_96d085868e33ae35 = []byte{
+	// 1146 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0xdf, 0x52, 0x1b, 0xb7,
+	0x17, 0x1e, 0x07, 0x03, 0xf6, 0xf1, 0x5f, 0x14, 0xe7, 0xf7, 0xdb, 0x98, 0x32, 0x18, 0x9a, 0xa4,
+	0x4e, 0xa7, 0x25, 0x1d, 0xca, 0x7d, 0xa7, 0x81, 0x30, 0xc3, 0x64, 0xd2, 0x52, 0x43, 0x7a, 0xab,
+	0x59, 0xef, 0xca, 0xa0, 0xb1, 0xad, 0xdd, 0x48, 0x5a, 0x82, 0xd3, 0xc7, 0xe8, 0x75, 0x5f, 0x28,
+	0x4f, 0xd1, 0x3e, 0x44, 0xef, 0x3b, 0x3a, 0xd2, 0xae, 0xb5, 0xae, 0xcb, 0x15, 0xab, 0x4f, 0x9f,
+	0xbe, 0xf3, 0x47, 0x9f, 0x8f, 0x80, 0xe7, 0xa9, 0x4c, 0x74, 0xf2, 0xea, 0x26, 0x1d, 0xcf, 0x93,
+	0x98, 0xcd, 0xd4, 0x2b, 0x2e, 0x34, 0x93, 0x93, 0x30, 0x62, 0x8a, 0x26, 0x29, 0x93, 0x47, 0xb8,
+	0x4f, 0xea, 0x05, 0xe1, 0xf0, 0x4b, 0x68, 0x5d, 0xe4, 0x9c, 0xb7, 0x6c, 0xa1, 0x08, 0x81, 0xaa,
+	0x08, 0xe7, 0x2c, 0xa8, 0x0c, 0x2a, 0xc3, 0xfa, 0x08, 0xbf, 0x0f, 0xff, 0xee, 0x41, 0xbd, 0x60,
+	0xad, 0x63, 0x90, 0xe7, 0xd0, 0x2e, 0x42, 0x51, 0xbd, 0x48, 0x59, 0xf0, 0x08, 0x77, 0x5b, 0x05,
+	0x7a, 0xbd, 0x48, 0x19, 0x39, 0x80, 0x66, 0x18, 0xcf, 0xb9, 0xa0, 0x4a, 0x87, 0x3a, 0x53, 0xc1,
+	0x06, 0x92, 0x1a, 0x88, 0x5d, 0x21, 0x44, 0xf6, 0xa1, 0x61, 0x32, 0xcd, 0x19, 0x55, 0x64, 0x80,
+	0x81, 0x96, 0x84, 0x59, 0xa8, 0x34, 0x8d, 0x6e, 0x43, 0x71, 0xc3, 0x82, 0x4d, 0x4b, 0x30, 0xd0,
+	0x29, 0x22, 0xe4, 0x29, 0xd4, 0xf8, 0x84, 0x72, 0x11, 0xb3, 0xfb, 0x60, 0x6b, 0x50, 0x19, 0xb6,
+	0x46, 0xdb, 0x7c, 0x72, 0x61, 0x96, 0x26, 0x7e, 0x7a, 0xbb, 0x50, 0x34, 0x8c, 0x63, 0xc9, 0x94,
+	0x0a, 0xb6, 0x6d, 0x7c, 0x83, 0xfd, 0x68, 0x21, 0xd2, 0x83, 0x4d, 0x95, 0x32, 0x16, 0x07, 0xb5,
+	0x41, 0x65, 0x58, 0x1d, 0xd9, 0x05, 0xe9, 0xc2, 0xc6, 0x5c, 0x67, 0x41, 0x1d, 0xe5, 0xcc, 0xa7,
+	0x41, 0xee, 0xe4, 0x24, 0x00, 0x54, 0x30, 0x9f, 0xa6, 0x2f, 0x3c, 0xbd, 0x3b, 0x09, 0x1a, 0xb6,
+	0x2f, 0xe6, 0x9b, 0x0c, 0xa1, 0x6b, 0xfe, 0x52, 0x95, 0x8d, 0x05, 0xd3, 0x74, 0x1e, 0xaa, 0x69,
+	0xd0, 0xc4, 0xfd, 0xb6, 0xc1, 0xaf, 0x10, 0x7e, 0x17, 0xaa, 0x29, 0x19, 0x40, 0x23, 0x66, 0x2a,
+	0x92, 0x3c, 0xd5, 0x3c, 0x11, 0x41, 0xcb, 0x66, 0xe6, 0x41, 0xe4, 0x07, 0x00, 0xd3, 0x14, 0xae,
+	0x34, 0x8f, 0x54, 0xd0, 0x1e, 0x54, 0x86, 0x8d, 0xe3, 0xfd, 0xa3, 0xe2, 0x2a, 0x8f, 0x8a, 0x1b,
+	0x3a, 0xba, 0x2a, 0x68, 0x23, 0xef, 0x08, 0xb9, 0x84, 0x9d, 0xbb, 0x13, 0x8a, 0x16, 0x88, 0x92,
+	0x19, 0x76, 0x58, 0x05, 0x1d, 0xd4, 0x79, 0xb6, 0x56, 0xe7, 0xd7, 0x93, 0x4b, 0x47, 0x36, 0x8a,
+	0x6a, 0xd4, 0xb9, 0x2b, 0x03, 0xe4, 0x1c, 0x5a, 0x31, 0x9f, 0x4c, 0x14, 0x93, 0x77, 0x94, 0x8b,
+	0x49, 0x12, 0x74, 0x07, 0x1b, 0xc3, 0xc6, 0xf1, 0xc1, 0x5a, 0xb5, 0x33, 0xc7, 0xbc, 0x10, 0x93,
+	0x64, 0xd4, 0x8c, 0xbd, 0x55, 0xff, 0xf3, 0x26, 0xc0, 0x32, 0x69, 0xf2, 0x2d, 0x90, 0x98, 0xab,
+	0x28, 0x11, 0x9a, 0x8b, 0x8c, 0xeb, 0x05, 0xd5, 0xbc, 0xf0, 0xdb, 0x4e, 0x69, 0xe7, 0x9a, 0xcf,
+	0x19, 0xd9, 0x85, 0x3a, 0x17, 0x34, 0x89, 0x34, 0xd3, 0x0a, 0x7d, 0x57, 0x1d, 0xd5, 0xb8, 0xf8,
+	0x19, 0xd7, 0xe4, 0x05, 0x74, 0xb8, 0xa0, 0x99, 0xe0, 0x91, 0x31, 0x4d, 0x3a, 0xd5, 0xd6, 0x75,
+	0x55, 0x63, 0xcd, 0xf7, 0x16, 0xbd, 0x9c, 0x6a, 0x45, 0xbe, 0x86, 0x1d, 0x2e, 0xe8, 0x58, 0x26,
+	0x61, 0xbc, 0x64, 0x56, 0x91, 0xd9, 0xe1, 0xe2, 0x75, 0x8e, 0x7b, 0xdc, 0x79, 0x36, 0xd3, 0x9e,
+	0xea, 0x66, 0xce, 0x7d, 0x97, 0xe3, 0xc8, 0xdd, 0x87, 0x06, 0x17, 0xd4, 0x24, 0x1d, 0xca, 0x58,
+	0x39, 0x43, 0x02, 0x17, 0x67, 0x0e, 0x71, 0xd9, 0x33, 0x29, 0x13, 0x69, 0x0d, 0xd9, 0x32, 0xd9,
+	0xbf, 0xc1, 0xb5, 0x8b, 0x94, 0x89, 0xa9, 0x48, 0x3e, 0x0a, 0x7b, 0x75, 0x0a, 0x9d, 0xd9, 0x32,
+	0x91, 0xde, 0x5b, 0x1c, 0xef, 0x44, 0x91, 0x3d, 0x80, 0x24, 0xd3, 0x79, 0x1f, 0xea, 0x98, 0x4e,
+	0x3d, 0xc9, 0xb4, 0x6b, 0xc4, 0x10, 0xba, 0x66, 0xbb, 0xd4, 0x09, 0x40, 0x52, 0x3b, 0xc9, 0xb4,
+	0xdf, 0x8a, 0x6f, 0x80, 0x18, 0xe6, 0x4a, 0x2f, 0x1a, 0xc8, 0x35, 0x1a, 0xe5, 0x66, 0x38, 0xf6,
+	0x4a, 0x37, 0x9a, 0x05, 0xbb, 0xdc, 0x8e, 0x03, 0x68, 0x1a, 0x76, 0xd1, 0x8f, 0x16, 0xf2, 0x1a,
+	0x49, 0xa6, 0x8b, 0x86, 0xb8, 0x3a, 0x5c, 0x47, 0xda, 0x45, 0x1d, 0xae, 0x25, 0x4f, 0x60, 0x4b,
+	0xde, 0xd3, 0x34, 0xb5, 0xd6, 0xad, 0x8e, 0x36, 0xe5, 0xfd, 0x65, 0xaa, 0xc8, 0xff, 0x61, 0x5b,
+	0xde, 0xd3, 0xe9, 0x38, 0x55, 0x41, 0x17, 0xf1, 0x2d, 0x79, 0xff, 0x76, 0x9c, 0x22, 0x5f, 0x5b,
+	0xfe, 0x8e, 0xe5, 0xeb, 0x9c, 0xaf, 0x1d, 0x9f, 0x58, 0xbe, 0xb6, 0xfc, 0x5d, 0xa8, 0x8b, 0x6c,
+	0x4e, 0x27, 0xb3, 0x30, 0x55, 0xc1, 0x63, 0xeb, 0x26, 0x91, 0xcd, 0xcf, 0xcd, 0x9a, 0x1c, 0x42,
+	0x8b, 0x0b, 0x1a, 0xc9, 0x28, 0x4f, 0xaf, 0x67, 0xf3, 0xe7, 0xe2, 0x54, 0x46, 0x36, 0xc1, 0xfe,
+	0x5f, 0x1b, 0xd0, 0x59, 0xf9, 0xe5, 0x98, 0x68, 0x5c, 0xd8, 0xce, 0x54, 0x6c, 0x34, 0x2e, 0xb0,
+	0x1f, 0x0f, 0x7a, 0xd7, 0x46, 0xc3, 0x48, 0xbe, 0x73, 0x1b, 0xce, 0x1e, 0x9e, 0x17, 0x27, 0x89,
+	0xfc, 0x18, 0xca, 0x98, 0xc5, 0x2b, 0xbe, 0x3d, 0xcf, 0x71, 0xe4, 0x1e, 0xc1, 0xe3, 0x12, 0xd7,
+	0x85, 0xb5, 0xce, 0xdd, 0xf1, 0xd8, 0x2e, 0xbe, 0xd5, 0x76, 0x77, 0x95, 0x6b, 0x6f, 0xe5, 0xda,
+	0x67, 0x39, 0x8e, 0xda, 0x4f, 0xa1, 0x66, 0x6e, 0x0d, 0x29, 0xdb, 0x48, 0xd9, 0x4e, 0x32, 0x7b,
+	0xe7, 0x65, 0x63, 0xd6, 0x56, 0x8d, 0xf9, 0x0c, 0xda, 0xc5, 0x7d, 0xdb, 0xf3, 0xd6, 0xbb, 0xcd,
+	0xfc, 0xce, 0x7d, 0x9b, 0xad, 0x14, 0x0a, 0x85, 0xcd, 0xca, 0x95, 0x7e, 0x07, 0xbd, 0x32, 0xdb,
+	0x05, 0xb7, 0x26, 0x26, 0x3e, 0xdf, 0x65, 0xe1, 0xf4, 0x57, 0x8a, 0x5d, 0xda, 0xb8, 0x54, 0x6d,
+	0xff, 0x73, 0x05, 0x9a, 0xfe, 0x3c, 0x23, 0x5f, 0x40, 0x3d, 0xe6, 0x92, 0x45, 0x38, 0xbc, 0xed,
+	0xa4, 0x5a, 0x02, 0x66, 0x08, 0xa4, 0xc9, 0x8c, 0x47, 0x0b, 0x8a, 0x2f, 0xa7, 0x7d, 0x1b, 0xc1,
+	0x42, 0x3f, 0x99, 0xf7, 0xf3, 0x37, 0x18, 0x14, 0x83, 0x54, 0x87, 0xf2, 0x86, 0x69, 0x1a, 0xcd,
+	0x42, 0xa5, 0xf8, 0x84, 0xbb, 0xb7, 0xd0, 0x5c, 0xbe, 0x99, 0xad, 0xc7, 0x0f, 0xce, 0xd6, 0x6b,
+	0x3c, 0x7b, 0x5a, 0x1c, 0xb5, 0x73, 0x7b, 0x2f, 0x7e, 0x68, 0xbb, 0xff, 0xc7, 0x23, 0xd8, 0x7b,
+	0x50, 0x80, 0x1c, 0xc3, 0x13, 0x2f, 0x1d, 0x26, 0xb4, 0x74, 0x95, 0xd8, 0x4a, 0x1f, 0x2f, 0x37,
+	0xdf, 0x98, 0x3d, 0x2c, 0xc9, 0xd4, 0x1c, 0x4a, 0x26, 0x34, 0x4d, 0x43, 0x7d, 0x5b, 0xd4, 0x8c,
+	0xd0, 0x65, 0xa8, 0x6f, 0x09, 0x85, 0xff, 0xfd, 0x4b, 0x34, 0xaf, 0xd4, 0xbc, 0x49, 0x2f, 0xd7,
+	0x56, 0x7a, 0x5a, 0x0e, 0x65, 0x0b, 0xec, 0x45, 0x6b, 0x50, 0xf3, 0x3a, 0x7d, 0xc8, 0x58, 0xc6,
+	0xc5, 0x8d, 0xd3, 0xad, 0x0e, 0x2a, 0xff, 0xf9, 0x3a, 0xfd, 0x62, 0x99, 0x56, 0xaf, 0xf9, 0xc1,
+	0x5b, 0xf5, 0x7f, 0xaf, 0x40, 0x6f, 0x5d, 0x58, 0xf2, 0x15, 0x74, 0x8a, 0xc0, 0xb1, 0xff, 0xeb,
+	0x6e, 0x2f, 0x61, 0xb4, 0xe3, 0x4b, 0xe8, 0x7a, 0xc4, 0xf1, 0x42, 0xb3, 0xfc, 0xc7, 0xee, 0x09,
+	0xbc, 0x36, 0xf0, 0x8a, 0xa6, 0x0c, 0x35, 0x0b, 0x36, 0x56, 0x35, 0x47, 0xa1, 0x66, 0xfd, 0x3f,
+	0x2b, 0xd0, 0xf4, 0x93, 0xc6, 0xff, 0x9c, 0x32, 0x9d, 0x66, 0xda, 0xcf, 0x04, 0x2c, 0xe4, 0xcd,
+	0x5e, 0x43, 0xf0, 0x33, 0x70, 0x87, 0x6c, 0xf4, 0x17, 0xd0, 0x31, 0xa5, 0x33, 0xaa, 0xf8, 0x27,
+	0x56, 0x7a, 0x2d, 0x11, 0xbe, 0xe2, 0x9f, 0x18, 0x4a, 0x0d, 0xa1, 0xeb, 0xf1, 0xac, 0x9c, 0x1d,
+	0x3a, 0xed, 0x82, 0x68, 0x15, 0x77, 0xa1, 0x1e, 0xcb, 0x24, 0xf5, 0xdf, 0xc8, 0x9a, 0x01, 0xf2,
+	0xc9, 0x80, 0x9b, 0x56, 0xc0, 0x4e, 0x16, 0xa4, 0xe3, 0xd9, 0xf1, 0x16, 0x3e, 0x78, 0xdf, 0xff,
+	0x33, 0x00, 0x33, 0x75, 0x12, 0x09, 0xd7, 0x0a, 0x00, 0x00,
+}
@@ -0,0 +1,438 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/ip_sla_native.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type SlaEntryKeys struct {
+	Number               uint32   `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaEntryKeys) Reset()         { *m = SlaEntryKeys{} }
+func (m *SlaEntryKeys) String() string { return proto.CompactTextString(m) }
+func (*SlaEntryKeys) ProtoMessage()    {}
+func (*SlaEntryKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13ef03f864123ccb, []int{0}
+}
+func (m *SlaEntryKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaEntryKeys.Unmarshal(m, b)
+}
+func (m *SlaEntryKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaEntryKeys.Marshal(b, m, deterministic)
+}
+func (m *SlaEntryKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaEntryKeys.Merge(m, src)
+}
+func (m *SlaEntryKeys) XXX_Size() int {
+	return xxx_messageInfo_SlaEntryKeys.Size(m)
+}
+func (m *SlaEntryKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaEntryKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaEntryKeys proto.InternalMessageInfo
+
+func (m *SlaEntryKeys) GetNumber() uint32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type SlaEntry struct {
+	UdpJitter            *SlaEntry_UdpJitter `protobuf:"bytes,1,opt,name=udp_jitter,json=udpJitter,proto3" json:"udp_jitter,omitempty"`
+	Http                 *SlaEntry_Http      `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+	IcmpEcho             *SlaEntry_IcmpEcho  `protobuf:"bytes,3,opt,name=icmp_echo,json=icmpEcho,proto3" json:"icmp_echo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SlaEntry) Reset()         { *m = SlaEntry{} }
+func (m *SlaEntry) String() string { return proto.CompactTextString(m) }
+func (*SlaEntry) ProtoMessage()    {}
+func (*SlaEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13ef03f864123ccb, []int{1}
+}
+func (m *SlaEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaEntry.Unmarshal(m, b)
+}
+func (m *SlaEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaEntry.Marshal(b, m, deterministic)
+}
+func (m *SlaEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaEntry.Merge(m, src)
+}
+func (m *SlaEntry) XXX_Size() int {
+	return xxx_messageInfo_SlaEntry.Size(m)
+}
+func (m *SlaEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaEntry proto.InternalMessageInfo
+
+func (m *SlaEntry) GetUdpJitter() *SlaEntry_UdpJitter {
+	if m != nil {
+		return m.UdpJitter
+	}
+	return nil
+}
+
+func (m *SlaEntry) GetHttp() *SlaEntry_Http {
+	if m != nil {
+		return m.Http
+	}
+	return nil
+}
+
+func (m *SlaEntry) GetIcmpEcho() *SlaEntry_IcmpEcho {
+	if m != nil {
+		return m.IcmpEcho
+	}
+	return nil
+}
+
+type SlaEntry_UdpJitter struct {
+	DestAddr             string   `protobuf:"bytes,1,opt,name=dest_addr,json=destAddr,proto3" json:"dest_addr,omitempty"`
+	Portno               uint32   `protobuf:"varint,2,opt,name=portno,proto3" json:"portno,omitempty"`
+	SourceIp             string   `protobuf:"bytes,3,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	SourcePort           uint32   `protobuf:"varint,4,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	Tos                  uint32   `protobuf:"varint,5,opt,name=tos,proto3" json:"tos,omitempty"`
+	Frequency            uint32   `protobuf:"varint,6,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Tag                  string   `protobuf:"bytes,7,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaEntry_UdpJitter) Reset()         { *m = SlaEntry_UdpJitter{} }
+func (m *SlaEntry_UdpJitter) String() string { return proto.CompactTextString(m) }
+func (*SlaEntry_UdpJitter) ProtoMessage()    {}
+func (*SlaEntry_UdpJitter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13ef03f864123ccb, []int{1, 0}
+}
+func (m *SlaEntry_UdpJitter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaEntry_UdpJitter.Unmarshal(m, b)
+}
+func (m *SlaEntry_UdpJitter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaEntry_UdpJitter.Marshal(b, m, deterministic)
+}
+func (m *SlaEntry_UdpJitter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaEntry_UdpJitter.Merge(m, src)
+}
+func (m *SlaEntry_UdpJitter) XXX_Size() int {
+	return xxx_messageInfo_SlaEntry_UdpJitter.Size(m)
+}
+func (m *SlaEntry_UdpJitter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaEntry_UdpJitter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaEntry_UdpJitter proto.InternalMessageInfo
+
+func (m *SlaEntry_UdpJitter) GetDestAddr() string {
+	if m != nil {
+		return m.DestAddr
+	}
+	return ""
+}
+
+func (m *SlaEntry_UdpJitter) GetPortno() uint32 {
+	if m != nil {
+		return m.Portno
+	}
+	return 0
+}
+
+func (m *SlaEntry_UdpJitter) GetSourceIp() string {
+	if m != nil {
+		return m.SourceIp
+	}
+	return ""
+}
+
+func (m *SlaEntry_UdpJitter) GetSourcePort() uint32 {
+	if m != nil {
+		return m.SourcePort
+	}
+	return 0
+}
+
+func (m *SlaEntry_UdpJitter) GetTos() uint32 {
+	if m != nil {
+		return m.Tos
+	}
+	return 0
+}
+
+func (m *SlaEntry_UdpJitter) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *SlaEntry_UdpJitter) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+type SlaEntry_Http struct {
+	Get                  *SlaEntry_HttpGet `protobuf:"bytes,1,opt,name=get,proto3" json:"get,omitempty"`
+	Frequency            uint32            `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Tag                  string            `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SlaEntry_Http) Reset()         { *m = SlaEntry_Http{} }
+func (m *SlaEntry_Http) String() string { return proto.CompactTextString(m) }
+func (*SlaEntry_Http) ProtoMessage()    {}
+func (*SlaEntry_Http) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13ef03f864123ccb, []int{1, 1}
+}
+func (m *SlaEntry_Http) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaEntry_Http.Unmarshal(m, b)
+}
+func (m *SlaEntry_Http) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaEntry_Http.Marshal(b, m, deterministic)
+}
+func (m *SlaEntry_Http) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaEntry_Http.Merge(m, src)
+}
+func (m *SlaEntry_Http) XXX_Size() int {
+	return xxx_messageInfo_SlaEntry_Http.Size(m)
+}
+func (m *SlaEntry_Http) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaEntry_Http.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaEntry_Http proto.InternalMessageInfo
+
+func (m *SlaEntry_Http) GetGet() *SlaEntry_HttpGet {
+	if m != nil {
+		return m.Get
+	}
+	return nil
+}
+
+func (m *SlaEntry_Http) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *SlaEntry_Http) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+type SlaEntry_HttpGet struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	SourceIp             string   `protobuf:"bytes,2,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	NameServer           string   `protobuf:"bytes,3,opt,name=name_server,json=nameServer,proto3" json:"name_server,omitempty"`
+	Version              string   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaEntry_HttpGet) Reset()         { *m = SlaEntry_HttpGet{} }
+func (m *SlaEntry_HttpGet) String() string { return proto.CompactTextString(m) }
+func (*SlaEntry_HttpGet) ProtoMessage()    {}
+func (*SlaEntry_HttpGet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13ef03f864123ccb, []int{1, 2}
+}
+func (m *SlaEntry_HttpGet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaEntry_HttpGet.Unmarshal(m, b)
+}
+func (m *SlaEntry_HttpGet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaEntry_HttpGet.Marshal(b, m, deterministic)
+}
+func (m *SlaEntry_HttpGet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaEntry_HttpGet.Merge(m, src)
+}
+func (m *SlaEntry_HttpGet) XXX_Size() int {
+	return xxx_messageInfo_SlaEntry_HttpGet.Size(m)
+}
+func (m *SlaEntry_HttpGet) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaEntry_HttpGet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaEntry_HttpGet proto.InternalMessageInfo
+
+func (m *SlaEntry_HttpGet) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *SlaEntry_HttpGet) GetSourceIp() string {
+	if m != nil {
+		return m.SourceIp
+	}
+	return ""
+}
+
+func (m *SlaEntry_HttpGet) GetNameServer() string {
+	if m != nil {
+		return m.NameServer
+	}
+	return ""
+}
+
+func (m *SlaEntry_HttpGet) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+type SlaEntry_IcmpEcho struct {
+	Destination          string   `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	SourceIp             string   `protobuf:"bytes,2,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	RequestDataSize      uint32   `protobuf:"varint,3,opt,name=request_data_size,json=requestDataSize,proto3" json:"request_data_size,omitempty"`
+	Tos                  uint32   `protobuf:"varint,4,opt,name=tos,proto3" json:"tos,omitempty"`
+	Frequency            uint32   `protobuf:"varint,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Tag                  string   `protobuf:"bytes,6,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaEntry_IcmpEcho) Reset()         { *m = SlaEntry_IcmpEcho{} }
+func (m *SlaEntry_IcmpEcho) String() string { return proto.CompactTextString(m) }
+func (*SlaEntry_IcmpEcho) ProtoMessage()    {}
+func (*SlaEntry_IcmpEcho) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13ef03f864123ccb, []int{1, 3}
+}
+func (m *SlaEntry_IcmpEcho) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaEntry_IcmpEcho.Unmarshal(m, b)
+}
+func (m *SlaEntry_IcmpEcho) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaEntry_IcmpEcho.Marshal(b, m, deterministic)
+}
+func (m *SlaEntry_IcmpEcho) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaEntry_IcmpEcho.Merge(m, src)
+}
+func (m *SlaEntry_IcmpEcho) XXX_Size() int {
+	return xxx_messageInfo_SlaEntry_IcmpEcho.Size(m)
+}
+func (m *SlaEntry_IcmpEcho) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaEntry_IcmpEcho.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaEntry_IcmpEcho proto.InternalMessageInfo
+
+func (m *SlaEntry_IcmpEcho) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *SlaEntry_IcmpEcho) GetSourceIp() string {
+	if m != nil {
+		return m.SourceIp
+	}
+	return ""
+}
+
+func (m *SlaEntry_IcmpEcho) GetRequestDataSize() uint32 {
+	if m != nil {
+		return m.RequestDataSize
+	}
+	return 0
+}
+
+func (m *SlaEntry_IcmpEcho) GetTos() uint32 {
+	if m != nil {
+		return m.Tos
+	}
+	return 0
+}
+
+func (m *SlaEntry_IcmpEcho) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *SlaEntry_IcmpEcho) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SlaEntryKeys)(nil), "gpbmodels.SlaEntryKeys")
+	proto.RegisterType((*SlaEntry)(nil), "gpbmodels.SlaEntry")
+	proto.RegisterType((*SlaEntry_UdpJitter)(nil), "gpbmodels.SlaEntry.UdpJitter")
+	proto.RegisterType((*SlaEntry_Http)(nil), "gpbmodels.SlaEntry.Http")
+	proto.RegisterType((*SlaEntry_HttpGet)(nil), "gpbmodels.SlaEntry.HttpGet")
+	proto.RegisterType((*SlaEntry_IcmpEcho)(nil), "gpbmodels.SlaEntry.IcmpEcho")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/ip_sla_native.proto", fileDescriptor_13ef03f864123ccb)
+}
+
+var fileDescriptor_13ef03f864123ccb = []byte{
+	// 442 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x4d, 0x6f, 0x13, 0x31,
+	0x10, 0x55, 0x3e, 0xba, 0xd9, 0x9d, 0x10, 0x01, 0x3e, 0xa0, 0x55, 0x5a, 0x44, 0x55, 0x24, 0x84,
+	0x10, 0xa4, 0x12, 0x9c, 0x90, 0xb8, 0x20, 0x51, 0x41, 0xe1, 0x82, 0x1c, 0x71, 0xb6, 0x9c, 0xf5,
+	0x90, 0x18, 0x65, 0xd7, 0xc6, 0x9e, 0x8d, 0x94, 0xfe, 0x04, 0xfe, 0x10, 0x57, 0x7e, 0x1a, 0xb2,
+	0xe3, 0x4d, 0xa9, 0x68, 0x7a, 0x1b, 0xbf, 0x79, 0xcf, 0x6f, 0xfc, 0x66, 0x17, 0x9e, 0x5a, 0x67,
+	0xc8, 0x9c, 0x2f, 0xed, 0xa2, 0x36, 0x0a, 0xd7, 0xfe, 0x5c, 0x5b, 0xe1, 0xd7, 0x52, 0x34, 0x92,
+	0xf4, 0x06, 0x67, 0xb1, 0xcb, 0x8a, 0x7d, 0xfb, 0xec, 0x19, 0xdc, 0x9b, 0xaf, 0xe5, 0x45, 0x43,
+	0x6e, 0xfb, 0x05, 0xb7, 0x9e, 0x3d, 0x82, 0xac, 0x69, 0xeb, 0x05, 0xba, 0xb2, 0x77, 0xda, 0x7b,
+	0x3e, 0xe1, 0xe9, 0x74, 0xf6, 0x2b, 0x83, 0xbc, 0x23, 0xb2, 0x77, 0x00, 0xad, 0xb2, 0xe2, 0x87,
+	0x26, 0x4a, 0xc4, 0xf1, 0xeb, 0xc7, 0xb3, 0xfd, 0xa5, 0xb3, 0x8e, 0x38, 0xfb, 0xa6, 0xec, 0xe7,
+	0x48, 0xe2, 0x45, 0xdb, 0x95, 0xec, 0x25, 0x0c, 0x57, 0x44, 0xb6, 0xec, 0x47, 0x5d, 0x79, 0x9b,
+	0xee, 0x13, 0x91, 0xe5, 0x91, 0xc5, 0xde, 0x42, 0xa1, 0xab, 0xda, 0x0a, 0xac, 0x56, 0xa6, 0x1c,
+	0x44, 0xc9, 0xc9, 0x6d, 0x92, 0xcb, 0xaa, 0xb6, 0x17, 0xd5, 0xca, 0xf0, 0x5c, 0xa7, 0x6a, 0xfa,
+	0xa7, 0x07, 0xc5, 0x7e, 0x02, 0x76, 0x0c, 0x85, 0x42, 0x4f, 0x42, 0x2a, 0xb5, 0x9b, 0xb9, 0xe0,
+	0x79, 0x00, 0xde, 0x2b, 0xe5, 0xc2, 0xb3, 0xad, 0x71, 0xd4, 0x98, 0x38, 0xd5, 0x84, 0xa7, 0x53,
+	0x10, 0x79, 0xd3, 0xba, 0x0a, 0x85, 0xb6, 0xd1, 0xbd, 0xe0, 0xf9, 0x0e, 0xb8, 0xb4, 0xec, 0x09,
+	0x8c, 0x53, 0x33, 0xb0, 0xcb, 0x61, 0x54, 0xc2, 0x0e, 0xfa, 0x6a, 0x1c, 0xb1, 0x07, 0x30, 0x20,
+	0xe3, 0xcb, 0xa3, 0xd8, 0x08, 0x25, 0x3b, 0x81, 0xe2, 0xbb, 0xc3, 0x9f, 0x2d, 0x36, 0xd5, 0xb6,
+	0xcc, 0x22, 0x7e, 0x0d, 0x44, 0xbe, 0x5c, 0x96, 0xa3, 0xe8, 0x13, 0xca, 0x29, 0xc2, 0x30, 0x64,
+	0xc1, 0x5e, 0xc1, 0x60, 0x89, 0x94, 0xa2, 0x3e, 0x3e, 0x14, 0xd9, 0x47, 0x24, 0x1e, 0x78, 0x37,
+	0x6d, 0xfa, 0x07, 0x6c, 0x06, 0xd7, 0x36, 0x1e, 0x46, 0x49, 0x1f, 0x9a, 0xad, 0x5b, 0xa7, 0x80,
+	0x42, 0x79, 0x33, 0x83, 0xfe, 0xff, 0x19, 0x34, 0xb2, 0x46, 0xe1, 0xd1, 0x6d, 0xd0, 0xa5, 0x3b,
+	0x21, 0x40, 0xf3, 0x88, 0xb0, 0x12, 0x46, 0x1b, 0x74, 0x5e, 0x9b, 0x26, 0x06, 0x54, 0xf0, 0xee,
+	0x38, 0xfd, 0xdd, 0x83, 0xbc, 0xdb, 0x1a, 0x3b, 0x85, 0x71, 0x58, 0x86, 0x0e, 0xdf, 0xa9, 0x69,
+	0x92, 0xfd, 0xbf, 0xd0, 0xdd, 0x63, 0xbc, 0x80, 0x87, 0xf1, 0x79, 0x9e, 0x84, 0x92, 0x24, 0x85,
+	0xd7, 0x57, 0x18, 0x87, 0x99, 0xf0, 0xfb, 0xa9, 0xf1, 0x41, 0x92, 0x9c, 0xeb, 0x2b, 0xec, 0xb6,
+	0x32, 0x3c, 0xb0, 0x95, 0xa3, 0x03, 0x71, 0x65, 0xfb, 0xb8, 0x16, 0x59, 0xfc, 0x8d, 0xde, 0xfc,
+	0x1d, 0x00, 0x1b, 0x01, 0x36, 0xf4, 0x6d, 0x03, 0x00, 0x00,
+}
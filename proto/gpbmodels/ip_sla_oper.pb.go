@@ -0,0 +1,739 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/ip_sla_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type SlaOperEntryKeys struct {
+	OperId               uint32   `protobuf:"varint,1,opt,name=oper_id,json=operId,proto3" json:"oper_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaOperEntryKeys) Reset()         { *m = SlaOperEntryKeys{} }
+func (m *SlaOperEntryKeys) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntryKeys) ProtoMessage()    {}
+func (*SlaOperEntryKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{0}
+}
+func (m *SlaOperEntryKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntryKeys.Unmarshal(m, b)
+}
+func (m *SlaOperEntryKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntryKeys.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntryKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntryKeys.Merge(m, src)
+}
+func (m *SlaOperEntryKeys) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntryKeys.Size(m)
+}
+func (m *SlaOperEntryKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntryKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntryKeys proto.InternalMessageInfo
+
+func (m *SlaOperEntryKeys) GetOperId() uint32 {
+	if m != nil {
+		return m.OperId
+	}
+	return 0
+}
+
+type SlaOperEntry struct {
+	OperId               uint32                `protobuf:"varint,1,opt,name=oper_id,json=operId,proto3" json:"oper_id,omitempty"`
+	OperType             string                `protobuf:"bytes,2,opt,name=oper_type,json=operType,proto3" json:"oper_type,omitempty"`
+	LatestReturnCode     string                `protobuf:"bytes,3,opt,name=latest_return_code,json=latestReturnCode,proto3" json:"latest_return_code,omitempty"`
+	SuccessCount         uint32                `protobuf:"varint,4,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	FailureCount         uint32                `protobuf:"varint,5,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
+	LatestOperStartTime  string                `protobuf:"bytes,6,opt,name=latest_oper_start_time,json=latestOperStartTime,proto3" json:"latest_oper_start_time,omitempty"`
+	RttInfo              *SlaOperEntry_RttInfo `protobuf:"bytes,7,opt,name=rtt_info,json=rttInfo,proto3" json:"rtt_info,omitempty"`
+	Stats                *SlaOperEntry_Stats   `protobuf:"bytes,8,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SlaOperEntry) Reset()         { *m = SlaOperEntry{} }
+func (m *SlaOperEntry) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry) ProtoMessage()    {}
+func (*SlaOperEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1}
+}
+func (m *SlaOperEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry.Unmarshal(m, b)
+}
+func (m *SlaOperEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry.Merge(m, src)
+}
+func (m *SlaOperEntry) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry.Size(m)
+}
+func (m *SlaOperEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry proto.InternalMessageInfo
+
+func (m *SlaOperEntry) GetOperId() uint32 {
+	if m != nil {
+		return m.OperId
+	}
+	return 0
+}
+
+func (m *SlaOperEntry) GetOperType() string {
+	if m != nil {
+		return m.OperType
+	}
+	return ""
+}
+
+func (m *SlaOperEntry) GetLatestReturnCode() string {
+	if m != nil {
+		return m.LatestReturnCode
+	}
+	return ""
+}
+
+func (m *SlaOperEntry) GetSuccessCount() uint32 {
+	if m != nil {
+		return m.SuccessCount
+	}
+	return 0
+}
+
+func (m *SlaOperEntry) GetFailureCount() uint32 {
+	if m != nil {
+		return m.FailureCount
+	}
+	return 0
+}
+
+func (m *SlaOperEntry) GetLatestOperStartTime() string {
+	if m != nil {
+		return m.LatestOperStartTime
+	}
+	return ""
+}
+
+func (m *SlaOperEntry) GetRttInfo() *SlaOperEntry_RttInfo {
+	if m != nil {
+		return m.RttInfo
+	}
+	return nil
+}
+
+func (m *SlaOperEntry) GetStats() *SlaOperEntry_Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type SlaOperEntry_RttInfo struct {
+	LatestRtt            *SlaOperEntry_LatestRtt `protobuf:"bytes,1,opt,name=latest_rtt,json=latestRtt,proto3" json:"latest_rtt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *SlaOperEntry_RttInfo) Reset()         { *m = SlaOperEntry_RttInfo{} }
+func (m *SlaOperEntry_RttInfo) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_RttInfo) ProtoMessage()    {}
+func (*SlaOperEntry_RttInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 0}
+}
+func (m *SlaOperEntry_RttInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_RttInfo.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_RttInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_RttInfo.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_RttInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_RttInfo.Merge(m, src)
+}
+func (m *SlaOperEntry_RttInfo) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_RttInfo.Size(m)
+}
+func (m *SlaOperEntry_RttInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_RttInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_RttInfo proto.InternalMessageInfo
+
+func (m *SlaOperEntry_RttInfo) GetLatestRtt() *SlaOperEntry_LatestRtt {
+	if m != nil {
+		return m.LatestRtt
+	}
+	return nil
+}
+
+type SlaOperEntry_LatestRtt struct {
+	Rtt                  uint64   `protobuf:"varint,1,opt,name=rtt,proto3" json:"rtt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaOperEntry_LatestRtt) Reset()         { *m = SlaOperEntry_LatestRtt{} }
+func (m *SlaOperEntry_LatestRtt) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_LatestRtt) ProtoMessage()    {}
+func (*SlaOperEntry_LatestRtt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 1}
+}
+func (m *SlaOperEntry_LatestRtt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_LatestRtt.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_LatestRtt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_LatestRtt.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_LatestRtt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_LatestRtt.Merge(m, src)
+}
+func (m *SlaOperEntry_LatestRtt) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_LatestRtt.Size(m)
+}
+func (m *SlaOperEntry_LatestRtt) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_LatestRtt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_LatestRtt proto.InternalMessageInfo
+
+func (m *SlaOperEntry_LatestRtt) GetRtt() uint64 {
+	if m != nil {
+		return m.Rtt
+	}
+	return 0
+}
+
+type SlaOperEntry_Stats struct {
+	OnewayLatency        *SlaOperEntry_SampleStats       `protobuf:"bytes,1,opt,name=oneway_latency,json=onewayLatency,proto3" json:"oneway_latency,omitempty"`
+	Jitter               *SlaOperEntry_SampleStats       `protobuf:"bytes,2,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	PacketLoss           *SlaOperEntry_PacketLoss        `protobuf:"bytes,3,opt,name=packet_loss,json=packetLoss,proto3" json:"packet_loss,omitempty"`
+	HttpSpecificStats    *SlaOperEntry_HttpSpecificStats `protobuf:"bytes,4,opt,name=http_specific_stats,json=httpSpecificStats,proto3" json:"http_specific_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *SlaOperEntry_Stats) Reset()         { *m = SlaOperEntry_Stats{} }
+func (m *SlaOperEntry_Stats) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_Stats) ProtoMessage()    {}
+func (*SlaOperEntry_Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 2}
+}
+func (m *SlaOperEntry_Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_Stats.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_Stats.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_Stats.Merge(m, src)
+}
+func (m *SlaOperEntry_Stats) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_Stats.Size(m)
+}
+func (m *SlaOperEntry_Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_Stats proto.InternalMessageInfo
+
+func (m *SlaOperEntry_Stats) GetOnewayLatency() *SlaOperEntry_SampleStats {
+	if m != nil {
+		return m.OnewayLatency
+	}
+	return nil
+}
+
+func (m *SlaOperEntry_Stats) GetJitter() *SlaOperEntry_SampleStats {
+	if m != nil {
+		return m.Jitter
+	}
+	return nil
+}
+
+func (m *SlaOperEntry_Stats) GetPacketLoss() *SlaOperEntry_PacketLoss {
+	if m != nil {
+		return m.PacketLoss
+	}
+	return nil
+}
+
+func (m *SlaOperEntry_Stats) GetHttpSpecificStats() *SlaOperEntry_HttpSpecificStats {
+	if m != nil {
+		return m.HttpSpecificStats
+	}
+	return nil
+}
+
+type SlaOperEntry_SampleStats struct {
+	SampleCount          uint32                  `protobuf:"varint,1,opt,name=sample_count,json=sampleCount,proto3" json:"sample_count,omitempty"`
+	Sd                   *SlaOperEntry_MinAvgMax `protobuf:"bytes,2,opt,name=sd,proto3" json:"sd,omitempty"`
+	Ds                   *SlaOperEntry_MinAvgMax `protobuf:"bytes,3,opt,name=ds,proto3" json:"ds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *SlaOperEntry_SampleStats) Reset()         { *m = SlaOperEntry_SampleStats{} }
+func (m *SlaOperEntry_SampleStats) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_SampleStats) ProtoMessage()    {}
+func (*SlaOperEntry_SampleStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 3}
+}
+func (m *SlaOperEntry_SampleStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_SampleStats.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_SampleStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_SampleStats.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_SampleStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_SampleStats.Merge(m, src)
+}
+func (m *SlaOperEntry_SampleStats) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_SampleStats.Size(m)
+}
+func (m *SlaOperEntry_SampleStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_SampleStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_SampleStats proto.InternalMessageInfo
+
+func (m *SlaOperEntry_SampleStats) GetSampleCount() uint32 {
+	if m != nil {
+		return m.SampleCount
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_SampleStats) GetSd() *SlaOperEntry_MinAvgMax {
+	if m != nil {
+		return m.Sd
+	}
+	return nil
+}
+
+func (m *SlaOperEntry_SampleStats) GetDs() *SlaOperEntry_MinAvgMax {
+	if m != nil {
+		return m.Ds
+	}
+	return nil
+}
+
+type SlaOperEntry_MinAvgMax struct {
+	Min                  uint32   `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Avg                  uint32   `protobuf:"varint,2,opt,name=avg,proto3" json:"avg,omitempty"`
+	Max                  uint32   `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaOperEntry_MinAvgMax) Reset()         { *m = SlaOperEntry_MinAvgMax{} }
+func (m *SlaOperEntry_MinAvgMax) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_MinAvgMax) ProtoMessage()    {}
+func (*SlaOperEntry_MinAvgMax) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 4}
+}
+func (m *SlaOperEntry_MinAvgMax) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_MinAvgMax.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_MinAvgMax) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_MinAvgMax.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_MinAvgMax) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_MinAvgMax.Merge(m, src)
+}
+func (m *SlaOperEntry_MinAvgMax) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_MinAvgMax.Size(m)
+}
+func (m *SlaOperEntry_MinAvgMax) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_MinAvgMax.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_MinAvgMax proto.InternalMessageInfo
+
+func (m *SlaOperEntry_MinAvgMax) GetMin() uint32 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_MinAvgMax) GetAvg() uint32 {
+	if m != nil {
+		return m.Avg
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_MinAvgMax) GetMax() uint32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type SlaOperEntry_PacketLoss struct {
+	SdLoss               *SlaOperEntry_Loss `protobuf:"bytes,1,opt,name=sd_loss,json=sdLoss,proto3" json:"sd_loss,omitempty"`
+	DsLoss               *SlaOperEntry_Loss `protobuf:"bytes,2,opt,name=ds_loss,json=dsLoss,proto3" json:"ds_loss,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SlaOperEntry_PacketLoss) Reset()         { *m = SlaOperEntry_PacketLoss{} }
+func (m *SlaOperEntry_PacketLoss) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_PacketLoss) ProtoMessage()    {}
+func (*SlaOperEntry_PacketLoss) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 5}
+}
+func (m *SlaOperEntry_PacketLoss) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_PacketLoss.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_PacketLoss) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_PacketLoss.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_PacketLoss) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_PacketLoss.Merge(m, src)
+}
+func (m *SlaOperEntry_PacketLoss) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_PacketLoss.Size(m)
+}
+func (m *SlaOperEntry_PacketLoss) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_PacketLoss.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_PacketLoss proto.InternalMessageInfo
+
+func (m *SlaOperEntry_PacketLoss) GetSdLoss() *SlaOperEntry_Loss {
+	if m != nil {
+		return m.SdLoss
+	}
+	return nil
+}
+
+func (m *SlaOperEntry_PacketLoss) GetDsLoss() *SlaOperEntry_Loss {
+	if m != nil {
+		return m.DsLoss
+	}
+	return nil
+}
+
+type SlaOperEntry_Loss struct {
+	LossPeriodCount      uint32   `protobuf:"varint,1,opt,name=loss_period_count,json=lossPeriodCount,proto3" json:"loss_period_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaOperEntry_Loss) Reset()         { *m = SlaOperEntry_Loss{} }
+func (m *SlaOperEntry_Loss) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_Loss) ProtoMessage()    {}
+func (*SlaOperEntry_Loss) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 6}
+}
+func (m *SlaOperEntry_Loss) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_Loss.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_Loss) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_Loss.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_Loss) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_Loss.Merge(m, src)
+}
+func (m *SlaOperEntry_Loss) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_Loss.Size(m)
+}
+func (m *SlaOperEntry_Loss) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_Loss.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_Loss proto.InternalMessageInfo
+
+func (m *SlaOperEntry_Loss) GetLossPeriodCount() uint32 {
+	if m != nil {
+		return m.LossPeriodCount
+	}
+	return 0
+}
+
+type SlaOperEntry_HttpSpecificStats struct {
+	HttpStats            *SlaOperEntry_HttpStats  `protobuf:"bytes,1,opt,name=http_stats,json=httpStats,proto3" json:"http_stats,omitempty"`
+	HttpErrors           *SlaOperEntry_HttpErrors `protobuf:"bytes,2,opt,name=http_errors,json=httpErrors,proto3" json:"http_errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *SlaOperEntry_HttpSpecificStats) Reset()         { *m = SlaOperEntry_HttpSpecificStats{} }
+func (m *SlaOperEntry_HttpSpecificStats) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_HttpSpecificStats) ProtoMessage()    {}
+func (*SlaOperEntry_HttpSpecificStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 7}
+}
+func (m *SlaOperEntry_HttpSpecificStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_HttpSpecificStats.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_HttpSpecificStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_HttpSpecificStats.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_HttpSpecificStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_HttpSpecificStats.Merge(m, src)
+}
+func (m *SlaOperEntry_HttpSpecificStats) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_HttpSpecificStats.Size(m)
+}
+func (m *SlaOperEntry_HttpSpecificStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_HttpSpecificStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_HttpSpecificStats proto.InternalMessageInfo
+
+func (m *SlaOperEntry_HttpSpecificStats) GetHttpStats() *SlaOperEntry_HttpStats {
+	if m != nil {
+		return m.HttpStats
+	}
+	return nil
+}
+
+func (m *SlaOperEntry_HttpSpecificStats) GetHttpErrors() *SlaOperEntry_HttpErrors {
+	if m != nil {
+		return m.HttpErrors
+	}
+	return nil
+}
+
+type SlaOperEntry_HttpStats struct {
+	StatusCode           uint32   `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	DnsRtt               uint32   `protobuf:"varint,2,opt,name=dns_rtt,json=dnsRtt,proto3" json:"dns_rtt,omitempty"`
+	TcpRtt               uint32   `protobuf:"varint,3,opt,name=tcp_rtt,json=tcpRtt,proto3" json:"tcp_rtt,omitempty"`
+	TransactionRtt       uint32   `protobuf:"varint,4,opt,name=transaction_rtt,json=transactionRtt,proto3" json:"transaction_rtt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaOperEntry_HttpStats) Reset()         { *m = SlaOperEntry_HttpStats{} }
+func (m *SlaOperEntry_HttpStats) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_HttpStats) ProtoMessage()    {}
+func (*SlaOperEntry_HttpStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 8}
+}
+func (m *SlaOperEntry_HttpStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_HttpStats.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_HttpStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_HttpStats.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_HttpStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_HttpStats.Merge(m, src)
+}
+func (m *SlaOperEntry_HttpStats) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_HttpStats.Size(m)
+}
+func (m *SlaOperEntry_HttpStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_HttpStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_HttpStats proto.InternalMessageInfo
+
+func (m *SlaOperEntry_HttpStats) GetStatusCode() uint32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpStats) GetDnsRtt() uint32 {
+	if m != nil {
+		return m.DnsRtt
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpStats) GetTcpRtt() uint32 {
+	if m != nil {
+		return m.TcpRtt
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpStats) GetTransactionRtt() uint32 {
+	if m != nil {
+		return m.TransactionRtt
+	}
+	return 0
+}
+
+type SlaOperEntry_HttpErrors struct {
+	TransactionError     uint32   `protobuf:"varint,1,opt,name=transaction_error,json=transactionError,proto3" json:"transaction_error,omitempty"`
+	TcpError             uint32   `protobuf:"varint,2,opt,name=tcp_error,json=tcpError,proto3" json:"tcp_error,omitempty"`
+	DnsError             uint32   `protobuf:"varint,3,opt,name=dns_error,json=dnsError,proto3" json:"dns_error,omitempty"`
+	TransactionTimeout   uint32   `protobuf:"varint,4,opt,name=transaction_timeout,json=transactionTimeout,proto3" json:"transaction_timeout,omitempty"`
+	TcpTimeout           uint32   `protobuf:"varint,5,opt,name=tcp_timeout,json=tcpTimeout,proto3" json:"tcp_timeout,omitempty"`
+	DnsTimeout           uint32   `protobuf:"varint,6,opt,name=dns_timeout,json=dnsTimeout,proto3" json:"dns_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlaOperEntry_HttpErrors) Reset()         { *m = SlaOperEntry_HttpErrors{} }
+func (m *SlaOperEntry_HttpErrors) String() string { return proto.CompactTextString(m) }
+func (*SlaOperEntry_HttpErrors) ProtoMessage()    {}
+func (*SlaOperEntry_HttpErrors) Descriptor() ([]byte, []int) {
+	return fileDescriptor_954b4d5f51ffdc10, []int{1, 9}
+}
+func (m *SlaOperEntry_HttpErrors) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlaOperEntry_HttpErrors.Unmarshal(m, b)
+}
+func (m *SlaOperEntry_HttpErrors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlaOperEntry_HttpErrors.Marshal(b, m, deterministic)
+}
+func (m *SlaOperEntry_HttpErrors) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlaOperEntry_HttpErrors.Merge(m, src)
+}
+func (m *SlaOperEntry_HttpErrors) XXX_Size() int {
+	return xxx_messageInfo_SlaOperEntry_HttpErrors.Size(m)
+}
+func (m *SlaOperEntry_HttpErrors) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlaOperEntry_HttpErrors.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlaOperEntry_HttpErrors proto.InternalMessageInfo
+
+func (m *SlaOperEntry_HttpErrors) GetTransactionError() uint32 {
+	if m != nil {
+		return m.TransactionError
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpErrors) GetTcpError() uint32 {
+	if m != nil {
+		return m.TcpError
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpErrors) GetDnsError() uint32 {
+	if m != nil {
+		return m.DnsError
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpErrors) GetTransactionTimeout() uint32 {
+	if m != nil {
+		return m.TransactionTimeout
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpErrors) GetTcpTimeout() uint32 {
+	if m != nil {
+		return m.TcpTimeout
+	}
+	return 0
+}
+
+func (m *SlaOperEntry_HttpErrors) GetDnsTimeout() uint32 {
+	if m != nil {
+		return m.DnsTimeout
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SlaOperEntryKeys)(nil), "gpbmodels.SlaOperEntryKeys")
+	proto.RegisterType((*SlaOperEntry)(nil), "gpbmodels.SlaOperEntry")
+	proto.RegisterType((*SlaOperEntry_RttInfo)(nil), "gpbmodels.SlaOperEntry.RttInfo")
+	proto.RegisterType((*SlaOperEntry_LatestRtt)(nil), "gpbmodels.SlaOperEntry.LatestRtt")
+	proto.RegisterType((*SlaOperEntry_Stats)(nil), "gpbmodels.SlaOperEntry.Stats")
+	proto.RegisterType((*SlaOperEntry_SampleStats)(nil), "gpbmodels.SlaOperEntry.SampleStats")
+	proto.RegisterType((*SlaOperEntry_MinAvgMax)(nil), "gpbmodels.SlaOperEntry.MinAvgMax")
+	proto.RegisterType((*SlaOperEntry_PacketLoss)(nil), "gpbmodels.SlaOperEntry.PacketLoss")
+	proto.RegisterType((*SlaOperEntry_Loss)(nil), "gpbmodels.SlaOperEntry.Loss")
+	proto.RegisterType((*SlaOperEntry_HttpSpecificStats)(nil), "gpbmodels.SlaOperEntry.HttpSpecificStats")
+	proto.RegisterType((*SlaOperEntry_HttpStats)(nil), "gpbmodels.SlaOperEntry.HttpStats")
+	proto.RegisterType((*SlaOperEntry_HttpErrors)(nil), "gpbmodels.SlaOperEntry.HttpErrors")
+}
+
+func init() { proto.RegisterFile("proto/gpbmodels/ip_sla_oper.proto", fileDescriptor_954b4d5f51ffdc10) }
+
+var fileDescriptor_954b4d5f51ffdc10 = []byte{
+	// 782 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xc1, 0x6e, 0xdb, 0x46,
+	0x10, 0x05, 0x65, 0x9b, 0x12, 0x47, 0x56, 0x62, 0xaf, 0x81, 0x9a, 0x60, 0x1b, 0xc4, 0x76, 0x0e,
+	0x75, 0x9b, 0x42, 0x46, 0x65, 0xf4, 0xd2, 0x5e, 0x1a, 0x18, 0x01, 0x9a, 0xc6, 0x41, 0x03, 0xca,
+	0x97, 0x9e, 0x08, 0x86, 0xbb, 0xb2, 0xd8, 0x52, 0xdc, 0xc5, 0xee, 0x28, 0xb5, 0xfa, 0x03, 0xfd,
+	0x83, 0x1e, 0x0a, 0xf4, 0x1f, 0xfa, 0x55, 0xfd, 0x8e, 0x62, 0x76, 0x48, 0x86, 0x6d, 0xa1, 0xc4,
+	0x27, 0x0d, 0xe7, 0xbd, 0x37, 0x33, 0x3b, 0xb3, 0x3b, 0x82, 0x53, 0x63, 0x35, 0xea, 0x8b, 0x5b,
+	0xf3, 0x66, 0xa5, 0xa5, 0xaa, 0xdc, 0x45, 0x69, 0x32, 0x57, 0xe5, 0x99, 0x36, 0xca, 0x4e, 0x3d,
+	0x26, 0xa2, 0x0e, 0x3c, 0x7b, 0x0a, 0x07, 0xf3, 0x2a, 0xff, 0xc1, 0x28, 0xfb, 0xbc, 0x46, 0xbb,
+	0x79, 0xa9, 0x36, 0x4e, 0x1c, 0xc3, 0x90, 0xc8, 0x59, 0x29, 0xe3, 0xe0, 0x24, 0x38, 0x9f, 0xa4,
+	0x21, 0x7d, 0xbe, 0x90, 0x67, 0x7f, 0x4e, 0x60, 0xbf, 0xcf, 0xde, 0xca, 0x14, 0x1f, 0x43, 0xe4,
+	0x01, 0xdc, 0x18, 0x15, 0x0f, 0x4e, 0x82, 0xf3, 0x28, 0x1d, 0x91, 0xe3, 0x66, 0x63, 0x94, 0xf8,
+	0x02, 0x44, 0x95, 0xa3, 0x72, 0x98, 0x59, 0x85, 0x6b, 0x5b, 0x67, 0x85, 0x96, 0x2a, 0xde, 0xf1,
+	0xac, 0x03, 0x46, 0x52, 0x0f, 0x5c, 0x69, 0xa9, 0xc4, 0x13, 0x98, 0xb8, 0x75, 0x51, 0x28, 0xe7,
+	0xb2, 0x42, 0xaf, 0x6b, 0x8c, 0x77, 0x7d, 0xa6, 0xfd, 0xc6, 0x79, 0x45, 0x3e, 0x22, 0x2d, 0xf2,
+	0xb2, 0x5a, 0x5b, 0xd5, 0x90, 0xf6, 0x98, 0xd4, 0x38, 0x99, 0x74, 0x09, 0x1f, 0x35, 0x79, 0x7d,
+	0x6d, 0x0e, 0x73, 0x8b, 0x19, 0x96, 0x2b, 0x15, 0x87, 0x3e, 0xf7, 0x11, 0xa3, 0x74, 0xbc, 0x39,
+	0x61, 0x37, 0xe5, 0x4a, 0x89, 0xaf, 0x61, 0x64, 0x11, 0xb3, 0xb2, 0x5e, 0xe8, 0x78, 0x78, 0x12,
+	0x9c, 0x8f, 0x67, 0x8f, 0xa7, 0x5d, 0xfb, 0xa6, 0xfd, 0x6e, 0x4c, 0x53, 0xc4, 0x17, 0xf5, 0x42,
+	0xa7, 0x43, 0xcb, 0x86, 0xb8, 0x84, 0x3d, 0x87, 0x39, 0xba, 0x78, 0xe4, 0x85, 0x8f, 0xb6, 0x09,
+	0xe7, 0x44, 0x4a, 0x99, 0x9b, 0xbc, 0x84, 0x61, 0x13, 0x48, 0x7c, 0x0b, 0xd0, 0x36, 0x0a, 0xd1,
+	0x77, 0x78, 0x3c, 0x3b, 0xdd, 0x16, 0xe4, 0x9a, 0x1b, 0x87, 0x98, 0x46, 0x55, 0x6b, 0x26, 0x8f,
+	0x20, 0xea, 0xfc, 0xe2, 0x00, 0x76, 0xda, 0x38, 0xbb, 0x29, 0x99, 0xc9, 0x5f, 0x03, 0xd8, 0xf3,
+	0xc9, 0xc5, 0xf7, 0xf0, 0x40, 0xd7, 0xea, 0x97, 0x7c, 0x93, 0x91, 0xb8, 0x2e, 0x36, 0x4d, 0xba,
+	0x27, 0x5b, 0x6b, 0xce, 0x57, 0xa6, 0x52, 0x5c, 0xf9, 0x84, 0xa5, 0xd7, 0xac, 0x14, 0xdf, 0x40,
+	0xf8, 0x53, 0x89, 0xa8, 0x6c, 0x3c, 0xb8, 0x7f, 0x8c, 0x46, 0x22, 0xae, 0x60, 0x6c, 0xf2, 0xe2,
+	0x67, 0x85, 0x59, 0xa5, 0x9d, 0xf3, 0xb7, 0x62, 0x3c, 0x3b, 0xdb, 0x16, 0xe1, 0xb5, 0xa7, 0x5e,
+	0x6b, 0xe7, 0x52, 0x30, 0x9d, 0x2d, 0x7e, 0x84, 0xa3, 0x25, 0xa2, 0xc9, 0x9c, 0x51, 0x45, 0xb9,
+	0x28, 0x8b, 0x8c, 0xc7, 0xb0, 0xeb, 0x83, 0x7d, 0xb6, 0x2d, 0xd8, 0x77, 0x88, 0x66, 0xde, 0x28,
+	0xb8, 0xa8, 0xc3, 0xe5, 0x7f, 0x5d, 0xc9, 0xef, 0x01, 0x8c, 0x7b, 0x75, 0x8b, 0x53, 0xd8, 0x77,
+	0xfe, 0xb3, 0xb9, 0x78, 0xfc, 0x0e, 0xc6, 0xec, 0xe3, 0x7b, 0xf7, 0x25, 0x0c, 0x9c, 0x8c, 0x07,
+	0xef, 0x1f, 0xdf, 0xab, 0xb2, 0x7e, 0xf6, 0xf6, 0xf6, 0x55, 0x7e, 0x97, 0x0e, 0x9c, 0x24, 0x89,
+	0x6c, 0x0f, 0x7f, 0x1f, 0x89, 0x74, 0xc9, 0x33, 0x88, 0x3a, 0x07, 0x8d, 0x7a, 0x55, 0xd6, 0x4d,
+	0x31, 0x64, 0x92, 0x27, 0x7f, 0x7b, 0xeb, 0xab, 0x98, 0xa4, 0x64, 0x7a, 0x4e, 0x7e, 0x17, 0xef,
+	0x34, 0x9c, 0xfc, 0x2e, 0xf9, 0x15, 0xe0, 0x5d, 0x43, 0xc5, 0x57, 0x30, 0x74, 0x92, 0xa7, 0xc0,
+	0x77, 0xe1, 0x93, 0xad, 0x57, 0x8f, 0xfa, 0x1f, 0x3a, 0xd9, 0xca, 0xa4, 0x63, 0xd9, 0xe0, 0x3e,
+	0x32, 0xe9, 0xe8, 0x37, 0x99, 0xc1, 0xae, 0x97, 0x7f, 0x0e, 0x87, 0xa4, 0xcd, 0x8c, 0xb2, 0xa5,
+	0x96, 0xff, 0x6a, 0xea, 0x43, 0x02, 0x5e, 0x7b, 0xbf, 0x6f, 0x6c, 0xf2, 0x47, 0x00, 0x87, 0xff,
+	0x1b, 0x1a, 0xbd, 0x1a, 0x1e, 0xbe, 0x9f, 0xf9, 0x07, 0x5e, 0x8d, 0x97, 0xfb, 0x59, 0x47, 0xcb,
+	0xd6, 0xa4, 0x3b, 0xe8, 0x23, 0x28, 0x6b, 0xb5, 0x6d, 0x8f, 0x71, 0xf6, 0xbe, 0x10, 0xcf, 0x3d,
+	0x33, 0x85, 0x65, 0x67, 0x27, 0xbf, 0x05, 0x10, 0x75, 0xd1, 0xc5, 0x63, 0x18, 0x53, 0x3d, 0x6b,
+	0xc7, 0xcb, 0x8e, 0x0f, 0x04, 0xec, 0xf2, 0x6b, 0xee, 0x18, 0x86, 0xb2, 0x76, 0xfe, 0xa1, 0xf3,
+	0x8c, 0x42, 0x59, 0x3b, 0x7a, 0xb5, 0xc7, 0x30, 0xc4, 0xc2, 0x78, 0x80, 0x47, 0x15, 0x62, 0x61,
+	0x08, 0xf8, 0x14, 0x1e, 0xa2, 0xcd, 0x6b, 0x97, 0x17, 0x58, 0xea, 0xda, 0x13, 0x78, 0x35, 0x3e,
+	0xe8, 0xb9, 0x69, 0x09, 0xfc, 0x1d, 0x00, 0xbc, 0x2b, 0x52, 0x3c, 0x85, 0xc3, 0xbe, 0xce, 0x1f,
+	0xb2, 0x29, 0xe8, 0xa0, 0x07, 0x78, 0x36, 0x2d, 0x72, 0xca, 0xce, 0x24, 0x2e, 0x6c, 0x84, 0x85,
+	0xe9, 0x40, 0xaa, 0x99, 0x41, 0x2e, 0x6e, 0x24, 0x6b, 0xc7, 0xe0, 0x05, 0x1c, 0xf5, 0xd3, 0xd0,
+	0x9e, 0xd5, 0xeb, 0xb6, 0x44, 0xd1, 0x83, 0x6e, 0x18, 0xa1, 0x16, 0x51, 0xaa, 0x96, 0xc8, 0x1b,
+	0x1c, 0xb0, 0x30, 0x3d, 0x02, 0xa5, 0x6b, 0x09, 0x21, 0x13, 0x64, 0xed, 0x1a, 0xc2, 0x9b, 0xd0,
+	0xff, 0xbd, 0x5d, 0xfe, 0x33, 0x00, 0x91, 0x1f, 0x8c, 0xf0, 0x03, 0x07, 0x00, 0x00,
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/license_native.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type License struct {
+	Udi                  *License_Udi  `protobuf:"bytes,1,opt,name=udi,proto3" json:"udi,omitempty"`
+	Boot                 *License_Boot `protobuf:"bytes,2,opt,name=boot,proto3" json:"boot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *License) Reset()         { *m = License{} }
+func (m *License) String() string { return proto.CompactTextString(m) }
+func (*License) ProtoMessage()    {}
+func (*License) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b4a2b656ee9ea8, []int{0}
+}
+func (m *License) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_License.Unmarshal(m, b)
+}
+func (m *License) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_License.Marshal(b, m, deterministic)
+}
+func (m *License) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_License.Merge(m, src)
+}
+func (m *License) XXX_Size() int {
+	return xxx_messageInfo_License.Size(m)
+}
+func (m *License) XXX_DiscardUnknown() {
+	xxx_messageInfo_License.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_License proto.InternalMessageInfo
+
+func (m *License) GetUdi() *License_Udi {
+	if m != nil {
+		return m.Udi
+	}
+	return nil
+}
+
+func (m *License) GetBoot() *License_Boot {
+	if m != nil {
+		return m.Boot
+	}
+	return nil
+}
+
+type License_Udi struct {
+	Pid                  string   `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Sn                   string   `protobuf:"bytes,2,opt,name=sn,proto3" json:"sn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *License_Udi) Reset()         { *m = License_Udi{} }
+func (m *License_Udi) String() string { return proto.CompactTextString(m) }
+func (*License_Udi) ProtoMessage()    {}
+func (*License_Udi) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b4a2b656ee9ea8, []int{0, 0}
+}
+func (m *License_Udi) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_License_Udi.Unmarshal(m, b)
+}
+func (m *License_Udi) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_License_Udi.Marshal(b, m, deterministic)
+}
+func (m *License_Udi) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_License_Udi.Merge(m, src)
+}
+func (m *License_Udi) XXX_Size() int {
+	return xxx_messageInfo_License_Udi.Size(m)
+}
+func (m *License_Udi) XXX_DiscardUnknown() {
+	xxx_messageInfo_License_Udi.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_License_Udi proto.InternalMessageInfo
+
+func (m *License_Udi) GetPid() string {
+	if m != nil {
+		return m.Pid
+	}
+	return ""
+}
+
+func (m *License_Udi) GetSn() string {
+	if m != nil {
+		return m.Sn
+	}
+	return ""
+}
+
+type License_Boot struct {
+	Level                *License_Level `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *License_Boot) Reset()         { *m = License_Boot{} }
+func (m *License_Boot) String() string { return proto.CompactTextString(m) }
+func (*License_Boot) ProtoMessage()    {}
+func (*License_Boot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b4a2b656ee9ea8, []int{0, 1}
+}
+func (m *License_Boot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_License_Boot.Unmarshal(m, b)
+}
+func (m *License_Boot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_License_Boot.Marshal(b, m, deterministic)
+}
+func (m *License_Boot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_License_Boot.Merge(m, src)
+}
+func (m *License_Boot) XXX_Size() int {
+	return xxx_messageInfo_License_Boot.Size(m)
+}
+func (m *License_Boot) XXX_DiscardUnknown() {
+	xxx_messageInfo_License_Boot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_License_Boot proto.InternalMessageInfo
+
+func (m *License_Boot) GetLevel() *License_Level {
+	if m != nil {
+		return m.Level
+	}
+	return nil
+}
+
+type License_Level struct {
+	Appxk9               *License_Addon `protobuf:"bytes,1,opt,name=appxk9,proto3" json:"appxk9,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *License_Level) Reset()         { *m = License_Level{} }
+func (m *License_Level) String() string { return proto.CompactTextString(m) }
+func (*License_Level) ProtoMessage()    {}
+func (*License_Level) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b4a2b656ee9ea8, []int{0, 2}
+}
+func (m *License_Level) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_License_Level.Unmarshal(m, b)
+}
+func (m *License_Level) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_License_Level.Marshal(b, m, deterministic)
+}
+func (m *License_Level) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_License_Level.Merge(m, src)
+}
+func (m *License_Level) XXX_Size() int {
+	return xxx_messageInfo_License_Level.Size(m)
+}
+func (m *License_Level) XXX_DiscardUnknown() {
+	xxx_messageInfo_License_Level.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_License_Level proto.InternalMessageInfo
+
+func (m *License_Level) GetAppxk9() *License_Addon {
+	if m != nil {
+		return m.Appxk9
+	}
+	return nil
+}
+
+type License_Addon struct {
+	Addon                string   `protobuf:"bytes,1,opt,name=addon,proto3" json:"addon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *License_Addon) Reset()         { *m = License_Addon{} }
+func (m *License_Addon) String() string { return proto.CompactTextString(m) }
+func (*License_Addon) ProtoMessage()    {}
+func (*License_Addon) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b4a2b656ee9ea8, []int{0, 3}
+}
+func (m *License_Addon) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_License_Addon.Unmarshal(m, b)
+}
+func (m *License_Addon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_License_Addon.Marshal(b, m, deterministic)
+}
+func (m *License_Addon) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_License_Addon.Merge(m, src)
+}
+func (m *License_Addon) XXX_Size() int {
+	return xxx_messageInfo_License_Addon.Size(m)
+}
+func (m *License_Addon) XXX_DiscardUnknown() {
+	xxx_messageInfo_License_Addon.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_License_Addon proto.InternalMessageInfo
+
+func (m *License_Addon) GetAddon() string {
+	if m != nil {
+		return m.Addon
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*License)(nil), "gpbmodels.License")
+	proto.RegisterType((*License_Udi)(nil), "gpbmodels.License.Udi")
+	proto.RegisterType((*License_Boot)(nil), "gpbmodels.License.Boot")
+	proto.RegisterType((*License_Level)(nil), "gpbmodels.License.Level")
+	proto.RegisterType((*License_Addon)(nil), "gpbmodels.License.Addon")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/license_native.proto", fileDescriptor_66b4a2b656ee9ea8)
+}
+
+var fileDescriptor_66b4a2b656ee9ea8 = []byte{
+	// 223 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0xd0, 0xc1, 0x4a, 0x03, 0x31,
+	0x10, 0x06, 0x60, 0xba, 0xdb, 0x54, 0x76, 0x04, 0x91, 0x41, 0x74, 0x09, 0x08, 0x22, 0x82, 0x05,
+	0x21, 0x15, 0x05, 0xa1, 0x47, 0x3d, 0xf7, 0x14, 0xe8, 0x59, 0x76, 0x4d, 0x90, 0x60, 0xcc, 0x04,
+	0x13, 0x8b, 0xef, 0xe2, 0xcb, 0x4a, 0x66, 0x43, 0x4f, 0xc5, 0xdb, 0x4c, 0xe6, 0x9b, 0x9f, 0x21,
+	0x70, 0x13, 0xbf, 0x28, 0xd3, 0xea, 0x3d, 0x8e, 0x9f, 0x64, 0xac, 0x4f, 0x2b, 0xef, 0xde, 0x6c,
+	0x48, 0xf6, 0x35, 0x0c, 0xd9, 0xed, 0xac, 0xe2, 0x31, 0x76, 0xfb, 0xf9, 0xf5, 0x6f, 0x03, 0x47,
+	0x9b, 0xc9, 0xe0, 0x12, 0xda, 0x6f, 0xe3, 0xfa, 0xd9, 0xd5, 0x6c, 0x79, 0xfc, 0x70, 0xae, 0xf6,
+	0x48, 0x55, 0xa0, 0xb6, 0xc6, 0xe9, 0x42, 0xf0, 0x0e, 0xe6, 0x23, 0x51, 0xee, 0x1b, 0xa6, 0x17,
+	0x07, 0xe8, 0x0b, 0x51, 0xd6, 0x8c, 0xe4, 0x2d, 0xb4, 0x5b, 0xe3, 0xf0, 0x14, 0xda, 0xe8, 0x0c,
+	0xa7, 0x77, 0xba, 0x94, 0x78, 0x02, 0x4d, 0x0a, 0x9c, 0xd1, 0xe9, 0x26, 0x05, 0xf9, 0x04, 0xf3,
+	0xb2, 0x86, 0x0a, 0x84, 0xb7, 0x3b, 0xeb, 0xeb, 0x25, 0xfd, 0x81, 0xf8, 0x4d, 0x99, 0xeb, 0x89,
+	0xc9, 0x35, 0x08, 0xee, 0xf1, 0x1e, 0x16, 0x43, 0x8c, 0x3f, 0x1f, 0xeb, 0x7f, 0x36, 0x9f, 0x8d,
+	0xa1, 0xa0, 0xab, 0x93, 0x97, 0x20, 0xf8, 0x01, 0xcf, 0x40, 0x0c, 0xa5, 0xa8, 0xf7, 0x4d, 0xcd,
+	0xb8, 0xe0, 0xff, 0x7a, 0xfc, 0x1b, 0x00, 0xe9, 0xbb, 0x58, 0x82, 0x57, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/memory_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type MemoryStatisticKeys struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemoryStatisticKeys) Reset()         { *m = MemoryStatisticKeys{} }
+func (m *MemoryStatisticKeys) String() string { return proto.CompactTextString(m) }
+func (*MemoryStatisticKeys) ProtoMessage()    {}
+func (*MemoryStatisticKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_419762c1733aeb2d, []int{0}
+}
+func (m *MemoryStatisticKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryStatisticKeys.Unmarshal(m, b)
+}
+func (m *MemoryStatisticKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryStatisticKeys.Marshal(b, m, deterministic)
+}
+func (m *MemoryStatisticKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryStatisticKeys.Merge(m, src)
+}
+func (m *MemoryStatisticKeys) XXX_Size() int {
+	return xxx_messageInfo_MemoryStatisticKeys.Size(m)
+}
+func (m *MemoryStatisticKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryStatisticKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryStatisticKeys proto.InternalMessageInfo
+
+func (m *MemoryStatisticKeys) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type MemoryStatistic struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TotalMemory          uint64   `protobuf:"varint,2,opt,name=total_memory,json=totalMemory,proto3" json:"total_memory,omitempty"`
+	UsedMemory           uint64   `protobuf:"varint,3,opt,name=used_memory,json=usedMemory,proto3" json:"used_memory,omitempty"`
+	FreeMemory           uint64   `protobuf:"varint,4,opt,name=free_memory,json=freeMemory,proto3" json:"free_memory,omitempty"`
+	LowestUsage          uint64   `protobuf:"varint,5,opt,name=lowest_usage,json=lowestUsage,proto3" json:"lowest_usage,omitempty"`
+	HighestUsage         uint64   `protobuf:"varint,6,opt,name=highest_usage,json=highestUsage,proto3" json:"highest_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemoryStatistic) Reset()         { *m = MemoryStatistic{} }
+func (m *MemoryStatistic) String() string { return proto.CompactTextString(m) }
+func (*MemoryStatistic) ProtoMessage()    {}
+func (*MemoryStatistic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_419762c1733aeb2d, []int{1}
+}
+func (m *MemoryStatistic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryStatistic.Unmarshal(m, b)
+}
+func (m *MemoryStatistic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryStatistic.Marshal(b, m, deterministic)
+}
+func (m *MemoryStatistic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryStatistic.Merge(m, src)
+}
+func (m *MemoryStatistic) XXX_Size() int {
+	return xxx_messageInfo_MemoryStatistic.Size(m)
+}
+func (m *MemoryStatistic) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryStatistic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryStatistic proto.InternalMessageInfo
+
+func (m *MemoryStatistic) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MemoryStatistic) GetTotalMemory() uint64 {
+	if m != nil {
+		return m.TotalMemory
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetUsedMemory() uint64 {
+	if m != nil {
+		return m.UsedMemory
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetFreeMemory() uint64 {
+	if m != nil {
+		return m.FreeMemory
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetLowestUsage() uint64 {
+	if m != nil {
+		return m.LowestUsage
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetHighestUsage() uint64 {
+	if m != nil {
+		return m.HighestUsage
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*MemoryStatisticKeys)(nil), "gpbmodels.MemoryStatisticKeys")
+	proto.RegisterType((*MemoryStatistic)(nil), "gpbmodels.MemoryStatistic")
+}
+
+func init() { proto.RegisterFile("proto/gpbmodels/memory_oper.proto", fileDescriptor_419762c1733aeb2d) }
+
+var fileDescriptor_419762c1733aeb2d = []byte{
+	// 202 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0xd0, 0xc1, 0x6a, 0x84, 0x30,
+	0x10, 0xc6, 0x71, 0xd2, 0x5a, 0xc1, 0xd1, 0x52, 0x48, 0x2f, 0xde, 0xaa, 0xf6, 0x62, 0x2f, 0xf5,
+	0xd0, 0xc7, 0x28, 0xbd, 0x58, 0x7a, 0x96, 0x58, 0x67, 0x55, 0x30, 0x1b, 0x49, 0x22, 0x8b, 0xaf,
+	0xb9, 0x4f, 0xb4, 0x38, 0xd1, 0x15, 0x96, 0xbd, 0xc9, 0xff, 0xfb, 0xc9, 0x40, 0x20, 0x1d, 0xb5,
+	0xb2, 0xaa, 0x68, 0xc7, 0x5a, 0xaa, 0x06, 0x07, 0x53, 0x48, 0x94, 0x4a, 0xcf, 0x95, 0x1a, 0x51,
+	0x7f, 0xd2, 0xc6, 0x83, 0xeb, 0x98, 0x7d, 0xc0, 0xeb, 0x0f, 0xed, 0xbf, 0x56, 0xd8, 0xde, 0xd8,
+	0xfe, 0xff, 0x1b, 0x67, 0xc3, 0x39, 0x78, 0x47, 0x21, 0x31, 0x66, 0x09, 0xcb, 0x83, 0x92, 0xbe,
+	0xb3, 0x33, 0x83, 0x97, 0x1b, 0x7b, 0xcf, 0xf1, 0x14, 0x22, 0xab, 0xac, 0x18, 0x2a, 0x77, 0x38,
+	0x7e, 0x48, 0x58, 0xee, 0x95, 0x21, 0x35, 0xf7, 0x3f, 0x7f, 0x83, 0x70, 0x32, 0xd8, 0x6c, 0xe2,
+	0x91, 0x04, 0x2c, 0x69, 0x07, 0x07, 0x8d, 0xb8, 0x01, 0xcf, 0x81, 0x25, 0xad, 0x20, 0x85, 0x68,
+	0x50, 0x27, 0x34, 0xb6, 0x9a, 0x8c, 0x68, 0x31, 0x7e, 0x72, 0x47, 0x5c, 0xfb, 0x5b, 0x12, 0x7f,
+	0x87, 0xe7, 0xae, 0x6f, 0xbb, 0xdd, 0xf8, 0x64, 0xa2, 0x35, 0x12, 0xaa, 0x7d, 0x7a, 0x91, 0xaf,
+	0xcb, 0x00, 0xd3, 0x15, 0x3f, 0x46, 0x36, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/ospf_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Ospfv2NeighborKeys struct {
+	InstanceId           uint32   `protobuf:"varint,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	AreaId               uint32   `protobuf:"varint,2,opt,name=area_id,json=areaId,proto3" json:"area_id,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	NbrId                uint32   `protobuf:"varint,4,opt,name=nbr_id,json=nbrId,proto3" json:"nbr_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ospfv2NeighborKeys) Reset()         { *m = Ospfv2NeighborKeys{} }
+func (m *Ospfv2NeighborKeys) String() string { return proto.CompactTextString(m) }
+func (*Ospfv2NeighborKeys) ProtoMessage()    {}
+func (*Ospfv2NeighborKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa68ea31ac583b54, []int{0}
+}
+func (m *Ospfv2NeighborKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ospfv2NeighborKeys.Unmarshal(m, b)
+}
+func (m *Ospfv2NeighborKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ospfv2NeighborKeys.Marshal(b, m, deterministic)
+}
+func (m *Ospfv2NeighborKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ospfv2NeighborKeys.Merge(m, src)
+}
+func (m *Ospfv2NeighborKeys) XXX_Size() int {
+	return xxx_messageInfo_Ospfv2NeighborKeys.Size(m)
+}
+func (m *Ospfv2NeighborKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ospfv2NeighborKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ospfv2NeighborKeys proto.InternalMessageInfo
+
+func (m *Ospfv2NeighborKeys) GetInstanceId() uint32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *Ospfv2NeighborKeys) GetAreaId() uint32 {
+	if m != nil {
+		return m.AreaId
+	}
+	return 0
+}
+
+func (m *Ospfv2NeighborKeys) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Ospfv2NeighborKeys) GetNbrId() uint32 {
+	if m != nil {
+		return m.NbrId
+	}
+	return 0
+}
+
+type Ospfv2Neighbor struct {
+	NbrId                uint32   `protobuf:"varint,1,opt,name=nbr_id,json=nbrId,proto3" json:"nbr_id,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Dr                   uint32   `protobuf:"varint,3,opt,name=dr,proto3" json:"dr,omitempty"`
+	Bdr                  uint32   `protobuf:"varint,4,opt,name=bdr,proto3" json:"bdr,omitempty"`
+	State                string   `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ospfv2Neighbor) Reset()         { *m = Ospfv2Neighbor{} }
+func (m *Ospfv2Neighbor) String() string { return proto.CompactTextString(m) }
+func (*Ospfv2Neighbor) ProtoMessage()    {}
+func (*Ospfv2Neighbor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa68ea31ac583b54, []int{1}
+}
+func (m *Ospfv2Neighbor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ospfv2Neighbor.Unmarshal(m, b)
+}
+func (m *Ospfv2Neighbor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ospfv2Neighbor.Marshal(b, m, deterministic)
+}
+func (m *Ospfv2Neighbor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ospfv2Neighbor.Merge(m, src)
+}
+func (m *Ospfv2Neighbor) XXX_Size() int {
+	return xxx_messageInfo_Ospfv2Neighbor.Size(m)
+}
+func (m *Ospfv2Neighbor) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ospfv2Neighbor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ospfv2Neighbor proto.InternalMessageInfo
+
+func (m *Ospfv2Neighbor) GetNbrId() uint32 {
+	if m != nil {
+		return m.NbrId
+	}
+	return 0
+}
+
+func (m *Ospfv2Neighbor) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Ospfv2Neighbor) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *Ospfv2Neighbor) GetBdr() uint32 {
+	if m != nil {
+		return m.Bdr
+	}
+	return 0
+}
+
+func (m *Ospfv2Neighbor) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Ospfv2NeighborKeys)(nil), "gpbmodels.Ospfv2NeighborKeys")
+	proto.RegisterType((*Ospfv2Neighbor)(nil), "gpbmodels.Ospfv2Neighbor")
+}
+
+func init() { proto.RegisterFile("proto/gpbmodels/ospf_oper.proto", fileDescriptor_aa68ea31ac583b54) }
+
+var fileDescriptor_aa68ea31ac583b54 = []byte{
+	// 216 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xd1, 0x4a, 0xc3, 0x40,
+	0x10, 0x45, 0x49, 0xda, 0xa4, 0x64, 0xa4, 0x45, 0x06, 0xc5, 0xbc, 0xb5, 0xf4, 0xa9, 0x4f, 0x16,
+	0xf4, 0x2b, 0x82, 0xa0, 0x90, 0x1f, 0x28, 0xbb, 0xce, 0xb4, 0x06, 0xec, 0xee, 0x32, 0x13, 0x84,
+	0xfc, 0xbd, 0xec, 0x68, 0xa5, 0xbe, 0xdd, 0xbb, 0xf7, 0x2c, 0x07, 0x06, 0xd6, 0x49, 0xe2, 0x18,
+	0xf7, 0xa7, 0xe4, 0xcf, 0x91, 0xf8, 0x53, 0xf7, 0x51, 0xd3, 0xf1, 0x10, 0x13, 0xcb, 0xa3, 0x2d,
+	0xd8, 0xfc, 0x4d, 0xdb, 0x09, 0xf0, 0x4d, 0xd3, 0xf1, 0xeb, 0xe9, 0x95, 0x87, 0xd3, 0x87, 0x8f,
+	0xf2, 0xc2, 0x93, 0xe2, 0x1a, 0x6e, 0x86, 0xa0, 0xa3, 0x0b, 0xef, 0x7c, 0x18, 0xa8, 0x2d, 0x36,
+	0xc5, 0x6e, 0xd9, 0xc3, 0xe5, 0xa9, 0x23, 0x7c, 0x80, 0x85, 0x13, 0x76, 0x79, 0x2c, 0x6d, 0xac,
+	0x73, 0xed, 0x08, 0x11, 0xe6, 0xc1, 0x9d, 0xb9, 0x9d, 0x6d, 0x8a, 0x5d, 0xd3, 0x5b, 0xc6, 0x7b,
+	0xa8, 0x83, 0x97, 0xcc, 0xce, 0x8d, 0xad, 0x82, 0x97, 0x8e, 0xb6, 0x13, 0xac, 0xfe, 0xab, 0xaf,
+	0xc0, 0xe2, 0x0a, 0xc4, 0x16, 0x16, 0x8e, 0x48, 0x58, 0xd5, 0x64, 0x4d, 0x7f, 0xa9, 0xb8, 0x82,
+	0x92, 0xc4, 0x5c, 0xcb, 0xbe, 0x24, 0xc1, 0x5b, 0x98, 0x79, 0x92, 0x5f, 0x4d, 0x8e, 0x78, 0x07,
+	0x95, 0x8e, 0x6e, 0xe4, 0xb6, 0xb2, 0x9f, 0x3f, 0xc5, 0xd7, 0x76, 0x87, 0xe7, 0xef, 0x01, 0x00,
+	0x79, 0xff, 0x9c, 0x25, 0x2a, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/process_cpu_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type CpuUtilization struct {
+	FiveSeconds          uint32                            `protobuf:"varint,1,opt,name=five_seconds,json=fiveSeconds,proto3" json:"five_seconds,omitempty"`
+	FiveSecondsIntr      uint32                            `protobuf:"varint,2,opt,name=five_seconds_intr,json=fiveSecondsIntr,proto3" json:"five_seconds_intr,omitempty"`
+	OneMinute            uint32                            `protobuf:"varint,3,opt,name=one_minute,json=oneMinute,proto3" json:"one_minute,omitempty"`
+	FiveMinutes          uint32                            `protobuf:"varint,4,opt,name=five_minutes,json=fiveMinutes,proto3" json:"five_minutes,omitempty"`
+	CpuUsageProcess      []*CpuUtilization_CpuUsageProcess `protobuf:"bytes,5,rep,name=cpu_usage_process,json=cpuUsageProcess,proto3" json:"cpu_usage_process,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *CpuUtilization) Reset()         { *m = CpuUtilization{} }
+func (m *CpuUtilization) String() string { return proto.CompactTextString(m) }
+func (*CpuUtilization) ProtoMessage()    {}
+func (*CpuUtilization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89d150943c95b8e4, []int{0}
+}
+func (m *CpuUtilization) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CpuUtilization.Unmarshal(m, b)
+}
+func (m *CpuUtilization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CpuUtilization.Marshal(b, m, deterministic)
+}
+func (m *CpuUtilization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CpuUtilization.Merge(m, src)
+}
+func (m *CpuUtilization) XXX_Size() int {
+	return xxx_messageInfo_CpuUtilization.Size(m)
+}
+func (m *CpuUtilization) XXX_DiscardUnknown() {
+	xxx_messageInfo_CpuUtilization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CpuUtilization proto.InternalMessageInfo
+
+func (m *CpuUtilization) GetFiveSeconds() uint32 {
+	if m != nil {
+		return m.FiveSeconds
+	}
+	return 0
+}
+
+func (m *CpuUtilization) GetFiveSecondsIntr() uint32 {
+	if m != nil {
+		return m.FiveSecondsIntr
+	}
+	return 0
+}
+
+func (m *CpuUtilization) GetOneMinute() uint32 {
+	if m != nil {
+		return m.OneMinute
+	}
+	return 0
+}
+
+func (m *CpuUtilization) GetFiveMinutes() uint32 {
+	if m != nil {
+		return m.FiveMinutes
+	}
+	return 0
+}
+
+func (m *CpuUtilization) GetCpuUsageProcess() []*CpuUtilization_CpuUsageProcess {
+	if m != nil {
+		return m.CpuUsageProcess
+	}
+	return nil
+}
+
+type CpuUtilization_CpuUsageProcess struct {
+	Pid                  uint32   `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TotalRunTime         uint64   `protobuf:"varint,3,opt,name=total_run_time,json=totalRunTime,proto3" json:"total_run_time,omitempty"`
+	InvocationCount      uint64   `protobuf:"varint,4,opt,name=invocation_count,json=invocationCount,proto3" json:"invocation_count,omitempty"`
+	AvgRunTime           uint64   `protobuf:"varint,5,opt,name=avg_run_time,json=avgRunTime,proto3" json:"avg_run_time,omitempty"`
+	FiveSeconds          float64  `protobuf:"fixed64,6,opt,name=five_seconds,json=fiveSeconds,proto3" json:"five_seconds,omitempty"`
+	OneMinute            float64  `protobuf:"fixed64,7,opt,name=one_minute,json=oneMinute,proto3" json:"one_minute,omitempty"`
+	FiveMinutes          float64  `protobuf:"fixed64,8,opt,name=five_minutes,json=fiveMinutes,proto3" json:"five_minutes,omitempty"`
+	Tty                  uint32   `protobuf:"varint,9,opt,name=tty,proto3" json:"tty,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CpuUtilization_CpuUsageProcess) Reset()         { *m = CpuUtilization_CpuUsageProcess{} }
+func (m *CpuUtilization_CpuUsageProcess) String() string { return proto.CompactTextString(m) }
+func (*CpuUtilization_CpuUsageProcess) ProtoMessage()    {}
+func (*CpuUtilization_CpuUsageProcess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_89d150943c95b8e4, []int{0, 0}
+}
+func (m *CpuUtilization_CpuUsageProcess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CpuUtilization_CpuUsageProcess.Unmarshal(m, b)
+}
+func (m *CpuUtilization_CpuUsageProcess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CpuUtilization_CpuUsageProcess.Marshal(b, m, deterministic)
+}
+func (m *CpuUtilization_CpuUsageProcess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CpuUtilization_CpuUsageProcess.Merge(m, src)
+}
+func (m *CpuUtilization_CpuUsageProcess) XXX_Size() int {
+	return xxx_messageInfo_CpuUtilization_CpuUsageProcess.Size(m)
+}
+func (m *CpuUtilization_CpuUsageProcess) XXX_DiscardUnknown() {
+	xxx_messageInfo_CpuUtilization_CpuUsageProcess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CpuUtilization_CpuUsageProcess proto.InternalMessageInfo
+
+func (m *CpuUtilization_CpuUsageProcess) GetPid() uint32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetTotalRunTime() uint64 {
+	if m != nil {
+		return m.TotalRunTime
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetInvocationCount() uint64 {
+	if m != nil {
+		return m.InvocationCount
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetAvgRunTime() uint64 {
+	if m != nil {
+		return m.AvgRunTime
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetFiveSeconds() float64 {
+	if m != nil {
+		return m.FiveSeconds
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetOneMinute() float64 {
+	if m != nil {
+		return m.OneMinute
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetFiveMinutes() float64 {
+	if m != nil {
+		return m.FiveMinutes
+	}
+	return 0
+}
+
+func (m *CpuUtilization_CpuUsageProcess) GetTty() uint32 {
+	if m != nil {
+		return m.Tty
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CpuUtilization)(nil), "gpbmodels.CpuUtilization")
+	proto.RegisterType((*CpuUtilization_CpuUsageProcess)(nil), "gpbmodels.CpuUtilization.CpuUsageProcess")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/process_cpu_oper.proto", fileDescriptor_89d150943c95b8e4)
+}
+
+var fileDescriptor_89d150943c95b8e4 = []byte{
+	// 336 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x6e, 0xb2, 0x40,
+	0x10, 0xc7, 0x83, 0xa0, 0xdf, 0xc7, 0x68, 0x45, 0xf7, 0x44, 0x9a, 0x34, 0xa1, 0x4d, 0xd3, 0x68,
+	0x0f, 0x9a, 0xb4, 0x8f, 0xe0, 0xa9, 0x87, 0x26, 0x0d, 0xad, 0xe7, 0x0d, 0xe2, 0x96, 0x6c, 0x22,
+	0xbb, 0x84, 0x9d, 0x25, 0xb1, 0xcf, 0xd2, 0x4b, 0xdf, 0xb4, 0x61, 0x50, 0x11, 0x0f, 0xbd, 0x2d,
+	0x7f, 0x7e, 0x3b, 0xc3, 0x6f, 0x06, 0x78, 0x28, 0x4a, 0x8d, 0x7a, 0x99, 0x15, 0x9b, 0x5c, 0x6f,
+	0xc5, 0xce, 0x2c, 0x8b, 0x52, 0xa7, 0xc2, 0x18, 0x9e, 0x16, 0x96, 0xeb, 0x42, 0x94, 0x0b, 0x02,
+	0x98, 0x7f, 0x22, 0xee, 0xbe, 0x3d, 0x18, 0xaf, 0x0a, 0xbb, 0x46, 0xb9, 0x93, 0x5f, 0x09, 0x4a,
+	0xad, 0xd8, 0x2d, 0x8c, 0x3e, 0x65, 0x25, 0xb8, 0x11, 0xa9, 0x56, 0x5b, 0x13, 0x3a, 0x91, 0x33,
+	0xbb, 0x8a, 0x87, 0x75, 0xf6, 0xde, 0x44, 0xec, 0x11, 0xa6, 0xe7, 0x08, 0x97, 0x0a, 0xcb, 0xb0,
+	0x47, 0x5c, 0x70, 0xc6, 0xbd, 0x28, 0x2c, 0xd9, 0x0d, 0x80, 0x56, 0x82, 0xe7, 0x52, 0x59, 0x14,
+	0xa1, 0x4b, 0x90, 0xaf, 0x95, 0x78, 0xa5, 0xe0, 0xd4, 0xad, 0x79, 0x6f, 0x42, 0xaf, 0xed, 0xd6,
+	0x10, 0x86, 0xad, 0x61, 0x5a, 0x0b, 0x58, 0x93, 0x64, 0x82, 0x1f, 0x94, 0xc2, 0x7e, 0xe4, 0xce,
+	0x86, 0x4f, 0xf3, 0xc5, 0x49, 0x65, 0xd1, 0xd5, 0xa0, 0xc7, 0xfa, 0xc6, 0x5b, 0x73, 0x21, 0x0e,
+	0xd2, 0x6e, 0x70, 0xfd, 0xd3, 0x83, 0xe0, 0x02, 0x62, 0x13, 0x70, 0x0b, 0xb9, 0x3d, 0x28, 0xd7,
+	0x47, 0xc6, 0xc0, 0x53, 0x49, 0x2e, 0xc8, 0xce, 0x8f, 0xe9, 0xcc, 0xee, 0x61, 0x8c, 0x1a, 0x93,
+	0x1d, 0x2f, 0xad, 0xe2, 0x28, 0xf3, 0x46, 0xcb, 0x8b, 0x47, 0x94, 0xc6, 0x56, 0x7d, 0xc8, 0x5c,
+	0xb0, 0x39, 0x4c, 0xa4, 0xaa, 0x74, 0x4a, 0x9f, 0xc3, 0x53, 0x6d, 0x15, 0x92, 0x9d, 0x17, 0x07,
+	0x6d, 0xbe, 0xaa, 0x63, 0x16, 0xc1, 0x28, 0xa9, 0xb2, 0xb6, 0x5c, 0x9f, 0x30, 0x48, 0xaa, 0xec,
+	0x58, 0xec, 0x72, 0x29, 0x83, 0xc8, 0x99, 0x39, 0xdd, 0xa5, 0x74, 0x07, 0xfd, 0x8f, 0x80, 0x3f,
+	0x06, 0xfd, 0xbf, 0xad, 0x70, 0x1c, 0xf4, 0x04, 0x5c, 0xc4, 0x7d, 0xe8, 0x37, 0xf6, 0x88, 0xfb,
+	0xcd, 0x80, 0x7e, 0x98, 0xe7, 0xdf, 0x01, 0x00, 0x1b, 0xdd, 0xe4, 0xac, 0x5a, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/gpbmodels/process_memory_oper.proto
+
+package gpbmodels
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type MemoryUsageProcessKeys struct {
+	Pid                  uint32   `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemoryUsageProcessKeys) Reset()         { *m = MemoryUsageProcessKeys{} }
+func (m *MemoryUsageProcessKeys) String() string { return proto.CompactTextString(m) }
+func (*MemoryUsageProcessKeys) ProtoMessage()    {}
+func (*MemoryUsageProcessKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a381f059c75e7bb9, []int{0}
+}
+func (m *MemoryUsageProcessKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryUsageProcessKeys.Unmarshal(m, b)
+}
+func (m *MemoryUsageProcessKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryUsageProcessKeys.Marshal(b, m, deterministic)
+}
+func (m *MemoryUsageProcessKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryUsageProcessKeys.Merge(m, src)
+}
+func (m *MemoryUsageProcessKeys) XXX_Size() int {
+	return xxx_messageInfo_MemoryUsageProcessKeys.Size(m)
+}
+func (m *MemoryUsageProcessKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryUsageProcessKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryUsageProcessKeys proto.InternalMessageInfo
+
+func (m *MemoryUsageProcessKeys) GetPid() uint32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcessKeys) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type MemoryUsageProcess struct {
+	Pid                  uint32   `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Tty                  uint32   `protobuf:"varint,3,opt,name=tty,proto3" json:"tty,omitempty"`
+	AllocatedMemory      uint64   `protobuf:"varint,4,opt,name=allocated_memory,json=allocatedMemory,proto3" json:"allocated_memory,omitempty"`
+	FreedMemory          uint64   `protobuf:"varint,5,opt,name=freed_memory,json=freedMemory,proto3" json:"freed_memory,omitempty"`
+	HoldingMemory        uint64   `protobuf:"varint,6,opt,name=holding_memory,json=holdingMemory,proto3" json:"holding_memory,omitempty"`
+	GetBuffers           uint64   `protobuf:"varint,7,opt,name=get_buffers,json=getBuffers,proto3" json:"get_buffers,omitempty"`
+	RetBuffers           uint64   `protobuf:"varint,8,opt,name=ret_buffers,json=retBuffers,proto3" json:"ret_buffers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemoryUsageProcess) Reset()         { *m = MemoryUsageProcess{} }
+func (m *MemoryUsageProcess) String() string { return proto.CompactTextString(m) }
+func (*MemoryUsageProcess) ProtoMessage()    {}
+func (*MemoryUsageProcess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a381f059c75e7bb9, []int{1}
+}
+func (m *MemoryUsageProcess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryUsageProcess.Unmarshal(m, b)
+}
+func (m *MemoryUsageProcess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryUsageProcess.Marshal(b, m, deterministic)
+}
+func (m *MemoryUsageProcess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryUsageProcess.Merge(m, src)
+}
+func (m *MemoryUsageProcess) XXX_Size() int {
+	return xxx_messageInfo_MemoryUsageProcess.Size(m)
+}
+func (m *MemoryUsageProcess) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryUsageProcess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryUsageProcess proto.InternalMessageInfo
+
+func (m *MemoryUsageProcess) GetPid() uint32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcess) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MemoryUsageProcess) GetTty() uint32 {
+	if m != nil {
+		return m.Tty
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcess) GetAllocatedMemory() uint64 {
+	if m != nil {
+		return m.AllocatedMemory
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcess) GetFreedMemory() uint64 {
+	if m != nil {
+		return m.FreedMemory
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcess) GetHoldingMemory() uint64 {
+	if m != nil {
+		return m.HoldingMemory
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcess) GetGetBuffers() uint64 {
+	if m != nil {
+		return m.GetBuffers
+	}
+	return 0
+}
+
+func (m *MemoryUsageProcess) GetRetBuffers() uint64 {
+	if m != nil {
+		return m.RetBuffers
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*MemoryUsageProcessKeys)(nil), "gpbmodels.MemoryUsageProcessKeys")
+	proto.RegisterType((*MemoryUsageProcess)(nil), "gpbmodels.MemoryUsageProcess")
+}
+
+func init() {
+	proto.RegisterFile("proto/gpbmodels/process_memory_oper.proto", fileDescriptor_a381f059c75e7bb9)
+}
+
+var fileDescriptor_a381f059c75e7bb9 = []byte{
+	// 237 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0xd0, 0xb1, 0x4b, 0xc3, 0x40,
+	0x14, 0x06, 0x70, 0xd2, 0xc6, 0x6a, 0x5f, 0xad, 0x96, 0x1b, 0xe4, 0x36, 0x63, 0x41, 0x48, 0x17,
+	0x3b, 0xb8, 0x3b, 0xb8, 0x8a, 0x20, 0x01, 0xe7, 0x90, 0x34, 0x2f, 0x67, 0x21, 0xc9, 0x1d, 0xef,
+	0x9e, 0x43, 0x66, 0xff, 0x71, 0xc9, 0xbb, 0x18, 0x05, 0x97, 0x6e, 0x8f, 0xef, 0x7e, 0xdf, 0xdd,
+	0xf1, 0x60, 0xe7, 0xc8, 0xb2, 0xdd, 0x1b, 0x57, 0xb6, 0xb6, 0xc2, 0xc6, 0xef, 0x1d, 0xd9, 0x03,
+	0x7a, 0x9f, 0xb7, 0xd8, 0x5a, 0xea, 0x73, 0xeb, 0x90, 0x1e, 0xc4, 0xa8, 0xe5, 0x84, 0xb6, 0x4f,
+	0x70, 0xf3, 0x2a, 0xe7, 0xef, 0xbe, 0x30, 0xf8, 0x16, 0x2a, 0x2f, 0xd8, 0x7b, 0xb5, 0x81, 0xb9,
+	0x3b, 0x56, 0x3a, 0x4a, 0xa2, 0x74, 0x9d, 0x0d, 0xa3, 0x52, 0x10, 0x77, 0x45, 0x8b, 0x7a, 0x96,
+	0x44, 0xe9, 0x32, 0x93, 0x79, 0xfb, 0x35, 0x03, 0xf5, 0xff, 0x82, 0xd3, 0xca, 0x83, 0x62, 0xee,
+	0xf5, 0x3c, 0x28, 0xe6, 0x5e, 0xed, 0x60, 0x53, 0x34, 0x8d, 0x3d, 0x14, 0x8c, 0xd5, 0xf8, 0x71,
+	0x1d, 0x27, 0x51, 0x1a, 0x67, 0xd7, 0x53, 0x1e, 0x9e, 0x53, 0x77, 0x70, 0x59, 0x13, 0xfe, 0xb2,
+	0x33, 0x61, 0x2b, 0xc9, 0x46, 0x72, 0x0f, 0x57, 0x1f, 0xb6, 0xa9, 0x8e, 0x9d, 0xf9, 0x41, 0x0b,
+	0x41, 0xeb, 0x31, 0x1d, 0xd9, 0x2d, 0xac, 0x0c, 0x72, 0x5e, 0x7e, 0xd6, 0x35, 0x92, 0xd7, 0xe7,
+	0x62, 0xc0, 0x20, 0x3f, 0x87, 0x64, 0x00, 0xf4, 0x07, 0x5c, 0x04, 0x40, 0x13, 0x28, 0x17, 0xb2,
+	0xd7, 0xc7, 0xef, 0x01, 0x00, 0x9b, 0xd6, 0x09, 0x7f, 0x84, 0x01, 0x00, 0x00,
+}
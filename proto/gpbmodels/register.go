@@ -0,0 +1,62 @@
+// Package gpbmodels holds the compact GPB protos of the YANG encoding paths supported by the metrics parsers.
+// The models are registered with the decoder when the package is imported. Field numbers must match the protos
+// the devices were compiled with: rows carrying fields unknown to a model are rejected by the decoder.
+package gpbmodels
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/lucabrasi83/peppamon_cisco/decoder"
+)
+
+func init() {
+
+	for path, model := range map[string]decoder.GPBModel{
+		"Cisco-IOS-XE-interfaces-oper:interfaces/interface": {
+			Keys:    func() proto.Message { return &InterfaceKeys{} },
+			Content: func() proto.Message { return &Interface{} },
+		},
+		"Cisco-IOS-XE-bgp-oper:bgp-state-data/address-families/address-family": {
+			Keys:    func() proto.Message { return &BgpAddressFamilyKeys{} },
+			Content: func() proto.Message { return &BgpAddressFamily{} },
+		},
+		"Cisco-IOS-XE-ospf-oper:ospf-oper-data/ospfv2-instance/ospfv2-area/ospfv2-interface/ospfv2-neighbor": {
+			Keys:    func() proto.Message { return &Ospfv2NeighborKeys{} },
+			Content: func() proto.Message { return &Ospfv2Neighbor{} },
+		},
+		"Cisco-IOS-XE-eigrp-oper:eigrp-oper-data/eigrp-instance/eigrp-interface/eigrp-nbr": {
+			Keys:    func() proto.Message { return &EigrpNbrKeys{} },
+			Content: func() proto.Message { return &EigrpNbr{} },
+		},
+		"Cisco-IOS-XE-ip-sla-oper:ip-sla-stats/sla-oper-entry": {
+			Keys:    func() proto.Message { return &SlaOperEntryKeys{} },
+			Content: func() proto.Message { return &SlaOperEntry{} },
+		},
+		"Cisco-IOS-XE-native:native/ip/Cisco-IOS-XE-sla:sla/entry": {
+			Keys:    func() proto.Message { return &SlaEntryKeys{} },
+			Content: func() proto.Message { return &SlaEntry{} },
+		},
+		"Cisco-IOS-XE-flow-monitor-oper:flow-monitors/flow-monitor": {
+			Keys:    func() proto.Message { return &FlowMonitorKeys{} },
+			Content: func() proto.Message { return &FlowMonitor{} },
+		},
+		"Cisco-IOS-XE-device-hardware-oper:device-hardware-data": {
+			Content: func() proto.Message { return &DeviceHardwareData{} },
+		},
+		"Cisco-IOS-XE-native:native/license": {
+			Content: func() proto.Message { return &License{} },
+		},
+		"Cisco-IOS-XE-process-cpu-oper:cpu-usage/cpu-utilization": {
+			Content: func() proto.Message { return &CpuUtilization{} },
+		},
+		"Cisco-IOS-XE-memory-oper:memory-statistics/memory-statistic": {
+			Keys:    func() proto.Message { return &MemoryStatisticKeys{} },
+			Content: func() proto.Message { return &MemoryStatistic{} },
+		},
+		"Cisco-IOS-XE-process-memory-oper:memory-usage-processes/memory-usage-process": {
+			Keys:    func() proto.Message { return &MemoryUsageProcessKeys{} },
+			Content: func() proto.Message { return &MemoryUsageProcess{} },
+		},
+	} {
+		decoder.RegisterGPBModel(path, model)
+	}
+}
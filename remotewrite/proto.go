@@ -0,0 +1,55 @@
+package remotewrite
+
+import (
+	"sort"
+
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/pbwire"
+)
+
+// encodeWriteRequest marshals samples into a remote write WriteRequest protocol buffer message:
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label        { string name = 1; string value = 2; }
+//	message Sample       { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(samples []metrics.Sample) []byte {
+
+	var req []byte
+
+	for _, s := range samples {
+
+		var ts []byte
+
+		for _, l := range seriesLabels(s) {
+
+			var label []byte
+			label = pbwire.AppendStringField(label, 1, l.Name)
+			label = pbwire.AppendStringField(label, 2, l.Value)
+
+			ts = pbwire.AppendBytesField(ts, 1, label)
+		}
+
+		var sample []byte
+		sample = pbwire.AppendDoubleField(sample, 1, s.Value)
+		sample = pbwire.AppendVarintField(sample, 2, uint64(s.Timestamp.UnixNano()/1e6))
+
+		ts = pbwire.AppendBytesField(ts, 2, sample)
+
+		req = pbwire.AppendBytesField(req, 1, ts)
+	}
+
+	return req
+}
+
+// seriesLabels returns the sample labels including the metric name, sorted by name as required by remote write
+func seriesLabels(s metrics.Sample) []metrics.Label {
+
+	labels := make([]metrics.Label, 0, len(s.Labels)+1)
+	labels = append(labels, metrics.Label{Name: "__name__", Value: s.Name})
+	labels = append(labels, s.Labels...)
+
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+	return labels
+}
@@ -0,0 +1,104 @@
+// Package remotewrite pushes the Telemetry metrics samples to a Prometheus remote write endpoint
+// such as Cortex, Thanos Receive or Mimir
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Settings represents the Prometheus remote write sink settings
+type Settings struct {
+	// Remote write endpoint URL. i.e. http://cortex:9009/api/v1/push
+	URL string
+
+	// Optional basic authentication or bearer token
+	Username    string
+	Password    string
+	BearerToken string
+
+	// HTTP request timeout
+	Timeout time.Duration
+
+	// Batching of the samples
+	Batch batch.Settings
+}
+
+// Enabled returns whether the remote write sink is configured
+func (s Settings) Enabled() bool {
+	return s.URL != ""
+}
+
+// SettingsFromConfig builds the remote write sink settings from the outputs.remote_write configuration section
+func SettingsFromConfig(c config.RemoteWrite) Settings {
+
+	return Settings{
+		URL:         c.URL,
+		Username:    c.Username,
+		Password:    c.Password,
+		BearerToken: c.BearerToken,
+		Timeout:     c.Timeout,
+		Batch:       batch.SettingsFromConfig(c.Batch),
+	}
+}
+
+// Writer batches the published samples and pushes them to the remote write endpoint.
+// It implements the metrics.Sink interface.
+type Writer struct {
+	*batch.Queue
+
+	settings Settings
+	client   *http.Client
+}
+
+// NewWriter returns a remote write Writer. Samples are sent once Run is started
+func NewWriter(s Settings) *Writer {
+
+	w := &Writer{
+		settings: s,
+		client:   &http.Client{Timeout: s.Timeout},
+	}
+
+	w.Queue = batch.NewQueue("remote_write", s.Batch, w.write)
+
+	return w
+}
+
+// write sends a batch of samples as a snappy compressed WriteRequest
+func (w *Writer) write(ctx context.Context, samples []metrics.Sample) (bool, error) {
+	return w.send(ctx, snappy.Encode(nil, encodeWriteRequest(samples)))
+}
+
+// send posts a remote write request and returns whether a failure is recoverable
+func (w *Writer) send(ctx context.Context, body []byte) (bool, error) {
+
+	req, err := http.NewRequest(http.MethodPost, w.settings.URL, bytes.NewReader(body))
+
+	if err != nil {
+		return false, err
+	}
+
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "peppamon")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	if w.settings.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+w.settings.BearerToken)
+	} else if w.settings.Username != "" {
+		req.SetBasicAuth(w.settings.Username, w.settings.Password)
+	}
+
+	return batch.Post(w.client, req)
+}
@@ -0,0 +1,299 @@
+package remotewrite
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+// The prompb remote write messages, declared with the field numbers of the Prometheus prompb package so the
+// requests are decoded by the gogo protobuf library rather than by pbwire.
+type prompbWriteRequest struct {
+	Timeseries []*prompbTimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3"`
+}
+
+type prompbTimeSeries struct {
+	Labels  []*prompbLabel  `protobuf:"bytes,1,rep,name=labels,proto3"`
+	Samples []*prompbSample `protobuf:"bytes,2,rep,name=samples,proto3"`
+}
+
+type prompbLabel struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3"`
+}
+
+type prompbSample struct {
+	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3"`
+	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3"`
+}
+
+func (m *prompbWriteRequest) Reset()         { *m = prompbWriteRequest{} }
+func (m *prompbWriteRequest) String() string { return proto.CompactTextString(m) }
+func (*prompbWriteRequest) ProtoMessage()    {}
+
+// endpoint represents a remote write receiver answering the successive requests with the given statuses
+type endpoint struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   []*prompbWriteRequest
+	errs     []error
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	wr, err := decodeRequest(r)
+
+	e.requests = append(e.requests, r)
+	e.bodies = append(e.bodies, wr)
+	e.errs = append(e.errs, err)
+
+	status := http.StatusNoContent
+
+	if len(e.statuses) > 0 {
+		status = e.statuses[0]
+
+		if len(e.statuses) > 1 {
+			e.statuses = e.statuses[1:]
+		}
+	}
+
+	w.WriteHeader(status)
+}
+
+func (e *endpoint) received() int {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return len(e.requests)
+}
+
+func decodeRequest(r *http.Request) (*prompbWriteRequest, error) {
+
+	compressed, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := snappy.Decode(nil, compressed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var wr prompbWriteRequest
+
+	if err := proto.Unmarshal(b, &wr); err != nil {
+		return nil, err
+	}
+
+	return &wr, nil
+}
+
+// startEndpoint starts a remote write receiver and returns a Writer pushing to it
+func startEndpoint(t *testing.T, statuses ...int) (*endpoint, *Writer) {
+
+	e := &endpoint{statuses: statuses}
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	w := NewWriter(Settings{
+		URL:         srv.URL,
+		BearerToken: "token",
+		Timeout:     5 * time.Second,
+		Batch:       batch.Settings{BatchSize: 100, FlushInterval: 10 * time.Millisecond, QueueSize: 100, MaxRetries: 3},
+	})
+
+	return e, w
+}
+
+func testSamples() []metrics.Sample {
+
+	ts := time.Unix(1600000000, 123456789)
+
+	return []metrics.Sample{
+		{
+			Name:      "cisco_iosxe_if_in_octets",
+			Labels:    []metrics.Label{{Name: "node", Value: "r1"}, {Name: "interface", Value: "Gi1"}},
+			Value:     1234,
+			Timestamp: ts,
+		},
+		{
+			Name:      "cisco_iosxe_cpu_busy_five_seconds",
+			Labels:    []metrics.Label{{Name: "node", Value: "r1"}},
+			Value:     2.5,
+			Timestamp: ts.Add(time.Second),
+		},
+	}
+}
+
+func TestWriterRequest(t *testing.T) {
+
+	e, w := startEndpoint(t)
+
+	if _, err := w.write(context.Background(), testSamples()); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	if e.received() != 1 {
+		t.Fatalf("%d request(s) received, want 1", e.received())
+	}
+
+	r := e.requests[0]
+
+	for header, want := range map[string]string{
+		"Content-Encoding":                  "snappy",
+		"Content-Type":                      "application/x-protobuf",
+		"X-Prometheus-Remote-Write-Version": "0.1.0",
+		"Authorization":                     "Bearer token",
+	} {
+		if got := r.Header.Get(header); got != want {
+			t.Errorf("header %v = %q, want %q", header, got, want)
+		}
+	}
+
+	if e.errs[0] != nil {
+		t.Fatalf("request body decoding error = %v", e.errs[0])
+	}
+
+	// Labels are sorted by name with the metric name, timestamps are in milliseconds
+	want := &prompbWriteRequest{Timeseries: []*prompbTimeSeries{
+		{
+			Labels: []*prompbLabel{
+				{Name: "__name__", Value: "cisco_iosxe_if_in_octets"},
+				{Name: "interface", Value: "Gi1"},
+				{Name: "node", Value: "r1"},
+			},
+			Samples: []*prompbSample{{Value: 1234, Timestamp: 1600000000123}},
+		},
+		{
+			Labels: []*prompbLabel{
+				{Name: "__name__", Value: "cisco_iosxe_cpu_busy_five_seconds"},
+				{Name: "node", Value: "r1"},
+			},
+			Samples: []*prompbSample{{Value: 2.5, Timestamp: 1600000001123}},
+		},
+	}}
+
+	if !reflect.DeepEqual(e.bodies[0], want) {
+		t.Errorf("write request =\n%v\nwant\n%v", e.bodies[0], want)
+	}
+}
+
+func TestWriterStatus(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		status          int
+		wantErr         bool
+		wantRecoverable bool
+	}{
+		{name: "no content", status: http.StatusNoContent, wantRecoverable: true},
+		{name: "bad request", status: http.StatusBadRequest, wantErr: true, wantRecoverable: false},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantErr: true, wantRecoverable: true},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, wantErr: true, wantRecoverable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			_, w := startEndpoint(t, tt.status)
+
+			recoverable, err := w.write(context.Background(), testSamples())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("write() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			// Successful writes are not retried whatever the recoverable value
+			if err != nil && recoverable != tt.wantRecoverable {
+				t.Errorf("write() recoverable = %v, want %v", recoverable, tt.wantRecoverable)
+			}
+		})
+	}
+}
+
+// runWriter runs the writer until stop is called, which waits for Run to return
+func runWriter(w *Writer) (stop func()) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func waitReceived(t *testing.T, e *endpoint, n int) {
+
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for e.received() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d request(s) received, want %d", e.received(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWriterRetriesServerErrors(t *testing.T) {
+
+	e, w := startEndpoint(t, http.StatusServiceUnavailable, http.StatusNoContent)
+
+	stop := runWriter(w)
+	defer stop()
+
+	w.Publish(&metrics.Round{Samples: testSamples()})
+
+	waitReceived(t, e, 2)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !reflect.DeepEqual(e.bodies[0], e.bodies[1]) {
+		t.Errorf("retried request =\n%v\nwant\n%v", e.bodies[1], e.bodies[0])
+	}
+}
+
+func TestWriterDoesNotRetryClientErrors(t *testing.T) {
+
+	e, w := startEndpoint(t, http.StatusBadRequest)
+
+	stop := runWriter(w)
+	defer stop()
+
+	w.Publish(&metrics.Round{Samples: testSamples()})
+
+	waitReceived(t, e, 1)
+
+	// Longer than the first retry backoff of the batch queue
+	time.Sleep(700 * time.Millisecond)
+
+	if e.received() != 1 {
+		t.Errorf("%d request(s) received, want the batch dropped after 1", e.received())
+	}
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/capture"
+	"github.com/lucabrasi83/peppamon_cisco/decoder"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metadb"
+	mdt_dialout "github.com/lucabrasi83/peppamon_cisco/proto/mdt_grpc_dialout"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+const replayUsage = `Usage: peppamon replay [options] <capture file or directory>...
+
+Replay raw Telemetry capture files recorded with capture.dir through the local
+parsers and output sinks, or to a remote Peppamon collector over the MDT dial-out RPC.
+Records of all devices are replayed in their original order.
+
+Options:
+`
+
+// replayTarget represents the destination of the replayed dial-out messages
+type replayTarget interface {
+	Send(ctx context.Context, device string, args *mdt_dialout.MdtDialoutArgs)
+	Close()
+}
+
+// runReplay implements the replay command
+func runReplay(args []string) {
+
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+
+	speed := fs.Float64("speed", 1,
+		"Replay speed factor. 1 keeps the original pace, 10 replays ten times faster, 0 as fast as possible")
+	client := registerDialoutClientFlags(fs, "",
+		"Remote Peppamon collector address (host:port). Messages go through the local pipeline when empty")
+	listen := fs.String("listen", "",
+		"Serve the local pipeline Prometheus metrics on this address (i.e. :2112) until interrupted")
+	linger := fs.Duration("linger", 10*time.Second,
+		"Time the local pipeline keeps running after the last message to flush collection rounds and output sinks")
+
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), replayUsage)
+		fs.PrintDefaults()
+	}
+
+	// ExitOnError flag set exits on parsing errors
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 || *speed < 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var files []string
+
+	for _, path := range fs.Args() {
+
+		pathFiles, err := capture.Files(path)
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to list capture files %v", err)
+		}
+		files = append(files, pathFiles...)
+	}
+
+	streams := openCaptureStreams(files)
+
+	if len(streams) == 0 {
+		logging.PeppaMonLog(
+			"fatal",
+			"No Telemetry record found in %v", fs.Args())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Stop replay on interrupt
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+
+	go func() {
+		<-ch
+		logging.PeppaMonLog("warning", "Stopping Telemetry replay...")
+		cancel()
+	}()
+
+	var dst replayTarget
+
+	// Output sinks of the local pipeline, drained once the replay completes
+	ctxSinks, cancelSinks := context.WithCancel(context.Background())
+	defer cancelSinks()
+
+	outputSinks := &sync.WaitGroup{}
+
+	if *client.target != "" {
+
+		conn, err := client.dial()
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to create gRPC connection to %v: %v", *client.target, err)
+		}
+		defer conn.Close()
+
+		dst = newRemoteReplay(conn)
+
+		logging.PeppaMonLog(
+			"info",
+			"Replaying %v capture file(s) to remote collector %v...", len(files), *client.target)
+	} else {
+
+		// Local pipeline parsers persist the metadata when enabled. Configuration is read from the file and environment only
+		cfg := loadConfig(nil)
+
+		metadb.Start(ctx, cfg.MetaDB)
+		defer metadb.Close()
+
+		loadMetricMappings(cfg.Metrics)
+		setupUnsupportedPaths(cfg.Metrics)
+		outputSinks = setupOutputSinks(ctxSinks, cfg)
+
+		if *listen != "" {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", promhttp.Handler())
+
+				if err := http.ListenAndServe(*listen, mux); err != nil {
+					logging.PeppaMonLog(
+						"fatal",
+						"Failed to start Prometheus HTTP metrics handler %v", err)
+				}
+			}()
+		}
+
+		dst = &localReplay{unsupported: make(map[string]bool)}
+
+		logging.PeppaMonLog(
+			"info",
+			"Replaying %v capture file(s) through the local pipeline...", len(files))
+	}
+
+	sent := replayStreams(ctx, streams, *speed, dst)
+	dst.Close()
+
+	logging.PeppaMonLog(
+		"info",
+		"Replayed %v Telemetry message(s)", sent)
+
+	local, ok := dst.(*localReplay)
+
+	if !ok {
+		return
+	}
+
+	logging.PeppaMonLog(
+		"info",
+		"Local pipeline: %v decode error(s), %v message(s) for unsupported YANG paths",
+		local.decodeErrors, local.unsupportedMsgs)
+
+	// Let staged collection rounds and output sinks batches flush
+	wait := time.After(*linger)
+
+	if *listen != "" {
+		logging.PeppaMonLog(
+			"info",
+			"Serving replayed metrics on %v/metrics until interrupted", *listen)
+		wait = nil
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-wait:
+	}
+
+	cancelSinks()
+	outputSinks.Wait()
+}
+
+// replayStreams sends the records of all devices in time order, waiting between records
+// for their original interval divided by speed. It returns the number of records sent
+func replayStreams(ctx context.Context, streams captureHeap, speed float64, dst replayTarget) int {
+
+	heap.Init(&streams)
+
+	var sent int
+
+	firstRecord := streams[0].rec.Time
+	start := time.Now()
+
+	for streams.Len() > 0 {
+
+		cs := streams[0]
+
+		if speed > 0 {
+
+			due := start.Add(time.Duration(float64(cs.rec.Time.Sub(firstRecord)) / speed))
+
+			if wait := time.Until(due); wait > 0 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		dst.Send(ctx, cs.device, cs.rec.Args)
+		sent++
+
+		if cs.advance() {
+			heap.Fix(&streams, 0)
+		} else {
+			heap.Pop(&streams)
+		}
+	}
+
+	for _, cs := range streams {
+		cs.close()
+	}
+
+	return sent
+}
+
+// localReplay dispatches the replayed messages to the local parsers and output sinks
+type localReplay struct {
+	decodeErrors    int
+	unsupportedMsgs int
+
+	// YANG paths already reported as unsupported
+	unsupported map[string]bool
+}
+
+func (l *localReplay) Send(ctx context.Context, device string, args *mdt_dialout.MdtDialoutArgs) {
+
+	msg, encoding, err := decoder.Decode(args.GetData())
+
+	if err != nil {
+		l.decodeErrors++
+
+		logging.PeppaMonLog(
+			"error",
+			"Error while decoding %v Telemetry message from device %v : %v", encoding, device, err)
+
+		return
+	}
+
+	if _, ok := collector.RecordTelemetryMsg(msg); !ok {
+
+		l.unsupportedMsgs++
+
+		if !l.unsupported[msg.GetEncodingPath()] {
+			l.unsupported[msg.GetEncodingPath()] = true
+
+			logging.PeppaMonLog(
+				"warning",
+				"Replayed Telemetry message from device %v for unsupported YANG Node Path %v",
+				device, msg.GetEncodingPath())
+		}
+	}
+}
+
+func (l *localReplay) Close() {}
+
+// remoteReplay sends the replayed messages to a remote collector over one dial-out stream per device
+type remoteReplay struct {
+	client  mdt_dialout.GRPCMdtDialoutClient
+	streams map[string]mdt_dialout.GRPCMdtDialout_MdtDialoutClient
+}
+
+func newRemoteReplay(conn *grpc.ClientConn) *remoteReplay {
+
+	return &remoteReplay{
+		client:  mdt_dialout.NewGRPCMdtDialoutClient(conn),
+		streams: make(map[string]mdt_dialout.GRPCMdtDialout_MdtDialoutClient),
+	}
+}
+
+func (r *remoteReplay) Send(ctx context.Context, device string, args *mdt_dialout.MdtDialoutArgs) {
+
+	stream, ok := r.streams[device]
+
+	if !ok {
+
+		var err error
+
+		if stream, err = r.client.MdtDialout(ctx); err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to open dial-out stream for device %v : %v", device, err)
+			return
+		}
+		r.streams[device] = stream
+	}
+
+	if err := stream.Send(args); err != nil {
+
+		// Actual error is the stream status returned by the collector
+		if _, errRecv := stream.Recv(); errRecv != nil && errRecv != io.EOF {
+			err = errRecv
+		}
+
+		logging.PeppaMonLog(
+			"error",
+			"Remote collector closed dial-out stream of device %v : %v", device, err)
+
+		// Next record of the device opens a new stream as the device would
+		delete(r.streams, device)
+	}
+}
+
+func (r *remoteReplay) Close() {
+
+	for device, stream := range r.streams {
+
+		if err := stream.CloseSend(); err != nil {
+			continue
+		}
+
+		// Wait for the collector to process the stream
+		if _, err := stream.Recv(); err != nil && err != io.EOF {
+			logging.PeppaMonLog(
+				"error",
+				"Remote collector closed dial-out stream of device %v : %v", device, err)
+		}
+	}
+}
+
+// captureStream reads the capture files of a device in order
+type captureStream struct {
+	device string
+	files  []string
+
+	f   *os.File
+	r   *capture.Reader
+	rec capture.Record
+}
+
+// openCaptureStreams groups the capture files per device and returns the streams holding at least a record
+func openCaptureStreams(files []string) captureHeap {
+
+	var streams captureHeap
+	byDevice := make(map[string]*captureStream)
+
+	for _, file := range files {
+
+		f, err := os.Open(file)
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Failed to open capture file %v", err)
+			continue
+		}
+
+		r, err := capture.NewReader(f)
+		_ = f.Close()
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"error",
+				"Skipping capture file %v : %v", file, err)
+			continue
+		}
+
+		cs, ok := byDevice[r.Device]
+
+		if !ok {
+			cs = &captureStream{device: r.Device}
+			byDevice[r.Device] = cs
+		}
+		cs.files = append(cs.files, file)
+	}
+
+	for _, cs := range byDevice {
+		if cs.advance() {
+			streams = append(streams, cs)
+		}
+	}
+
+	return streams
+}
+
+// advance loads the next record of the device, moving to its next capture file as needed.
+// It returns false once all the files are read.
+func (cs *captureStream) advance() bool {
+
+	for {
+		if cs.r == nil {
+
+			if len(cs.files) == 0 {
+				return false
+			}
+
+			file := cs.files[0]
+			cs.files = cs.files[1:]
+
+			f, err := os.Open(file)
+
+			if err != nil {
+				logging.PeppaMonLog(
+					"error",
+					"Failed to open capture file %v", err)
+				continue
+			}
+
+			r, err := capture.NewReader(f)
+
+			if err != nil {
+				_ = f.Close()
+				continue
+			}
+
+			cs.f, cs.r = f, r
+		}
+
+		rec, err := cs.r.Next()
+
+		if err == nil {
+			cs.rec = rec
+			return true
+		}
+
+		if err != io.EOF {
+			logging.PeppaMonLog(
+				"warning",
+				"Stopped reading capture file %v : %v", cs.f.Name(), err)
+		}
+
+		cs.close()
+	}
+}
+
+func (cs *captureStream) close() {
+
+	if cs.f != nil {
+		_ = cs.f.Close()
+	}
+	cs.f, cs.r = nil, nil
+}
+
+// captureHeap orders the device streams by time of their next record
+type captureHeap []*captureStream
+
+func (h captureHeap) Len() int            { return len(h) }
+func (h captureHeap) Less(i, j int) bool  { return h[i].rec.Time.Before(h[j].rec.Time) }
+func (h captureHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *captureHeap) Push(x interface{}) { *h = append(*h, x.(*captureStream)) }
+
+func (h *captureHeap) Pop() interface{} {
+
+	old := *h
+	n := len(old)
+	cs := old[n-1]
+	*h = old[:n-1]
+
+	return cs
+}
@@ -0,0 +1,299 @@
+// Package selfmetrics instruments the Peppamon collector itself with the peppamon_* metrics
+package selfmetrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "peppamon"
+
+// Results of the samples created by the metrics parsers
+const (
+	SampleCreated       = "created"
+	SampleInvalidValue  = "invalid_value"
+	SampleLabelMismatch = "label_mismatch"
+)
+
+// instrumentation represents the self-metrics of the Peppamon collector
+type instrumentation struct {
+	streamsConnected *prometheus.GaugeVec
+	streams          *prometheus.CounterVec
+	messages         *prometheus.CounterVec
+	unmarshalErrors  *prometheus.CounterVec
+	recoveredPanics  prometheus.Counter
+	samples          *prometheus.CounterVec
+	metaDBDuration   *prometheus.HistogramVec
+	metaDBFailures   *prometheus.CounterVec
+	queueCoalesced   *prometheus.CounterVec
+	queueRetries     *prometheus.CounterVec
+	queueDropped     *prometheus.CounterVec
+
+	// Functions returning the number of entries of the caches and metadata write queues on scrape
+	mu          *sync.Mutex
+	cacheSizes  map[string]func() int
+	cacheDesc   *prometheus.Desc
+	queueDepths map[string]func() int
+	queueDesc   *prometheus.Desc
+}
+
+var self = &instrumentation{
+	streamsConnected: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "streams_connected",
+			Help:      "The number of Telemetry streams currently connected",
+		},
+		[]string{"transport"},
+	),
+	streams: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "streams_total",
+			Help:      "The number of Telemetry streams opened",
+		},
+		[]string{"transport"},
+	),
+	messages: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "telemetry_messages_total",
+			Help:      "The number of Telemetry messages received per node and YANG encoding path",
+		},
+		[]string{"node", "path"},
+	),
+	unmarshalErrors: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "telemetry_unmarshal_failures_total",
+			Help:      "The number of Telemetry messages that could not be decoded per encoding",
+		},
+		[]string{"encoding"},
+	),
+	recoveredPanics: prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_recovered_panics_total",
+			Help:      "The number of panics recovered while handling the Telemetry gRPC streams",
+		},
+	),
+	samples: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "parser_samples_total",
+			Help:      "The number of samples decoded by the metrics parsers by result",
+		},
+		[]string{"result"},
+	),
+	metaDBDuration: prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "metadb_batch_duration_seconds",
+			Help:      "The duration of the Telemetry metadata database writes per table",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+		[]string{"table"},
+	),
+	metaDBFailures: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "metadb_batch_failures_total",
+			Help:      "The number of failed Telemetry metadata database writes per table",
+		},
+		[]string{"table"},
+	),
+	queueCoalesced: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "metadb_queue_coalesced_total",
+			Help:      "The number of Telemetry metadata writes replaced by a more recent write of the same node per table",
+		},
+		[]string{"table"},
+	),
+	queueRetries: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "metadb_queue_retries_total",
+			Help:      "The number of Telemetry metadata writes retried after a transient failure per table",
+		},
+		[]string{"table"},
+	),
+	queueDropped: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "metadb_queue_dropped_total",
+			Help:      "The number of Telemetry metadata writes dropped per table and reason",
+		},
+		[]string{"table", "reason"},
+	),
+	mu:         &sync.Mutex{},
+	cacheSizes: make(map[string]func() int),
+	cacheDesc: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cache_entries"),
+		"The number of entries held in the collector caches",
+		[]string{"cache"},
+		nil,
+	),
+	queueDepths: make(map[string]func() int),
+	queueDesc: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "metadb_queue_depth"),
+		"The number of nodes with a pending Telemetry metadata write per table",
+		[]string{"table"},
+		nil,
+	),
+}
+
+// Collector returns the prometheus.Collector exposing the self-metrics
+func Collector() prometheus.Collector {
+	return self
+}
+
+// StreamOpened records a Telemetry stream connection
+func StreamOpened(transport string) {
+
+	self.streams.WithLabelValues(transport).Inc()
+	self.streamsConnected.WithLabelValues(transport).Inc()
+}
+
+// StreamClosed records a Telemetry stream disconnection
+func StreamClosed(transport string) {
+	self.streamsConnected.WithLabelValues(transport).Dec()
+}
+
+// MessageReceived records a Telemetry message received from a node for a YANG encoding path
+func MessageReceived(node string, path string) {
+	self.messages.WithLabelValues(node, path).Inc()
+}
+
+// UnmarshalFailed records a Telemetry message that could not be decoded
+func UnmarshalFailed(encoding string) {
+	self.unmarshalErrors.WithLabelValues(encoding).Inc()
+}
+
+// PanicRecovered records a panic recovered by the gRPC middleware
+func PanicRecovered() {
+	self.recoveredPanics.Inc()
+}
+
+// SampleDecoded records the result of a sample created by a metrics parser
+func SampleDecoded(result string) {
+	self.samples.WithLabelValues(result).Inc()
+}
+
+// ObserveMetaDB records the duration and result of a Telemetry metadata database write
+func ObserveMetaDB(table string, d time.Duration, err error) {
+
+	self.metaDBDuration.WithLabelValues(table).Observe(d.Seconds())
+
+	if err != nil {
+		self.metaDBFailures.WithLabelValues(table).Inc()
+	}
+}
+
+// MetaDBWriteCoalesced records a pending metadata write replaced by a more recent one
+func MetaDBWriteCoalesced(table string) {
+	self.queueCoalesced.WithLabelValues(table).Inc()
+}
+
+// MetaDBWriteRetried records a metadata write scheduled for retry
+func MetaDBWriteRetried(table string) {
+	self.queueRetries.WithLabelValues(table).Inc()
+}
+
+// MetaDBWriteDropped records a metadata write dropped by the write queue
+func MetaDBWriteDropped(table string, reason string) {
+	self.queueDropped.WithLabelValues(table, reason).Inc()
+}
+
+// AddMetaDBQueueDepth registers a function returning the depth of a metadata write queue, evaluated on scrape
+func AddMetaDBQueueDepth(table string, depth func() int) {
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.queueDepths[table] = depth
+}
+
+// AddCacheSize registers a function returning the number of entries of a cache, evaluated on scrape
+func AddCacheSize(cache string, size func() int) {
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.cacheSizes[cache] = size
+}
+
+// Describe implements prometheus.Collector interface
+func (i *instrumentation) Describe(ch chan<- *prometheus.Desc) {
+
+	i.streamsConnected.Describe(ch)
+	i.streams.Describe(ch)
+	i.messages.Describe(ch)
+	i.unmarshalErrors.Describe(ch)
+	i.recoveredPanics.Describe(ch)
+	i.samples.Describe(ch)
+	i.metaDBDuration.Describe(ch)
+	i.metaDBFailures.Describe(ch)
+	i.queueCoalesced.Describe(ch)
+	i.queueRetries.Describe(ch)
+	i.queueDropped.Describe(ch)
+
+	ch <- i.cacheDesc
+	ch <- i.queueDesc
+}
+
+// Collect implements prometheus.Collector interface
+func (i *instrumentation) Collect(ch chan<- prometheus.Metric) {
+
+	i.streamsConnected.Collect(ch)
+	i.streams.Collect(ch)
+	i.messages.Collect(ch)
+	i.unmarshalErrors.Collect(ch)
+	i.recoveredPanics.Collect(ch)
+	i.samples.Collect(ch)
+	i.metaDBDuration.Collect(ch)
+	i.metaDBFailures.Collect(ch)
+	i.queueCoalesced.Collect(ch)
+	i.queueRetries.Collect(ch)
+	i.queueDropped.Collect(ch)
+
+	// Copy the size functions as they may lock the caches
+	i.mu.Lock()
+
+	cacheSizes := copySizeFuncs(i.cacheSizes)
+	queueDepths := copySizeFuncs(i.queueDepths)
+
+	i.mu.Unlock()
+
+	collectSizes(ch, i.cacheDesc, cacheSizes)
+	collectSizes(ch, i.queueDesc, queueDepths)
+}
+
+func copySizeFuncs(funcs map[string]func() int) map[string]func() int {
+
+	c := make(map[string]func() int, len(funcs))
+
+	for k, f := range funcs {
+		c[k] = f
+	}
+
+	return c
+}
+
+// collectSizes evaluates the size functions sorted by label value
+func collectSizes(ch chan<- prometheus.Metric, desc *prometheus.Desc, funcs map[string]func() int) {
+
+	labels := make([]string, 0, len(funcs))
+
+	for label := range funcs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(funcs[label]()), label)
+	}
+}
@@ -0,0 +1,230 @@
+// Package sessions keeps track of the Telemetry dial-out sessions established by the devices
+package sessions
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Period over which the message rate of a session is measured
+const rateWindow = time.Minute
+
+// Registry represents the active dial-out sessions
+type Registry struct {
+	mu       *sync.Mutex
+	sessions map[uint64]*Session
+	nextID   uint64
+}
+
+// Session represents a dial-out gRPC stream established by a device
+type Session struct {
+	mu *sync.Mutex
+
+	id          uint64
+	peer        string
+	connectedAt time.Time
+
+	nodeID        string
+	subscriptions map[string]bool
+	paths         map[string]bool
+	firstMessage  time.Time
+	lastMessage   time.Time
+	messages      uint64
+	bytes         uint64
+
+	// Messages received in the current rate window and rate of the last complete window
+	windowStart    time.Time
+	windowMessages uint64
+	rate           float64
+}
+
+// Info represents the state of a session rendered by the admin endpoints
+type Info struct {
+	ID            uint64    `json:"id"`
+	Peer          string    `json:"peer"`
+	NodeID        string    `json:"nodeId"`
+	Subscriptions []string  `json:"subscriptions"`
+	Paths         []string  `json:"paths"`
+	ConnectedAt   time.Time `json:"connectedAt"`
+	FirstMessage  time.Time `json:"firstMessage"`
+	LastMessage   time.Time `json:"lastMessage"`
+	Messages      uint64    `json:"messages"`
+	Bytes         uint64    `json:"bytes"`
+
+	// Messages per second over the last minute, or since the connection during the first minute.
+	// Zero when no message was received during the last minute
+	MessageRate float64 `json:"messageRate"`
+}
+
+// NewRegistry will create a new instance of the dial-out sessions registry
+func NewRegistry() *Registry {
+
+	return &Registry{
+		mu:       &sync.Mutex{},
+		sessions: make(map[uint64]*Session),
+	}
+}
+
+// Open registers a new session established from the peer address
+func (r *Registry) Open(peer string) *Session {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+
+	now := time.Now()
+
+	s := &Session{
+		mu:            &sync.Mutex{},
+		id:            r.nextID,
+		peer:          peer,
+		connectedAt:   now,
+		windowStart:   now,
+		subscriptions: make(map[string]bool),
+		paths:         make(map[string]bool),
+	}
+
+	r.sessions[s.id] = s
+
+	return s
+}
+
+// Close removes the session from the registry
+func (r *Registry) Close(s *Session) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, s.id)
+}
+
+// Sessions returns the state of the active sessions sorted by node and peer address
+func (r *Registry) Sessions() []Info {
+
+	r.mu.Lock()
+
+	sessions := make([]*Session, 0, len(r.sessions))
+
+	for _, s := range r.sessions {
+		sessions = append(sessions, s)
+	}
+
+	r.mu.Unlock()
+
+	infos := make([]Info, 0, len(sessions))
+
+	for _, s := range sessions {
+		infos = append(infos, s.Info())
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].NodeID != infos[j].NodeID {
+			return infos[i].NodeID < infos[j].NodeID
+		}
+		if infos[i].Peer != infos[j].Peer {
+			return infos[i].Peer < infos[j].Peer
+		}
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
+// Record updates the session with a received Telemetry message
+func (s *Session) Record(nodeID string, subscription string, path string, size int) {
+	s.recordAt(time.Now(), nodeID, subscription, path, size)
+}
+
+func (s *Session) recordAt(now time.Time, nodeID string, subscription string, path string, size int) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.messages == 0 {
+		s.firstMessage = now
+	}
+	s.lastMessage = now
+	s.messages++
+	s.bytes += uint64(size)
+
+	if elapsed := now.Sub(s.windowStart); elapsed >= rateWindow {
+		s.rate = float64(s.windowMessages) / elapsed.Seconds()
+		s.windowStart = now
+		s.windowMessages = 0
+	}
+	s.windowMessages++
+
+	if nodeID != "" {
+		s.nodeID = nodeID
+	}
+
+	if subscription != "" {
+		s.subscriptions[subscription] = true
+	}
+
+	if path != "" {
+		s.paths[path] = true
+	}
+}
+
+// Info returns the state of the session
+func (s *Session) Info() Info {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Info{
+		ID:            s.id,
+		Peer:          s.peer,
+		NodeID:        s.nodeID,
+		Subscriptions: sortedKeys(s.subscriptions),
+		Paths:         sortedKeys(s.paths),
+		ConnectedAt:   s.connectedAt,
+		FirstMessage:  s.firstMessage,
+		LastMessage:   s.lastMessage,
+		Messages:      s.messages,
+		Bytes:         s.bytes,
+		MessageRate:   s.rateAt(time.Now()),
+	}
+}
+
+// rateAt returns the message rate of the session at the given time. The rate decays to zero once no message was
+// received for a rate window, as the window is only rolled over by the messages.
+func (s *Session) rateAt(now time.Time) float64 {
+
+	if s.messages == 0 || now.Sub(s.lastMessage) >= rateWindow {
+		return 0
+	}
+
+	// Current window complete without a message since to roll it over
+	if elapsed := now.Sub(s.windowStart); elapsed >= rateWindow {
+		return float64(s.windowMessages) / elapsed.Seconds()
+	}
+
+	// No complete rate window yet
+	if s.windowStart.Equal(s.connectedAt) {
+
+		elapsed := now.Sub(s.connectedAt)
+
+		if elapsed < time.Second {
+			elapsed = time.Second
+		}
+		return float64(s.messages) / elapsed.Seconds()
+	}
+
+	return s.rate
+}
+
+func sortedKeys(m map[string]bool) []string {
+
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,67 @@
+package sessions
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionRate(t *testing.T) {
+
+	connectedAt := time.Unix(1600000000, 0)
+
+	// session returns a session with the messages received at the given offsets from the connection
+	session := func(offsets ...time.Duration) *Session {
+
+		s := &Session{
+			mu:            &sync.Mutex{},
+			connectedAt:   connectedAt,
+			windowStart:   connectedAt,
+			subscriptions: make(map[string]bool),
+			paths:         make(map[string]bool),
+		}
+
+		for _, o := range offsets {
+			s.recordAt(connectedAt.Add(o), "r1", "101", "", 100)
+		}
+
+		return s
+	}
+
+	// One message every second for 2 minutes
+	var everySecond []time.Duration
+
+	for i := 1; i <= 120; i++ {
+		everySecond = append(everySecond, time.Duration(i)*time.Second)
+	}
+
+	tests := []struct {
+		name     string
+		session  *Session
+		at       time.Duration
+		wantRate float64
+	}{
+		{name: "no message", session: session(), at: 10 * time.Second, wantRate: 0},
+		{name: "first minute", session: session(time.Second, 2*time.Second), at: 4 * time.Second, wantRate: 0.5},
+		{name: "first second", session: session(0, 0), at: 0, wantRate: 2},
+		{name: "complete window", session: session(everySecond...), at: 121 * time.Second, wantRate: 1},
+		{
+			// 31 messages in the window started at 60s, not rolled over after 120s
+			name:     "complete window not rolled over",
+			session:  session(everySecond[:90]...),
+			at:       125 * time.Second,
+			wantRate: 31.0 / 65,
+		},
+		{name: "no message for a window", session: session(everySecond...), at: 180 * time.Second, wantRate: 0},
+		{name: "idle session", session: session(time.Second), at: time.Hour, wantRate: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := tt.session.rateAt(connectedAt.Add(tt.at)); got != tt.wantRate {
+				t.Errorf("rateAt() = %v, want %v", got, tt.wantRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/health"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metadb"
+)
+
+// shutdownSignals start the graceful shutdown of the collector. Kubernetes stops the pods with SIGTERM.
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// gracefulShutdown represents the steps stopping the collector without losing the queued samples and metadata
+type gracefulShutdown struct {
+	// Readiness failed during the drain delay so traffic drains from the collector
+	readiness  *health.Checker
+	drainDelay time.Duration
+
+	// Stops the gRPC and HTTP servers
+	stopServers func()
+
+	// Cancels the background routines, the output sinks flush their queues before the WaitGroup is done
+	cancelBackground context.CancelFunc
+	outputSinks      *sync.WaitGroup
+}
+
+// wait blocks until a shutdown signal is received on ch then shuts the collector down
+func (g gracefulShutdown) wait(ch <-chan os.Signal) {
+
+	sig := <-ch
+
+	logging.PeppaMonLog("warning", "Received %v, shutting down Peppamon server...", sig)
+
+	// Fail readiness so traffic drains before the servers stop
+	g.readiness.ShutDown()
+	time.Sleep(g.drainDelay)
+
+	g.stopServers()
+
+	// Flush the samples queued in the output sinks
+	g.cancelBackground()
+	g.outputSinks.Wait()
+
+	// Flush the queued metadata writes
+	metadb.Close()
+}
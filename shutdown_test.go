@@ -0,0 +1,181 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/batch"
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/health"
+	"github.com/lucabrasi83/peppamon_cisco/metadb"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+)
+
+func TestGracefulShutdownOnSIGTERM(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "peppamon-shutdown")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctxBackground, cancelBackground := context.WithCancel(context.Background())
+	defer cancelBackground()
+
+	// Metadata writes stay queued until the shutdown flushes them
+	dbPath := filepath.Join(dir, "metadata.db")
+
+	metadb.Start(ctxBackground, config.MetaDB{
+		Backend:       metadb.BackendSQLite,
+		SQLitePath:    dbPath,
+		QueryTimeout:  10 * time.Second,
+		RetryInterval: time.Second,
+		Queue: config.MetaDBQueue{
+			Size:          10,
+			FlushSize:     10,
+			FlushInterval: time.Hour,
+			MaxRetries:    1,
+			RetryBackoff:  time.Millisecond,
+		},
+	})
+	defer metadb.Close()
+
+	if metadb.Instance() == nil {
+		t.Fatal("SQLite metadata store not opened")
+	}
+
+	license := map[string]interface{}{"node": "r1", "timestamps": time.Now().Unix(), "pid": "C9300", "sn": "FOC1"}
+
+	if err := metadb.Instance().PersistsDeviceLicenseData(license, "r1"); err != nil {
+		t.Fatalf("PersistsDeviceLicenseData() error = %v", err)
+	}
+
+	// Samples stay queued in the output sink until the shutdown drains it
+	var (
+		flushedMu sync.Mutex
+		flushed   int
+	)
+
+	sink := batch.NewQueue("shutdown_test", batch.Settings{BatchSize: 100, FlushInterval: time.Hour, QueueSize: 100},
+		func(ctx context.Context, samples []metrics.Sample) (bool, error) {
+			flushedMu.Lock()
+			flushed += len(samples)
+			flushedMu.Unlock()
+			return false, nil
+		})
+
+	outputSinks := &sync.WaitGroup{}
+	outputSinks.Add(1)
+
+	go func() {
+		defer outputSinks.Done()
+		sink.Run(ctxBackground)
+	}()
+
+	sink.Publish(&metrics.Round{Samples: []metrics.Sample{{Name: "m1"}, {Name: "m2"}, {Name: "m3"}}})
+
+	readiness := health.NewChecker()
+	readyz := httptest.NewServer(http.HandlerFunc(readiness.Handler))
+	defer readyz.Close()
+
+	if code := getStatus(t, readyz.URL); code != http.StatusOK {
+		t.Fatalf("/readyz before shutdown = %v, want %v", code, http.StatusOK)
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, shutdownSignals...)
+	defer signal.Stop(ch)
+
+	var serversStopped int32
+	done := make(chan struct{})
+
+	go func() {
+		gracefulShutdown{
+			readiness:        readiness,
+			drainDelay:       300 * time.Millisecond,
+			stopServers:      func() { atomic.StoreInt32(&serversStopped, 1) },
+			cancelBackground: cancelBackground,
+			outputSinks:      outputSinks,
+		}.wait(ch)
+		close(done)
+	}()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	// Readiness fails while the servers still run
+	deadline := time.Now().Add(2 * time.Second)
+
+	for getStatus(t, readyz.URL) != http.StatusServiceUnavailable {
+		if time.Now().After(deadline) {
+			t.Fatal("/readyz did not fail after SIGTERM")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&serversStopped) == 1 {
+		t.Error("servers stopped before the drain delay")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("shutdown did not complete")
+	}
+
+	if atomic.LoadInt32(&serversStopped) == 0 {
+		t.Error("servers not stopped on shutdown")
+	}
+
+	flushedMu.Lock()
+	defer flushedMu.Unlock()
+
+	if flushed != 3 {
+		t.Errorf("samples flushed on shutdown = %v, want 3", flushed)
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var rows int
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM device_license_meta WHERE device_id = 'r1'").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if rows != 1 {
+		t.Errorf("metadata rows flushed on shutdown = %v, want 1", rows)
+	}
+}
+
+func getStatus(t *testing.T, url string) int {
+
+	resp, err := http.Get(url)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/simulator"
+)
+
+const simulateUsage = `Usage: peppamon simulate [options]
+
+Simulate IOS-XE routers streaming synthetic kvGPB Telemetry to a Peppamon collector over MDT dial-out
+for every supported YANG path, with interface flaps, routing neighbors going down and CRC errors bursts.
+
+Options:
+`
+
+// Interval between two simulator statistics logs
+const simulateStatsInterval = time.Minute
+
+// runSimulate implements the simulate command
+func runSimulate(args []string) {
+
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+
+	client := registerDialoutClientFlags(fs, "localhost:50051", "Peppamon collector address (host:port)")
+
+	devices := fs.Int("devices", 10, "Number of simulated devices")
+	nodePrefix := fs.String("node-prefix", "sim-csr", "Node ID prefix of the simulated devices")
+	interfaces := fs.Int("interfaces", 8, "Interfaces per device. Every fourth interface is a sub-interface")
+	interval := fs.Duration("interval", 10*time.Second, "Sample interval of the subscriptions")
+	paths := fs.String("paths", "",
+		fmt.Sprintf("Comma separated paths to stream among %v. All paths when empty",
+			strings.Join(simulator.PathNames(), ", ")))
+	flapProb := fs.Float64("flap-prob", 0.005, "Probability of an interface flap per sample interval")
+	peerDownProb := fs.Float64("peer-down-prob", 0.005,
+		"Probability of a BGP, OSPF or EIGRP neighbor going down per sample interval")
+	crcBurstProb := fs.Float64("crc-burst-prob", 0.01,
+		"Probability of a CRC errors burst on a physical interface per sample interval")
+	seed := fs.Int64("seed", 1, "Seed of the simulated devices state to reproduce a run")
+	duration := fs.Duration("duration", 0, "Stop after this duration. Runs until interrupted when 0")
+
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), simulateUsage)
+		fs.PrintDefaults()
+	}
+
+	// ExitOnError flag set exits on parsing errors
+	_ = fs.Parse(args)
+
+	settings := simulator.Settings{
+		Devices:             *devices,
+		NodePrefix:          *nodePrefix,
+		Interfaces:          *interfaces,
+		Interval:            *interval,
+		FlapProbability:     *flapProb,
+		PeerDownProbability: *peerDownProb,
+		CRCBurstProbability: *crcBurstProb,
+		Seed:                *seed,
+	}
+
+	for _, p := range strings.Split(*paths, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			settings.Paths = append(settings.Paths, p)
+		}
+	}
+
+	conn, err := client.dial()
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"fatal",
+			"Failed to create gRPC connection to %v: %v", *client.target, err)
+	}
+	defer conn.Close()
+
+	sim, err := simulator.New(settings, conn)
+
+	if err != nil {
+		logging.PeppaMonLog(
+			"fatal",
+			"Invalid simulator settings %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if *duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	// Stop simulation on interrupt
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+
+	go func() {
+		select {
+		case <-ch:
+			logging.PeppaMonLog("warning", "Stopping Telemetry simulator...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(simulateStatsInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				logSimulatorStats(sim.Stats())
+			}
+		}
+	}()
+
+	logging.PeppaMonLog(
+		"info",
+		"Simulating %v device(s) with %v interface(s) streaming every %v to %v...",
+		settings.Devices, settings.Interfaces, settings.Interval, *client.target)
+
+	sim.Run(ctx)
+
+	logSimulatorStats(sim.Stats())
+}
+
+func logSimulatorStats(s simulator.Stats) {
+
+	logging.PeppaMonLog(
+		"info",
+		"Simulator sent %v message(s) over %v dial-out stream(s), %v send error(s)",
+		s.Messages, s.Connections, s.SendErrors)
+}
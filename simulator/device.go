@@ -0,0 +1,505 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Failure injection durations in sample intervals
+const (
+	minFlapIntervals     = 1
+	maxFlapIntervals     = 3
+	minPeerDownIntervals = 2
+	maxPeerDownIntervals = 6
+	minCRCBurst          = 100
+	maxCRCBurst          = 5000
+)
+
+// device represents the simulated state of a router
+type device struct {
+	name     string
+	index    int
+	rnd      *rand.Rand
+	bootTime time.Time
+
+	// Number of samples streamed so far
+	ticks uint64
+
+	interfaces []*simInterface
+	bgpPeers   []*simPeer
+	ospfNbrs   []*simPeer
+	eigrpNbrs  []*simPeer
+	slaProbes  []*simProbe
+	flows      []*simFlow
+	processes  []*simProcess
+
+	cpu5Sec, cpu1Min, cpu5Min float64
+	memTotal, memUsed         uint64
+
+	// Collection ID per YANG path
+	collectionIDs map[string]uint64
+}
+
+// simInterface represents the state and counters of an interface
+type simInterface struct {
+	name        string
+	description string
+	ifIndex     uint32
+	mac         string
+	ipv4        string
+	ipv4Mask    string
+	speed       uint64
+	mtu         uint32
+	up          bool
+	downFor     int
+	lastChange  time.Time
+
+	// Sub-interfaces stream v4-protocol-stats instead of statistics
+	subInterface bool
+
+	// Average utilization ratio of the interface speed
+	load float64
+
+	inOctets, outOctets           uint64
+	inUnicast, outUnicast         uint64
+	inMulticast, outMulticast     uint64
+	inBroadcast, outBroadcast     uint64
+	inErrors, outErrors           uint64
+	inDiscards, outDiscards       uint64
+	crcErrors, flaps              uint64
+	qosClasses                    []*simQoSClass
+	lastInRate, lastOutRate       uint64
+	lastInPktRate, lastOutPktRate uint64
+}
+
+// simQoSClass represents the counters of an output policy class
+type simQoSClass struct {
+	name                       string
+	share                      float64
+	classifiedPkts, classified uint64
+	outputPkts, output         uint64
+	dropPkts, drop             uint64
+	queueBytes                 uint64
+}
+
+// simPeer represents a BGP, OSPF or EIGRP neighbor
+type simPeer struct {
+	id      string
+	address string
+	as      uint32
+
+	// Index of the interface the neighbor is reached through
+	ifIndex int
+
+	down     bool
+	downFor  int
+	upSince  time.Time
+	prefixes uint64
+}
+
+// simProbe represents an IP SLA operation
+type simProbe struct {
+	id       uint32
+	operType string
+	target   string
+	port     uint32
+	tos      uint32
+	tag      string
+	url      string
+
+	baseRTT        uint64
+	rtt            uint64
+	success        uint32
+	failure        uint32
+	lastReturnCode string
+	lastStart      time.Time
+}
+
+// simFlow represents a Flexible NetFlow top talker record
+type simFlow struct {
+	source, destination string
+	sourcePort, dstPort uint32
+	protocol            uint32
+	tos                 string
+	ifInput, ifOutput   string
+	bytesPerSec         uint64
+	bytes, packets      uint64
+}
+
+// simProcess represents an IOSd process
+type simProcess struct {
+	pid            uint32
+	name           string
+	runTime        uint64
+	invocations    uint64
+	cpu            float64
+	allocated      uint64
+	freed          uint64
+	holdingBaseKiB uint64
+}
+
+func newDevice(index int, name string, s Settings, now time.Time) *device {
+
+	rnd := rand.New(rand.NewSource(s.Seed + int64(index)))
+
+	d := &device{
+		name:          name,
+		index:         index,
+		rnd:           rnd,
+		bootTime:      now.Add(-time.Duration(1+rnd.Intn(90*24)) * time.Hour),
+		cpu5Sec:       float64(2 + rnd.Intn(10)),
+		memTotal:      2 << 30,
+		collectionIDs: make(map[string]uint64),
+	}
+	d.cpu1Min, d.cpu5Min = d.cpu5Sec, d.cpu5Sec
+	d.memUsed = d.memTotal / 4
+
+	var parent *simInterface
+
+	for i := 0; i < s.Interfaces; i++ {
+
+		itf := &simInterface{
+			ifIndex:    uint32(i + 1),
+			mac:        fmt.Sprintf("00:1e:49:%02x:%02x:%02x", index>>8&0xff, index&0xff, i&0xff),
+			ipv4:       fmt.Sprintf("10.%d.%d.%d", index>>8&0xff, index&0xff, i*4+1),
+			ipv4Mask:   "255.255.255.252",
+			mtu:        1500,
+			speed:      1000000000,
+			up:         true,
+			lastChange: d.bootTime.Add(time.Minute),
+			load:       0.05 + rnd.Float64()*0.4,
+		}
+
+		// Every fourth interface is a dot1q sub-interface of the previous physical interface
+		if i%4 == 3 && parent != nil {
+			itf.name = fmt.Sprintf("%v.%d", parent.name, 100+i)
+			itf.description = fmt.Sprintf("VLAN %d", 100+i)
+			itf.subInterface = true
+		} else {
+			itf.name = fmt.Sprintf("GigabitEthernet0/0/%d", i)
+			itf.description = fmt.Sprintf("Uplink %d", i)
+			itf.qosClasses = []*simQoSClass{
+				{name: "VOICE", share: 0.1},
+				{name: "CRITICAL-DATA", share: 0.3},
+				{name: "class-default", share: 0.6},
+			}
+			parent = itf
+		}
+
+		d.interfaces = append(d.interfaces, itf)
+	}
+
+	// One BGP, OSPF and EIGRP neighbor per interface up to 4 neighbors each
+	for i := 0; i < len(d.interfaces) && i < 4; i++ {
+
+		peerIP := fmt.Sprintf("10.%d.%d.%d", index>>8&0xff, index&0xff, i*4+2)
+
+		d.bgpPeers = append(d.bgpPeers, &simPeer{
+			id:       peerIP,
+			as:       uint32(65100 + i),
+			ifIndex:  i,
+			upSince:  d.bootTime.Add(2 * time.Minute),
+			prefixes: uint64(100 + rnd.Intn(5000)),
+		})
+
+		d.ospfNbrs = append(d.ospfNbrs, &simPeer{
+			id:      fmt.Sprintf("10.255.%d.%d", i, index&0xff),
+			address: peerIP,
+			ifIndex: i,
+			upSince: d.bootTime.Add(time.Minute),
+		})
+
+		d.eigrpNbrs = append(d.eigrpNbrs, &simPeer{
+			id:      peerIP,
+			as:      100,
+			ifIndex: i,
+			upSince: d.bootTime.Add(time.Minute),
+		})
+	}
+
+	d.slaProbes = []*simProbe{
+		{id: 10, operType: "oper-type-udp-jitter", target: "192.0.2.10", port: 16384, tos: 184,
+			tag: "COS1_VOICE_sbc.example.net:5060", baseRTT: 20},
+		{id: 20, operType: "oper-type-http", target: "192.0.2.20", port: 80, tos: 0,
+			tag: "COS3_WEB_portal.example.net:80", url: "http://portal.example.net/health", baseRTT: 80},
+		{id: 30, operType: "oper-type-icmp-echo", target: "192.0.2.30", tos: 0,
+			tag: "COS2_DATA_dc.example.net:0", baseRTT: 10},
+	}
+
+	if len(d.interfaces) > 1 {
+
+		talkers := []struct {
+			src, dst       string
+			sport, dport   uint32
+			protocol       uint32
+			tos            string
+			bytesPerSecond uint64
+		}{
+			{"172.16.1.10", "198.51.100.5", 49152, 443, 6, "0x00", 250000},
+			{"172.16.1.11", "198.51.100.6", 16384, 16384, 17, "0xb8", 12000},
+			{"172.16.1.12", "198.51.100.7", 51000, 22, 6, "0x48", 4000},
+			{"172.16.1.13", "198.51.100.8", 53000, 53, 17, "0x00", 800},
+			{"172.16.1.14", "198.51.100.9", 60000, 445, 6, "0x28", 90000},
+		}
+
+		for _, tk := range talkers {
+			d.flows = append(d.flows, &simFlow{
+				source:      tk.src,
+				destination: tk.dst,
+				sourcePort:  tk.sport,
+				dstPort:     tk.dport,
+				protocol:    tk.protocol,
+				tos:         tk.tos,
+				ifInput:     d.interfaces[1].name,
+				ifOutput:    d.interfaces[0].name,
+				bytesPerSec: tk.bytesPerSecond,
+			})
+		}
+	}
+
+	for i, name := range []string{"Chunk Manager", "Load Meter", "Check heaps", "IP Input", "BGP Router",
+		"OSPF-1 Hello", "EIGRP-IPv4", "SNMP ENGINE", "NETCONF", "MDT Publisher"} {
+
+		d.processes = append(d.processes, &simProcess{
+			pid:            uint32(i + 1),
+			name:           name,
+			holdingBaseKiB: uint64(50 + rnd.Intn(5000)),
+		})
+	}
+
+	return d
+}
+
+// step advances the device counters by an interval and injects the failures
+func (d *device) step(s Settings, interval time.Duration, now time.Time) {
+
+	d.ticks++
+
+	seconds := interval.Seconds()
+
+	for _, itf := range d.interfaces {
+		d.stepInterface(itf, s, seconds, now)
+	}
+
+	for _, p := range d.bgpPeers {
+		d.stepPeer(p, s, now)
+
+		if !p.down && !d.interfaces[p.ifIndex].isDown() {
+			p.prefixes = walkUint(d.rnd, p.prefixes, 5, 10)
+		}
+	}
+
+	for _, p := range d.ospfNbrs {
+		d.stepPeer(p, s, now)
+	}
+
+	for _, p := range d.eigrpNbrs {
+		d.stepPeer(p, s, now)
+	}
+
+	for _, probe := range d.slaProbes {
+		d.stepProbe(probe, now)
+	}
+
+	for _, f := range d.flows {
+		bytes := uint64(float64(f.bytesPerSec) * seconds * (0.5 + d.rnd.Float64()))
+		f.bytes += bytes
+		f.packets += bytes/512 + 1
+	}
+
+	var busy float64
+
+	for _, p := range d.processes {
+		p.cpu = d.rnd.Float64() * 2
+		p.invocations += uint64(d.rnd.Intn(1000))
+		p.runTime += uint64(p.cpu * seconds * 10)
+		p.allocated += uint64(d.rnd.Intn(100000))
+		p.freed = p.allocated - p.allocated/10
+		busy += p.cpu
+	}
+
+	// CPU averages follow the 5 seconds load like IOS does
+	d.cpu5Sec = clamp(busy+float64(d.rnd.Intn(15)), 1, 100)
+	d.cpu1Min = d.cpu1Min + (d.cpu5Sec-d.cpu1Min)*clamp(seconds/60, 0, 1)
+	d.cpu5Min = d.cpu5Min + (d.cpu5Sec-d.cpu5Min)*clamp(seconds/300, 0, 1)
+
+	d.memUsed = clampUint(walkUint(d.rnd, d.memUsed, 1, 1000), d.memTotal/8, d.memTotal-d.memTotal/16)
+}
+
+func (d *device) stepInterface(itf *simInterface, s Settings, seconds float64, now time.Time) {
+
+	if itf.downFor > 0 {
+
+		itf.downFor--
+
+		if itf.downFor == 0 {
+			itf.up = true
+			itf.lastChange = now
+		}
+	} else if d.rnd.Float64() < s.FlapProbability {
+
+		itf.up = false
+		itf.downFor = minFlapIntervals + d.rnd.Intn(maxFlapIntervals-minFlapIntervals+1)
+		itf.lastChange = now
+		itf.flaps++
+	}
+
+	if !itf.up {
+		itf.lastInRate, itf.lastOutRate, itf.lastInPktRate, itf.lastOutPktRate = 0, 0, 0, 0
+		return
+	}
+
+	// Utilization wanders around the interface average load
+	ratio := clamp(itf.load*(0.6+0.8*d.rnd.Float64()), 0, 1)
+
+	inBytes := uint64(float64(itf.speed) / 8 * ratio * seconds)
+	outBytes := uint64(float64(inBytes) * (0.3 + 0.5*d.rnd.Float64()))
+
+	inPkts := inBytes/700 + 1
+	outPkts := outBytes/700 + 1
+
+	itf.inOctets += inBytes
+	itf.outOctets += outBytes
+	itf.inUnicast += inPkts - inPkts/100
+	itf.outUnicast += outPkts - outPkts/100
+	itf.inMulticast += inPkts / 200
+	itf.outMulticast += outPkts / 200
+	itf.inBroadcast += inPkts / 200
+	itf.outBroadcast += outPkts / 200
+
+	if d.rnd.Intn(20) == 0 {
+		itf.inDiscards += uint64(d.rnd.Intn(10))
+		itf.outDiscards += uint64(d.rnd.Intn(10))
+	}
+
+	if !itf.subInterface && d.rnd.Float64() < s.CRCBurstProbability {
+
+		burst := uint64(minCRCBurst + d.rnd.Intn(maxCRCBurst-minCRCBurst+1))
+		itf.crcErrors += burst
+		itf.inErrors += burst
+	}
+
+	itf.lastInRate = uint64(float64(inBytes) * 8 / seconds)
+	itf.lastOutRate = uint64(float64(outBytes) * 8 / seconds)
+	itf.lastInPktRate = uint64(float64(inPkts) / seconds)
+	itf.lastOutPktRate = uint64(float64(outPkts) / seconds)
+
+	for _, c := range itf.qosClasses {
+
+		bytes := uint64(float64(outBytes) * c.share)
+		pkts := bytes/700 + 1
+
+		c.classified += bytes
+		c.classifiedPkts += pkts
+
+		// Congestion drops in the default class
+		var dropped uint64
+
+		if c.name == "class-default" && ratio > 0.3 {
+			dropped = bytes / 50
+		}
+
+		c.drop += dropped
+		c.dropPkts += dropped / 700
+		c.output += bytes - dropped
+		c.outputPkts += pkts - dropped/700
+		c.queueBytes = uint64(d.rnd.Intn(64000))
+	}
+}
+
+func (itf *simInterface) isDown() bool {
+	return !itf.up
+}
+
+func (d *device) stepPeer(p *simPeer, s Settings, now time.Time) {
+
+	wasDown := p.down || d.interfaces[p.ifIndex].isDown()
+
+	if p.downFor > 0 {
+
+		p.downFor--
+
+		if p.downFor == 0 {
+			p.down = false
+		}
+	} else if d.rnd.Float64() < s.PeerDownProbability {
+
+		p.down = true
+		p.downFor = minPeerDownIntervals + d.rnd.Intn(maxPeerDownIntervals-minPeerDownIntervals+1)
+	}
+
+	// Adjacency resets when the neighbor or its interface recover
+	if wasDown && !p.down && !d.interfaces[p.ifIndex].isDown() {
+		p.upSince = now
+	}
+}
+
+// peerUp returns whether a neighbor is reachable
+func (d *device) peerUp(p *simPeer) bool {
+	return !p.down && !d.interfaces[p.ifIndex].isDown()
+}
+
+func (d *device) stepProbe(p *simProbe, now time.Time) {
+
+	p.lastStart = now
+
+	// Probes are sourced from the first interface
+	if len(d.interfaces) > 0 && d.interfaces[0].isDown() {
+		p.failure++
+		p.lastReturnCode = "ret-code-timeout"
+		p.rtt = 0
+		return
+	}
+
+	p.success++
+	p.lastReturnCode = "ret-code-ok"
+	p.rtt = p.baseRTT/2 + uint64(d.rnd.Int63n(int64(p.baseRTT)+1))
+}
+
+// nextCollectionID returns the collection ID of the next message of a YANG path
+func (d *device) nextCollectionID(path string) uint64 {
+
+	d.collectionIDs[path]++
+
+	return d.collectionIDs[path]
+}
+
+// walkUint moves a value randomly by up to perMille / 1000 of it, plus or minus abs
+func walkUint(rnd *rand.Rand, v uint64, perMille int64, abs int64) uint64 {
+
+	delta := int64(v)*perMille/1000 + abs
+	next := int64(v) + rnd.Int63n(2*delta+1) - delta
+
+	if next < 0 {
+		return 0
+	}
+
+	return uint64(next)
+}
+
+func clamp(v float64, min float64, max float64) float64 {
+
+	if v < min {
+		return min
+	}
+
+	if v > max {
+		return max
+	}
+
+	return v
+}
+
+func clampUint(v uint64, min uint64, max uint64) uint64 {
+
+	if v < min {
+		return min
+	}
+
+	if v > max {
+		return max
+	}
+
+	return v
+}
@@ -0,0 +1,55 @@
+package simulator
+
+import (
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+// Helpers building the kvGPB fields the IOS-XE devices stream. YANG uint8/uint16/uint32 leafs are encoded as
+// uint32 values, uint64 and counter64 leafs as uint64 values, enumerations and yang:date-and-time as strings.
+
+func str(name string, v string) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_StringValue{StringValue: v}}
+}
+
+func u32(name string, v uint32) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_Uint32Value{Uint32Value: v}}
+}
+
+func u64(name string, v uint64) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_Uint64Value{Uint64Value: v}}
+}
+
+func dbl(name string, v float64) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_DoubleValue{DoubleValue: v}}
+}
+
+func boolean(name string, v bool) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, ValueByType: &telemetry.TelemetryField_BoolValue{BoolValue: v}}
+}
+
+func container(name string, fields ...*telemetry.TelemetryField) *telemetry.TelemetryField {
+	return &telemetry.TelemetryField{Name: name, Fields: fields}
+}
+
+// row returns a kvGPB row made of the keys and content containers
+func row(t time.Time, keys []*telemetry.TelemetryField, content []*telemetry.TelemetryField) *telemetry.TelemetryField {
+
+	return &telemetry.TelemetryField{
+		Timestamp: msTimestamp(t),
+		Fields: []*telemetry.TelemetryField{
+			container("keys", keys...),
+			container("content", content...),
+		},
+	}
+}
+
+func msTimestamp(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(time.Millisecond))
+}
+
+// dateAndTime formats a time as the IOS-XE yang:date-and-time leafs
+func dateAndTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000-07:00")
+}
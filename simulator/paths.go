@@ -0,0 +1,657 @@
+package simulator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/proto/telemetry"
+)
+
+// YANG encoding paths streamed by the simulator. They match the paths of the metrics parsers
+const (
+	interfacesPath      = "Cisco-IOS-XE-interfaces-oper:interfaces/interface"
+	bgpPath             = "Cisco-IOS-XE-bgp-oper:bgp-state-data/address-families/address-family"
+	ospfPath            = "Cisco-IOS-XE-ospf-oper:ospf-oper-data/ospfv2-instance/ospfv2-area/ospfv2-interface/ospfv2-neighbor"
+	eigrpPath           = "Cisco-IOS-XE-eigrp-oper:eigrp-oper-data/eigrp-instance/eigrp-interface/eigrp-nbr"
+	ipSLAPath           = "Cisco-IOS-XE-ip-sla-oper:ip-sla-stats/sla-oper-entry"
+	ipSLAConfigPath     = "Cisco-IOS-XE-native:native/ip/Cisco-IOS-XE-sla:sla/entry"
+	flowMonitorPath     = "Cisco-IOS-XE-flow-monitor-oper:flow-monitors/flow-monitor"
+	hardwarePath        = "Cisco-IOS-XE-device-hardware-oper:device-hardware-data"
+	licensePath         = "Cisco-IOS-XE-native:native/license"
+	cpuPath             = "Cisco-IOS-XE-process-cpu-oper:cpu-usage/cpu-utilization"
+	memoryPath          = "Cisco-IOS-XE-memory-oper:memory-statistics/memory-statistic"
+	memoryProcessesPath = "Cisco-IOS-XE-process-memory-oper:memory-usage-processes/memory-usage-process"
+)
+
+// Configuration and inventory paths are streamed every metadataEvery samples like on-change subscriptions
+const metadataEvery = 10
+
+// pathBuilder builds the kvGPB rows of a YANG path from the device state
+type pathBuilder struct {
+	path     string
+	metadata bool
+	rows     func(d *device, now time.Time) []*telemetry.TelemetryField
+}
+
+// Paths maps the names accepted by Settings.Paths to the YANG paths the simulator streams
+var Paths = map[string]string{
+	"interfaces":       interfacesPath,
+	"bgp":              bgpPath,
+	"ospf":             ospfPath,
+	"eigrp":            eigrpPath,
+	"ip-sla":           ipSLAPath,
+	"ip-sla-config":    ipSLAConfigPath,
+	"flow-monitor":     flowMonitorPath,
+	"hardware":         hardwarePath,
+	"license":          licensePath,
+	"cpu":              cpuPath,
+	"memory":           memoryPath,
+	"memory-processes": memoryProcessesPath,
+}
+
+var builders = []pathBuilder{
+	{path: interfacesPath, rows: interfaceRows},
+	{path: bgpPath, rows: bgpRows},
+	{path: ospfPath, rows: ospfRows},
+	{path: eigrpPath, rows: eigrpRows},
+	{path: ipSLAPath, rows: ipSLARows},
+	{path: ipSLAConfigPath, metadata: true, rows: ipSLAConfigRows},
+	{path: flowMonitorPath, rows: flowMonitorRows},
+	{path: hardwarePath, metadata: true, rows: hardwareRows},
+	{path: licensePath, metadata: true, rows: licenseRows},
+	{path: cpuPath, rows: cpuRows},
+	{path: memoryPath, rows: memoryRows},
+	{path: memoryProcessesPath, rows: memoryProcessesRows},
+}
+
+// messages returns the Telemetry messages of the selected YANG paths due for the current sample
+func (d *device) messages(paths map[string]bool, now time.Time) []*telemetry.Telemetry {
+
+	var msgs []*telemetry.Telemetry
+
+	for _, b := range builders {
+
+		if !paths[b.path] || (b.metadata && (d.ticks-1)%metadataEvery != 0) {
+			continue
+		}
+
+		ts := msTimestamp(now)
+
+		msgs = append(msgs, &telemetry.Telemetry{
+			NodeId:              &telemetry.Telemetry_NodeIdStr{NodeIdStr: d.name},
+			Subscription:        &telemetry.Telemetry_SubscriptionIdStr{SubscriptionIdStr: fmt.Sprint(101 + len(msgs))},
+			EncodingPath:        b.path,
+			CollectionId:        d.nextCollectionID(b.path),
+			CollectionStartTime: ts,
+			MsgTimestamp:        ts,
+			DataGpbkv:           b.rows(d, now),
+			CollectionEndTime:   ts,
+		})
+	}
+
+	return msgs
+}
+
+func interfaceRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	rows := make([]*telemetry.TelemetryField, 0, len(d.interfaces))
+
+	for _, itf := range d.interfaces {
+
+		operStatus := "if-oper-state-ready"
+
+		if !itf.up {
+			operStatus = "if-oper-state-lower-layer-down"
+		}
+
+		content := []*telemetry.TelemetryField{
+			str("name", itf.name),
+			str("interface-type", "iana-iftype-ethernet-csmacd"),
+			str("admin-status", "if-state-up"),
+			str("oper-status", operStatus),
+			str("last-change", dateAndTime(itf.lastChange)),
+			u32("if-index", itf.ifIndex),
+			str("phys-address", itf.mac),
+			u64("speed", itf.speed),
+			u32("mtu", itf.mtu),
+			str("vrf", ""),
+			str("ipv4", itf.ipv4),
+			str("ipv4-subnet-mask", itf.ipv4Mask),
+			str("description", itf.description),
+		}
+
+		if itf.subInterface {
+			content = append(content, container("v4-protocol-stats",
+				u64("in-pkts", itf.inUnicast+itf.inMulticast+itf.inBroadcast),
+				u64("in-octets", itf.inOctets),
+				u64("in-error-pkts", itf.inErrors),
+				u64("in-forwarded-pkts", itf.inUnicast),
+				u64("in-forwarded-octets", itf.inOctets),
+				u64("in-discarded-pkts", itf.inDiscards),
+				u64("out-pkts", itf.outUnicast+itf.outMulticast+itf.outBroadcast),
+				u64("out-octets", itf.outOctets),
+				u64("out-error-pkts", itf.outErrors),
+				u64("out-forwarded-pkts", itf.outUnicast),
+				u64("out-forwarded-octets", itf.outOctets),
+				u64("out-discarded-pkts", itf.outDiscards),
+			))
+		} else {
+			content = append(content, container("statistics",
+				str("discontinuity-time", dateAndTime(d.bootTime)),
+				u64("in-octets", itf.inOctets),
+				u64("in-unicast-pkts", itf.inUnicast),
+				u64("in-broadcast-pkts", itf.inBroadcast),
+				u64("in-multicast-pkts", itf.inMulticast),
+				u32("in-discards", uint32(itf.inDiscards)),
+				u32("in-errors", uint32(itf.inErrors)),
+				u32("in-unknown-protos", 0),
+				u64("out-octets", itf.outOctets),
+				u64("out-unicast-pkts", itf.outUnicast),
+				u64("out-broadcast-pkts", itf.outBroadcast),
+				u64("out-multicast-pkts", itf.outMulticast),
+				u64("out-discards", itf.outDiscards),
+				u64("out-errors", itf.outErrors),
+				u64("rx-pps", itf.lastInPktRate),
+				u64("rx-kbps", itf.lastInRate/1000),
+				u64("tx-pps", itf.lastOutPktRate),
+				u64("tx-kbps", itf.lastOutRate/1000),
+				u64("num-flaps", itf.flaps),
+				u64("in-crc-errors", itf.crcErrors),
+			))
+
+			if len(itf.qosClasses) > 0 {
+				content = append(content, diffservInfo(itf))
+			}
+		}
+
+		rows = append(rows, row(now, []*telemetry.TelemetryField{str("name", itf.name)}, content))
+	}
+
+	return rows
+}
+
+func diffservInfo(itf *simInterface) *telemetry.TelemetryField {
+
+	fields := []*telemetry.TelemetryField{
+		str("direction", "outbound"),
+		str("policy-name", "WAN-EDGE-OUT"),
+	}
+
+	for _, c := range itf.qosClasses {
+		fields = append(fields, container("diffserv-target-classifier-stats",
+			str("classifier-entry-name", c.name),
+			str("parent-path", "WAN-EDGE-OUT "+c.name),
+			container("classifier-entry-stats",
+				u64("classified-pkts", c.classifiedPkts),
+				u64("classified-bytes", c.classified),
+				u64("classified-rate", 0),
+			),
+			container("queuing-stats",
+				u64("output-pkts", c.outputPkts),
+				u64("output-bytes", c.output),
+				u64("queue-size-pkts", c.queueBytes/700),
+				u64("queue-size-bytes", c.queueBytes),
+				u64("drop-pkts", c.dropPkts),
+				u64("drop-bytes", c.drop),
+			),
+		))
+	}
+
+	return container("diffserv-info", fields...)
+}
+
+func bgpRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	var neighbors []*telemetry.TelemetryField
+	var totalPrefixes uint64
+
+	for _, p := range d.bgpPeers {
+
+		state, upTime, prefixes := "fsm-established", upTimeString(now.Sub(p.upSince)), p.prefixes
+
+		if !d.peerUp(p) {
+			state, upTime, prefixes = "fsm-active", "never", 0
+		}
+		totalPrefixes += prefixes
+
+		neighbors = append(neighbors, container("bgp-neighbor-summary",
+			str("id", p.id),
+			u32("bgp-version", 4),
+			u64("messages-received", d.ticks*3),
+			u64("messages-sent", d.ticks*3),
+			u64("table-version", d.ticks),
+			u64("input-queue", 0),
+			u64("output-queue", 0),
+			str("up-time", upTime),
+			str("state", state),
+			u64("prefixes-received", prefixes),
+			boolean("dynamically-configured", false),
+			u32("as", p.as),
+		))
+	}
+
+	content := []*telemetry.TelemetryField{
+		str("afi-safi", "ipv4-unicast"),
+		str("vrf-name", "default"),
+		str("router-id", fmt.Sprintf("10.255.255.%d", d.index%250+1)),
+		u64("bgp-table-version", d.ticks),
+		u64("routing-table-version", d.ticks),
+		container("prefixes", u64("total-entries", totalPrefixes), u64("memory-usage", totalPrefixes*120)),
+		container("path", u64("total-entries", totalPrefixes), u64("memory-usage", totalPrefixes*100)),
+		u32("local-as", 65000),
+	}
+	content = append(content, neighbors...)
+
+	keys := []*telemetry.TelemetryField{str("afi-safi", "ipv4-unicast"), str("vrf-name", "default")}
+
+	return []*telemetry.TelemetryField{row(now, keys, content)}
+}
+
+func ospfRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	var rows []*telemetry.TelemetryField
+
+	for _, p := range d.ospfNbrs {
+
+		state := "ospf-nbr-full"
+
+		if !d.peerUp(p) {
+			state = "ospf-nbr-down"
+		}
+
+		nbrID := ipToUint32(p.id)
+
+		keys := []*telemetry.TelemetryField{
+			u32("instance-id", 1),
+			u32("area-id", 0),
+			str("name", d.interfaces[p.ifIndex].name),
+			u32("nbr-id", nbrID),
+		}
+
+		rows = append(rows, row(now, keys, []*telemetry.TelemetryField{
+			u32("nbr-id", nbrID),
+			str("address", p.address),
+			u32("dr", 0),
+			u32("bdr", 0),
+			str("state", state),
+		}))
+	}
+
+	return rows
+}
+
+func eigrpRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	var rows []*telemetry.TelemetryField
+
+	for _, p := range d.eigrpNbrs {
+
+		// Neighbors lost are removed from the EIGRP neighbor table
+		if !d.peerUp(p) {
+			continue
+		}
+
+		ifName := d.interfaces[p.ifIndex].name
+
+		keys := []*telemetry.TelemetryField{
+			str("afi", "eigrp-af-ipv4"),
+			str("vrf-name", ""),
+			u32("as-num", p.as),
+			str("name", ifName),
+			str("nbr-address", p.id),
+		}
+
+		rows = append(rows, row(now, keys, []*telemetry.TelemetryField{
+			str("nbr-address", p.id),
+			str("interface-name", ifName),
+			u32("hold-time", 12),
+			u64("uptime", uint64(now.Sub(p.upSince).Seconds())),
+			u32("srtt", 1),
+			u32("rto", 100),
+			u32("qcount", 0),
+		}))
+	}
+
+	return rows
+}
+
+func ipSLARows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	rows := make([]*telemetry.TelemetryField, 0, len(d.slaProbes))
+
+	for _, p := range d.slaProbes {
+
+		content := []*telemetry.TelemetryField{
+			u32("oper-id", p.id),
+			str("oper-type", p.operType),
+			str("latest-return-code", p.lastReturnCode),
+			u32("success-count", p.success),
+			u32("failure-count", p.failure),
+			str("latest-oper-start-time", dateAndTime(p.lastStart)),
+			container("rtt-info",
+				container("latest-rtt", u64("rtt", p.rtt)),
+			),
+		}
+
+		switch p.operType {
+		case "oper-type-udp-jitter":
+			content = append(content, container("stats",
+				container("oneway-latency",
+					u32("sample-count", 100),
+					minAvgMax("sd", p.rtt/2, d),
+					minAvgMax("ds", p.rtt/2, d),
+				),
+				container("jitter",
+					u32("sample-count", 100),
+					minAvgMax("sd", p.rtt/10, d),
+					minAvgMax("ds", p.rtt/10, d),
+				),
+				container("packet-loss",
+					container("sd-loss", u32("loss-period-count", lossCount(p, d))),
+					container("ds-loss", u32("loss-period-count", lossCount(p, d))),
+				),
+			))
+
+		case "oper-type-http":
+			statusCode := uint32(200)
+
+			if p.lastReturnCode != "ret-code-ok" {
+				statusCode = 0
+			}
+
+			content = append(content, container("stats",
+				container("http-specific-stats",
+					container("http-stats",
+						u32("status-code", statusCode),
+						u32("dns-rtt", uint32(p.rtt/8)),
+						u32("tcp-rtt", uint32(p.rtt/4)),
+						u32("transaction-rtt", uint32(p.rtt)),
+					),
+					container("http-errors",
+						u32("transaction-error", 0),
+						u32("tcp-error", 0),
+						u32("dns-error", 0),
+						u32("transaction-timeout", p.failure),
+						u32("tcp-timeout", 0),
+						u32("dns-timeout", 0),
+					),
+				),
+			))
+		}
+
+		rows = append(rows, row(now, []*telemetry.TelemetryField{u32("oper-id", p.id)}, content))
+	}
+
+	return rows
+}
+
+func minAvgMax(name string, avg uint64, d *device) *telemetry.TelemetryField {
+
+	spread := avg/2 + 1
+
+	return container(name,
+		u32("min", uint32(avg-avg/2)),
+		u32("avg", uint32(avg)),
+		u32("max", uint32(avg+uint64(d.rnd.Int63n(int64(spread))))),
+	)
+}
+
+func lossCount(p *simProbe, d *device) uint32 {
+
+	if p.lastReturnCode != "ret-code-ok" {
+		return 100
+	}
+
+	return uint32(d.rnd.Intn(2))
+}
+
+func ipSLAConfigRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	rows := make([]*telemetry.TelemetryField, 0, len(d.slaProbes))
+
+	sourceIP := "0.0.0.0"
+
+	if len(d.interfaces) > 0 {
+		sourceIP = d.interfaces[0].ipv4
+	}
+
+	for _, p := range d.slaProbes {
+
+		var operation *telemetry.TelemetryField
+
+		switch p.operType {
+		case "oper-type-udp-jitter":
+			operation = container("udp-jitter",
+				str("dest-addr", p.target),
+				u32("portno", p.port),
+				str("source-ip", sourceIP),
+				u32("source-port", 16400),
+				u32("tos", p.tos),
+				u32("frequency", 60),
+				str("tag", p.tag),
+			)
+
+		case "oper-type-http":
+			operation = container("http",
+				container("get",
+					str("url", p.url),
+					str("source-ip", sourceIP),
+					str("name-server", "192.0.2.53"),
+					str("version", "1.1"),
+				),
+				u32("frequency", 60),
+				str("tag", p.tag),
+			)
+
+		default:
+			operation = container("icmp-echo",
+				str("destination", p.target),
+				str("source-ip", sourceIP),
+				u32("request-data-size", 64),
+				u32("tos", p.tos),
+				u32("frequency", 30),
+				str("tag", p.tag),
+			)
+		}
+
+		rows = append(rows, row(now, []*telemetry.TelemetryField{u32("number", p.id)}, []*telemetry.TelemetryField{operation}))
+	}
+
+	return rows
+}
+
+func flowMonitorRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	if len(d.flows) == 0 {
+		return nil
+	}
+
+	content := []*telemetry.TelemetryField{
+		str("name", "FNF-TOP-TALKERS"),
+		str("time-collected", dateAndTime(now)),
+	}
+
+	for _, f := range d.flows {
+		content = append(content, container("flow",
+			str("source-address", f.source),
+			str("destination-address", f.destination),
+			str("interface-input", f.ifInput),
+			str("is-multicast", "no"),
+			u32("vrf-id-input", 0),
+			u32("source-port", f.sourcePort),
+			u32("destination-port", f.dstPort),
+			str("ip-tos", f.tos),
+			u32("ip-protocol", f.protocol),
+			str("interface-output", f.ifOutput),
+			u64("bytes", f.bytes),
+			u64("packets", f.packets),
+		))
+	}
+
+	return []*telemetry.TelemetryField{
+		row(now, []*telemetry.TelemetryField{str("name", "FNF-TOP-TALKERS")}, content),
+	}
+}
+
+func hardwareRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	serial := fmt.Sprintf("FDO%08d", 21000000+d.index)
+
+	inventory := []struct {
+		hwType, name, description, partNumber string
+		fru                                   bool
+	}{
+		{"hw-type-chassis", "Chassis", "Cisco ISR4331 Chassis", "ISR4331/K9", false},
+		{"hw-type-pem", "Power Supply Module 0", "250W AC Power Supply for Cisco ISR 4330", "PWR-4330-AC", true},
+		{"hw-type-fan", "Fan Tray", "Cisco ISR4330 Fan Assembly", "ACS-4330-FANASSY", true},
+		{"hw-type-module", "module 0", "Cisco ISR4331 Built-In NIM controller", "ISR4331/K9", false},
+		{"hw-type-dimm", "DIMM 0", "4GB DRAM", "MEM-4300-4G", true},
+	}
+
+	var hw []*telemetry.TelemetryField
+
+	for i, item := range inventory {
+		hw = append(hw, container("device-inventory",
+			str("hw-type", item.hwType),
+			u32("hw-dev-index", uint32(i)),
+			str("version", "V04"),
+			str("part-number", item.partNumber),
+			str("serial-number", fmt.Sprintf("%v%02d", serial, i)),
+			str("hw-description", item.description),
+			str("dev-name", item.name),
+			boolean("field-replaceable", item.fru),
+		))
+	}
+
+	hw = append(hw, container("device-system-data",
+		str("current-time", dateAndTime(now)),
+		str("boot-time", dateAndTime(d.bootTime)),
+		str("software-version", "Cisco IOS Software [Gibraltar], ISR Software (X86_64_LINUX_IOSD-UNIVERSALK9-M), "+
+			"Version 16.12.3, RELEASE SOFTWARE (fc5)"),
+		str("rommon-version", "16.9(1r)"),
+		u64("last-reboot-reason", 0),
+	))
+
+	content := []*telemetry.TelemetryField{container("device-hardware", hw...)}
+
+	return []*telemetry.TelemetryField{row(now, nil, content)}
+}
+
+func licenseRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	content := []*telemetry.TelemetryField{
+		container("udi",
+			str("pid", "ISR4331/K9"),
+			str("sn", fmt.Sprintf("FDO%08d", 21000000+d.index)),
+		),
+		container("boot",
+			container("level",
+				container("appxk9", str("addon", "")),
+			),
+		),
+	}
+
+	return []*telemetry.TelemetryField{row(now, nil, content)}
+}
+
+func cpuRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	content := []*telemetry.TelemetryField{
+		u32("five-seconds", uint32(d.cpu5Sec)),
+		u32("five-seconds-intr", uint32(d.cpu5Sec/10)),
+		u32("one-minute", uint32(d.cpu1Min)),
+		u32("five-minutes", uint32(d.cpu5Min)),
+	}
+
+	for _, p := range d.processes {
+		content = append(content, container("cpu-usage-process",
+			u32("pid", p.pid),
+			str("name", p.name),
+			u64("total-run-time", p.runTime),
+			u64("invocation-count", p.invocations),
+			u64("avg-run-time", p.runTime/(p.invocations+1)),
+			dbl("five-seconds", p.cpu),
+			dbl("one-minute", p.cpu),
+			dbl("five-minutes", p.cpu),
+			u32("tty", 0),
+		))
+	}
+
+	return []*telemetry.TelemetryField{row(now, nil, content)}
+}
+
+func memoryRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	ioTotal := d.memTotal / 16
+	ioUsed := ioTotal / 3
+
+	return []*telemetry.TelemetryField{
+		row(now, []*telemetry.TelemetryField{str("name", "Processor")}, []*telemetry.TelemetryField{
+			str("name", "Processor"),
+			u64("total-memory", d.memTotal),
+			u64("used-memory", d.memUsed),
+			u64("free-memory", d.memTotal-d.memUsed),
+			u64("lowest-usage", d.memTotal-d.memUsed),
+			u64("highest-usage", d.memTotal-d.memUsed),
+		}),
+		row(now, []*telemetry.TelemetryField{str("name", "lsmpi_io")}, []*telemetry.TelemetryField{
+			str("name", "lsmpi_io"),
+			u64("total-memory", ioTotal),
+			u64("used-memory", ioUsed),
+			u64("free-memory", ioTotal-ioUsed),
+			u64("lowest-usage", ioTotal-ioUsed),
+			u64("highest-usage", ioTotal-ioUsed),
+		}),
+	}
+}
+
+func memoryProcessesRows(d *device, now time.Time) []*telemetry.TelemetryField {
+
+	rows := make([]*telemetry.TelemetryField, 0, len(d.processes))
+
+	for _, p := range d.processes {
+
+		keys := []*telemetry.TelemetryField{u32("pid", p.pid), str("name", p.name)}
+
+		rows = append(rows, row(now, keys, []*telemetry.TelemetryField{
+			u32("pid", p.pid),
+			str("name", p.name),
+			u32("tty", 0),
+			u64("allocated-memory", p.allocated),
+			u64("freed-memory", p.freed),
+			u64("holding-memory", p.holdingBaseKiB*1024),
+			u64("get-buffers", 0),
+			u64("ret-buffers", 0),
+		}))
+	}
+
+	return rows
+}
+
+// upTimeString formats a BGP session uptime like the IOS show commands
+func upTimeString(d time.Duration) string {
+
+	switch {
+	case d < 24*time.Hour:
+		secs := int(d.Seconds())
+		return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+
+	days := int(d.Hours()) / 24
+
+	return fmt.Sprintf("%dw%dd", days/7, days%7)
+}
+
+func ipToUint32(ip string) uint32 {
+
+	var b [4]uint32
+
+	if _, err := fmt.Sscanf(strings.TrimSpace(ip), "%d.%d.%d.%d", &b[0], &b[1], &b[2], &b[3]); err != nil {
+		return 0
+	}
+
+	return b[0]<<24 | b[1]<<16 | b[2]<<8 | b[3]
+}
@@ -0,0 +1,227 @@
+// Package simulator emulates IOS-XE routers streaming synthetic kvGPB Telemetry over MDT dial-out
+// for parser development, load tests and demos
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	mdt_dialout "github.com/lucabrasi83/peppamon_cisco/proto/mdt_grpc_dialout"
+	"google.golang.org/grpc"
+)
+
+// Settings represents the simulated devices settings
+type Settings struct {
+	// Number of simulated devices
+	Devices int
+
+	// Node ID prefix of the devices. i.e. sim-csr becomes sim-csr-0001
+	NodePrefix string
+
+	// Interfaces per device. Every fourth interface is a sub-interface
+	Interfaces int
+
+	// Sample interval of the subscriptions
+	Interval time.Duration
+
+	// Names of the streamed paths (see Paths). All paths when empty
+	Paths []string
+
+	// Probability per sample interval of an interface flap, a routing neighbor going down
+	// and a CRC errors burst on a physical interface
+	FlapProbability     float64
+	PeerDownProbability float64
+	CRCBurstProbability float64
+
+	// Seed of the devices random state so runs can be reproduced
+	Seed int64
+}
+
+// Stats represents the simulator counters
+type Stats struct {
+	Messages    uint64
+	SendErrors  uint64
+	Connections uint64
+}
+
+// Simulator streams the Telemetry of the simulated devices to a collector
+type Simulator struct {
+	settings Settings
+	client   mdt_dialout.GRPCMdtDialoutClient
+	paths    map[string]bool
+
+	messages    uint64
+	sendErrors  uint64
+	connections uint64
+}
+
+// New returns a Simulator streaming over the gRPC connection to the collector
+func New(s Settings, conn *grpc.ClientConn) (*Simulator, error) {
+
+	if s.Devices < 1 || s.Interfaces < 1 || s.Interval <= 0 {
+		return nil, fmt.Errorf("devices, interfaces and interval must be positive")
+	}
+
+	paths := make(map[string]bool)
+
+	for _, name := range s.Paths {
+
+		path, ok := Paths[name]
+
+		if !ok {
+			return nil, fmt.Errorf("unsupported path %v, expecting one of %v", name, PathNames())
+		}
+		paths[path] = true
+	}
+
+	if len(paths) == 0 {
+		for _, path := range Paths {
+			paths[path] = true
+		}
+	}
+
+	return &Simulator{
+		settings: s,
+		client:   mdt_dialout.NewGRPCMdtDialoutClient(conn),
+		paths:    paths,
+	}, nil
+}
+
+// PathNames returns the sorted names of the paths the simulator can stream
+func PathNames() []string {
+
+	names := make([]string, 0, len(Paths))
+
+	for name := range Paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Stats returns the simulator counters
+func (sim *Simulator) Stats() Stats {
+
+	return Stats{
+		Messages:    atomic.LoadUint64(&sim.messages),
+		SendErrors:  atomic.LoadUint64(&sim.sendErrors),
+		Connections: atomic.LoadUint64(&sim.connections),
+	}
+}
+
+// Run streams the Telemetry of every device until the context is cancelled
+func (sim *Simulator) Run(ctx context.Context) {
+
+	wg := sync.WaitGroup{}
+	now := time.Now()
+
+	// Spread the devices over the sample interval like a real network
+	offsets := rand.New(rand.NewSource(sim.settings.Seed))
+
+	for i := 0; i < sim.settings.Devices; i++ {
+
+		d := newDevice(i, fmt.Sprintf("%v-%04d", sim.settings.NodePrefix, i+1), sim.settings, now)
+		offset := time.Duration(offsets.Int63n(int64(sim.settings.Interval)))
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			sim.runDevice(ctx, d, offset)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// runDevice streams the samples of a device, re-establishing the dial-out stream when the collector closes it
+func (sim *Simulator) runDevice(ctx context.Context, d *device, offset time.Duration) {
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(offset):
+	}
+
+	ticker := time.NewTicker(sim.settings.Interval)
+	defer ticker.Stop()
+
+	var stream mdt_dialout.GRPCMdtDialout_MdtDialoutClient
+	var reqID int64
+
+	for {
+		now := time.Now()
+		d.step(sim.settings, sim.settings.Interval, now)
+
+		if stream == nil {
+
+			var err error
+
+			if stream, err = sim.client.MdtDialout(ctx); err != nil {
+
+				if ctx.Err() == nil {
+					logging.PeppaMonLog(
+						"error",
+						"Simulated device %v failed to open dial-out stream: %v", d.name, err)
+				}
+				stream = nil
+			} else {
+				atomic.AddUint64(&sim.connections, 1)
+			}
+		}
+
+		if stream != nil {
+			for _, msg := range d.messages(sim.paths, now) {
+
+				data, err := msg.Marshal()
+
+				if err != nil {
+					logging.PeppaMonLog(
+						"error",
+						"Simulated device %v failed to marshal %v message: %v", d.name, msg.GetEncodingPath(), err)
+					continue
+				}
+
+				reqID++
+
+				if err = stream.Send(&mdt_dialout.MdtDialoutArgs{ReqId: reqID, Data: data}); err != nil {
+
+					atomic.AddUint64(&sim.sendErrors, 1)
+
+					// Collector status explains why the stream was closed
+					if _, errRecv := stream.Recv(); errRecv != nil {
+						err = errRecv
+					}
+
+					if ctx.Err() == nil {
+						logging.PeppaMonLog(
+							"error",
+							"Simulated device %v dial-out stream closed: %v", d.name, err)
+					}
+
+					// Reconnect on next sample like a device would
+					stream = nil
+					break
+				}
+
+				atomic.AddUint64(&sim.messages, 1)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if stream != nil {
+				_ = stream.CloseSend()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/lucabrasi83/peppamon_cisco/config"
+	"github.com/lucabrasi83/peppamon_cisco/influxdb"
+	"github.com/lucabrasi83/peppamon_cisco/kafkasink"
+	"github.com/lucabrasi83/peppamon_cisco/logging"
+	"github.com/lucabrasi83/peppamon_cisco/metrics"
+	"github.com/lucabrasi83/peppamon_cisco/otlp"
+	"github.com/lucabrasi83/peppamon_cisco/remotewrite"
+	"github.com/lucabrasi83/peppamon_cisco/selfmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// enabledOutputSinks returns the output sinks listed in outputs.sinks.
+// When empty, the Prometheus scrape endpoint is enabled along with the sinks whose endpoint is configured.
+func enabledOutputSinks(
+	o config.Outputs,
+	remoteWriteSettings remotewrite.Settings,
+	influxSettings influxdb.Settings,
+	kafkaSettings kafkasink.Settings,
+	otlpSettings otlp.Settings) map[string]bool {
+
+	sinks := make(map[string]bool)
+
+	if len(o.Sinks) == 0 {
+		sinks[config.SinkPrometheus] = true
+		sinks[config.SinkRemoteWrite] = remoteWriteSettings.Enabled()
+		sinks[config.SinkInfluxDB] = influxSettings.HTTPEnabled()
+		sinks[config.SinkInfluxDBFile] = influxSettings.FileEnabled()
+		sinks[config.SinkKafka] = kafkaSettings.Enabled()
+		sinks[config.SinkOTLP] = otlpSettings.Enabled()
+
+		return sinks
+	}
+
+	// Sink names and their required settings are checked by the configuration validation
+	for _, name := range o.Sinks {
+		sinks[name] = true
+	}
+
+	return sinks
+}
+
+// setupOutputSinks registers the enabled output sinks to the Collector and starts their background routines.
+// The returned WaitGroup is done once the sinks flushed their queues after the context is cancelled.
+func setupOutputSinks(ctx context.Context, cfg *config.Config) *sync.WaitGroup {
+
+	wg := &sync.WaitGroup{}
+
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+
+	remoteWriteSettings := remotewrite.SettingsFromConfig(cfg.Outputs.RemoteWrite)
+	influxSettings := influxdb.SettingsFromConfig(cfg.Outputs.InfluxDB)
+	kafkaSettings := kafkasink.SettingsFromConfig(cfg.Outputs.Kafka)
+	otlpSettings := otlp.SettingsFromConfig(cfg.Outputs.OTLP)
+
+	sinks := enabledOutputSinks(cfg.Outputs, remoteWriteSettings, influxSettings, kafkaSettings, otlpSettings)
+
+	// Prometheus metrics cache exposed on the scrape endpoint
+	if sinks[config.SinkPrometheus] {
+
+		promSink := metrics.NewPrometheusSink()
+
+		prometheus.MustRegister(promSink)
+		collector.AddSink(promSink)
+
+		selfmetrics.AddCacheSize("prometheus_sink", promSink.Size)
+
+		// Evict metrics of YANG paths the devices stopped streaming
+		go promSink.StartExpiry(ctx, metrics.ExpirySettingsFromConfig(cfg.Metrics.TTL))
+
+		logging.PeppaMonLog(
+			"info",
+			"Prometheus scrape endpoint output sink enabled")
+	}
+
+	// Push metrics samples to a Prometheus remote write endpoint
+	if sinks[config.SinkRemoteWrite] {
+
+		remoteWriter := remotewrite.NewWriter(remoteWriteSettings)
+		collector.AddSink(remoteWriter)
+
+		run(func() { remoteWriter.Run(ctx) })
+
+		logging.PeppaMonLog(
+			"info",
+			"Prometheus remote write output sink enabled towards %v", remoteWriteSettings.URL)
+	}
+
+	// Write metrics samples in line protocol to InfluxDB
+	if sinks[config.SinkInfluxDB] {
+
+		influxWriter := influxdb.NewHTTPWriter(influxSettings)
+		collector.AddSink(influxWriter)
+
+		run(func() { influxWriter.Run(ctx) })
+
+		logging.PeppaMonLog(
+			"info",
+			"InfluxDB output sink enabled towards %v", influxSettings.URL)
+	}
+
+	// Append metrics samples in line protocol to a file
+	if sinks[config.SinkInfluxDBFile] {
+
+		fileWriter, err := influxdb.NewFileWriter(influxSettings)
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Failed to open InfluxDB line protocol file %v", err)
+		}
+		collector.AddSink(fileWriter)
+
+		run(func() {
+			fileWriter.Run(ctx)
+
+			if errClose := fileWriter.Close(); errClose != nil {
+				logging.PeppaMonLog(
+					"error",
+					"Failed to close InfluxDB line protocol file %v", errClose)
+			}
+		})
+
+		logging.PeppaMonLog(
+			"info",
+			"InfluxDB line protocol file output sink enabled towards %v", influxSettings.File)
+	}
+
+	// Produce decoded Telemetry messages and metrics samples to Kafka
+	if sinks[config.SinkKafka] {
+
+		producer, err := kafkasink.NewProducer(kafkaSettings)
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Invalid Kafka output sink settings %v", err)
+		}
+
+		kafkaSink := kafkasink.NewSink(kafkaSettings, producer)
+
+		prometheus.MustRegister(kafkasink.RecordsCounter)
+		collector.AddSink(kafkaSink)
+		collector.AddMessageSink(kafkaSink)
+
+		run(func() { kafkaSink.Run(ctx) })
+
+		logging.PeppaMonLog(
+			"info",
+			"Kafka output sink enabled towards %v", strings.Join(kafkaSettings.Brokers, ","))
+	}
+
+	// Export metrics samples to an OpenTelemetry OTLP receiver
+	if sinks[config.SinkOTLP] {
+
+		otlpExporter, err := otlp.NewExporter(otlpSettings)
+
+		if err != nil {
+			logging.PeppaMonLog(
+				"fatal",
+				"Invalid OTLP output sink settings %v", err)
+		}
+		collector.AddSink(otlpExporter)
+
+		run(func() {
+			otlpExporter.Run(ctx)
+
+			if errClose := otlpExporter.Close(); errClose != nil {
+				logging.PeppaMonLog(
+					"error",
+					"Failed to close OTLP gRPC connection %v", errClose)
+			}
+		})
+
+		logging.PeppaMonLog(
+			"info",
+			"OTLP %v output sink enabled towards %v", otlpSettings.Protocol, otlpSettings.Endpoint)
+	}
+
+	return wg
+}